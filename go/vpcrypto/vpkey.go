@@ -105,6 +105,50 @@ func ImportPubKey(key []byte) (*Key, error) {
 	return &pubKey, nil
 }
 
+// ExportPriv exports the private key of a key pair, encrypted with
+// a passphrase. The passphrase must be at least 16 bytes long.
+func (key Key) ExportPriv(passphrase []byte) ([]byte, error) {
+	var byteWriter bytes.Buffer
+	var err error
+
+	if key.entity.PrivateKey == nil {
+		return nil, errors.New("no private key")
+	}
+	err = key.entity.SerializePrivate(&byteWriter, nil)
+	if err != nil {
+		return nil, vperror.Chain(err, "unable to serialize private key")
+	}
+
+	return SymEncrypt(byteWriter.Bytes(), passphrase)
+}
+
+// ImportPrivKey creates a key from an exported private key, which
+// is decrypted using the passphrase it has been exported with.
+func ImportPrivKey(key, passphrase []byte) (*Key, error) {
+	var packetReader *packet.Reader
+	var err error
+	var privKey Key
+	var entity *openpgp.Entity
+	var content []byte
+
+	content, err = SymDecrypt(key, passphrase)
+	if err != nil {
+		return nil, vperror.Chain(err, "unable to decrypt private key")
+	}
+	packetReader = packet.NewReader(bytes.NewReader(content))
+	entity, err = openpgp.ReadEntity(packetReader)
+	if err != nil {
+		return nil, vperror.Chain(err, "unable to read entity from private key")
+	}
+	if entity.PrivateKey == nil {
+		return nil, errors.New("no private key")
+	}
+
+	privKey.entity = entity
+
+	return &privKey, nil
+}
+
 // Sign signs a content with a key.
 // Note that the key must contain a private key, it is not possible
 // to sign with a public key.
@@ -73,6 +73,44 @@ func TestExportImport(t *testing.T) {
 	}
 }
 
+func TestExportImportPriv(t *testing.T) {
+	passphrase := []byte("this is a long enough passphrase")
+
+	buf, err := benchKey.ExportPriv(passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("len of exported private key is %d", len(buf))
+	_, err = ImportPrivKey(buf, []byte("this is a wrong passphrase"))
+	if err == nil {
+		t.Error("private key imported with a wrong passphrase")
+	}
+	key, err := ImportPrivKey(buf, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := key.Sign(benchContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = benchKey.CheckSig(benchContent, sig)
+	if err != nil {
+		t.Error("imported key does not sign like the original one", err)
+	}
+	pubBuf, err := benchKey.ExportPub()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey, err := ImportPubKey(pubBuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = pubKey.ExportPriv(passphrase)
+	if err == nil {
+		t.Error("exported a private key from a public key")
+	}
+}
+
 func TestSig(t *testing.T) {
 	var key1 *Key
 	var buf []byte
@@ -261,3 +261,9 @@ func LogGetPriority() Priority {
 func LogFlush() {
 	getGlobalLog(PackageTarname).Flush()
 }
+
+// GlobalLogger returns the default global logging backend, this is
+// usefull to pass it to code which expects a Logger interface.
+func GlobalLogger() Logger {
+	return getGlobalLog(PackageTarname)
+}
@@ -0,0 +1,43 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"time"
+)
+
+// Clock gives the time used by nodes to track peers and challenges.
+// The default is the system clock, simulations can replace it by
+// a virtual clock, to run things in a reproducible way.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (c systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock returns a clock which gives the system time.
+func SystemClock() Clock {
+	return systemClock{}
+}
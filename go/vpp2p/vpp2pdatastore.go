@@ -0,0 +1,159 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sync"
+	"time"
+)
+
+// dataEntry is a value stored on a node, along with its expiration date.
+type dataEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// dataStore is the local key/value store of a node.
+type dataStore struct {
+	access  sync.RWMutex
+	entries map[[vpp2pdat.NodeIDBufNbBytes]byte]*dataEntry
+	clock   Clock
+}
+
+func newDataStore(clock Clock) *dataStore {
+	return &dataStore{entries: make(map[[vpp2pdat.NodeIDBufNbBytes]byte]*dataEntry), clock: clock}
+}
+
+// put stores a value, or refreshes it if it already exists.
+// It's thread-safe.
+func (ds *dataStore) put(key, value []byte, lifetime time.Duration) {
+	entry := dataEntry{value: make([]byte, len(value)), expires: ds.clock.Now().Add(lifetime)}
+	copy(entry.value, value)
+
+	defer ds.access.Unlock()
+	ds.access.Lock()
+
+	ds.entries[vpp2pdat.NodeIDToBuf(key)] = &entry
+}
+
+// get returns a value, expired entries are ignored.
+// It's thread-safe.
+func (ds *dataStore) get(key []byte) ([]byte, bool) {
+	defer ds.access.RUnlock()
+	ds.access.RLock()
+
+	entry := ds.entries[vpp2pdat.NodeIDToBuf(key)]
+	if entry == nil || ds.clock.Now().After(entry.expires) {
+		return nil, false
+	}
+	ret := make([]byte, len(entry.value))
+	copy(ret, entry.value)
+
+	return ret, true
+}
+
+// entry returns a value along with the time left before it expires,
+// expired entries are ignored.
+// It's thread-safe.
+func (ds *dataStore) entry(key []byte) ([]byte, time.Duration, bool) {
+	now := ds.clock.Now()
+
+	defer ds.access.RUnlock()
+	ds.access.RLock()
+
+	entry := ds.entries[vpp2pdat.NodeIDToBuf(key)]
+	if entry == nil || now.After(entry.expires) {
+		return nil, 0, false
+	}
+	ret := make([]byte, len(entry.value))
+	copy(ret, entry.value)
+
+	return ret, entry.expires.Sub(now), true
+}
+
+// delete removes a value, returns true if it was there.
+// It's thread-safe.
+func (ds *dataStore) delete(key []byte) bool {
+	keyBuf := vpp2pdat.NodeIDToBuf(key)
+
+	defer ds.access.Unlock()
+	ds.access.Lock()
+
+	_, ok := ds.entries[keyBuf]
+	delete(ds.entries, keyBuf)
+
+	return ok
+}
+
+// purge removes all expired entries, returns the number of removed entries.
+// It's thread-safe.
+func (ds *dataStore) purge() int {
+	ret := 0
+	now := ds.clock.Now()
+
+	defer ds.access.Unlock()
+	ds.access.Lock()
+
+	for k, v := range ds.entries {
+		if now.After(v.expires) {
+			delete(ds.entries, k)
+			ret++
+		}
+	}
+
+	return ret
+}
+
+// list returns copies of all the keys and values which have not expired,
+// values being in the same order than keys.
+// It's thread-safe.
+func (ds *dataStore) list() ([][]byte, [][]byte) {
+	now := ds.clock.Now()
+
+	defer ds.access.RUnlock()
+	ds.access.RLock()
+
+	keys := make([][]byte, 0, len(ds.entries))
+	values := make([][]byte, 0, len(ds.entries))
+	for k, v := range ds.entries {
+		if now.After(v.expires) {
+			continue
+		}
+		key := make([]byte, len(k))
+		copy(key, k[:])
+		value := make([]byte, len(v.value))
+		copy(value, v.value)
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	return keys, values
+}
+
+// len returns the number of entries, including expired ones
+// which have not been purged yet.
+// It's thread-safe.
+func (ds *dataStore) len() int {
+	defer ds.access.RUnlock()
+	ds.access.RLock()
+
+	return len(ds.entries)
+}
@@ -0,0 +1,81 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+	"time"
+)
+
+func TestDataStore(t *testing.T) {
+	ds := newDataStore(SystemClock())
+	key1 := vpsum.Checksum256([]byte("key1"))
+	key2 := vpsum.Checksum256([]byte("key2"))
+	value := []byte("value")
+
+	ds.put(key1, value, time.Hour)
+	ds.put(key2, value, -time.Second)
+	got, ok := ds.get(key1)
+	if !ok || bytes.Compare(got, value) != 0 {
+		t.Error("unable to get value")
+	}
+	_, ok = ds.get(key2)
+	if ok {
+		t.Error("got an expired value")
+	}
+	keys, values := ds.list()
+	if len(keys) != 1 || len(values) != 1 || bytes.Compare(keys[0], key1) != 0 || bytes.Compare(values[0], value) != 0 {
+		t.Error("bad list")
+	}
+	if ds.purge() != 1 || ds.len() != 1 {
+		t.Error("bad purge")
+	}
+	if !ds.delete(key1) {
+		t.Error("unable to delete value")
+	}
+	if ds.delete(key1) {
+		t.Error("deleted a value twice")
+	}
+	if ds.len() != 0 {
+		t.Error("store should be empty")
+	}
+}
+
+func TestDataStoreClock(t *testing.T) {
+	clock := &testHostInfoClock{now: time.Unix(1000000000, 0)}
+	ds := newDataStore(clock)
+	key := vpsum.Checksum256([]byte("key"))
+
+	ds.put(key, []byte("value"), time.Hour)
+	_, ttl, ok := ds.entry(key)
+	if !ok || ttl != time.Hour {
+		t.Error("bad entry lifetime", ttl)
+	}
+	clock.now = clock.now.Add(2 * time.Hour)
+	_, ok = ds.get(key)
+	if ok {
+		t.Error("value not expired when the clock moved forward")
+	}
+	if ds.purge() != 1 {
+		t.Error("expired value not purged")
+	}
+}
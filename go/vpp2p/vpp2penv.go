@@ -0,0 +1,98 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"github.com/ufoot/vapor/go/vplog"
+	"sync"
+)
+
+// Env is an environment, it owns the catalogs, the remote hosts pool,
+// the logger and the transport shared by a set of hosts, rings and nodes.
+// Hosts, rings and nodes created within different environments do not
+// see each other, so that tests and simulations can each have their
+// own isolated world.
+type Env struct {
+	hostInfoCatalog *HostInfoCatalog
+	remoteHostPool  *RemoteHostPool
+	nodeCatalog     *NodeCatalog
+
+	loggerAccess sync.RWMutex
+	logger       vplog.Logger
+}
+
+// NewEnv creates a new environment, with empty catalogs, using
+// the default global logger, and calling local hosts directly.
+func NewEnv() *Env {
+	var ret Env
+
+	ret.hostInfoCatalog = NewHostInfoCatalog()
+	ret.remoteHostPool = NewRemoteHostPool(ret.hostInfoCatalog)
+	ret.nodeCatalog = NewNodeCatalogWithRemotes(ret.hostInfoCatalog, ret.remoteHostPool)
+
+	return &ret
+}
+
+// HostInfoCatalog returns the catalog of all hosts known within the env.
+func (env *Env) HostInfoCatalog() *HostInfoCatalog {
+	return env.hostInfoCatalog
+}
+
+// RemoteHostPool returns the pool used to contact hosts which
+// are not in this process.
+func (env *Env) RemoteHostPool() *RemoteHostPool {
+	return env.remoteHostPool
+}
+
+// NodeCatalog returns the catalog containing all local nodes of the env.
+func (env *Env) NodeCatalog() *NodeCatalog {
+	return env.nodeCatalog
+}
+
+// SetTransport sets the transport used to reach local hosts. Passing
+// nil restores the default, which is to call local hosts directly.
+// It's thread-safe.
+func (env *Env) SetTransport(transport Transport) {
+	env.nodeCatalog.SetTransport(transport)
+}
+
+// SetLogger sets the logger used by hosts and nodes of the env.
+// Passing nil restores the default global logger.
+// It's thread-safe.
+func (env *Env) SetLogger(logger vplog.Logger) {
+	defer env.loggerAccess.Unlock()
+	env.loggerAccess.Lock()
+
+	env.logger = logger
+}
+
+// Logger returns the logger used by hosts and nodes of the env.
+// It's thread-safe.
+func (env *Env) Logger() vplog.Logger {
+	env.loggerAccess.RLock()
+	logger := env.logger
+	env.loggerAccess.RUnlock()
+
+	if logger == nil {
+		return vplog.GlobalLogger()
+	}
+
+	return logger
+}
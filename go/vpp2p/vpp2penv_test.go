@@ -0,0 +1,77 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"testing"
+)
+
+func TestEnv(t *testing.T) {
+	var envs [2]*Env
+	var hosts [2]*Host
+	var rings [2]*Ring
+	var nodes [2]*Node
+	var err error
+
+	for i := range envs {
+		envs[i] = NewEnv()
+		hosts[i], err = NewHost(envs[i], testTitle, testURL+"/env", false)
+		if err != nil {
+			t.Fatal("unable to create host", err)
+		}
+		rings[i], err = NewRing(envs[i], hosts[i], testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+		if err != nil {
+			t.Fatal("unable to create ring", err)
+		}
+		nodes[i], err = NewNode(envs[i], hosts[i], rings[i], nil)
+		if err != nil {
+			t.Fatal("unable to create node", err)
+		}
+		defer nodes[i].Stop()
+		nodes[i].Start()
+	}
+
+	for i := range envs {
+		j := 1 - i
+		if !envs[i].NodeCatalog().HasNode(nodes[i].Status.Info.NodeID) {
+			t.Error("node not registered within its env")
+		}
+		if envs[i].NodeCatalog().HasNode(nodes[j].Status.Info.NodeID) {
+			t.Error("node registered within another env")
+		}
+		if len(envs[i].NodeCatalog().List()) != 1 {
+			t.Error("bad number of nodes in env")
+		}
+	}
+
+	_, err = NewRing(envs[0], hosts[1], testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err == nil {
+		t.Error("ring created with a host from another env")
+	}
+	_, err = NewNode(envs[0], hosts[0], rings[1], nil)
+	if err == nil {
+		t.Error("node created with a ring from another env")
+	}
+	_, err = NewNode(envs[0], hosts[1], rings[0], nil)
+	if err == nil {
+		t.Error("node created with a host from another env")
+	}
+}
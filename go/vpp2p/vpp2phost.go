@@ -24,11 +24,14 @@ import (
 	"github.com/ufoot/vapor/go/vpapp"
 	"github.com/ufoot/vapor/go/vpcommonapi"
 	"github.com/ufoot/vapor/go/vpcrypto"
+	"github.com/ufoot/vapor/go/vperror"
+	"github.com/ufoot/vapor/go/vplog"
 	"github.com/ufoot/vapor/go/vpp2papi"
 	"github.com/ufoot/vapor/go/vpp2pdat"
 	"github.com/ufoot/vapor/go/vprand"
 	"github.com/ufoot/vapor/go/vpsum"
 	"github.com/ufoot/vapor/go/vptimeout"
+	"sync"
 	"time"
 )
 
@@ -38,29 +41,36 @@ type Host struct {
 	// Info about the host
 	Info vpp2papi.HostInfo
 
+	env     *Env
 	creator HostsRefsCreator
 
 	key              *vpcrypto.Key
 	localNodeCatalog *NodeCatalog
 	startTime        time.Time
+	limiter          *Limiter
+	secure           *secureStore
+
+	capacityAccess sync.RWMutex
+	capacity       float64
 }
 
-// NewHost returns a new host object
-func NewHost(title, url string, useSig bool, creator HostsRefsCreator) (*Host, error) {
-	var ret Host
+// NewHost returns a new host object, living within env.
+func NewHost(env *Env, title, url string, useSig bool) (*Host, error) {
+	var info vpp2papi.HostInfo
+	var key *vpcrypto.Key
 	var pubKey []byte
 	var sig []byte
 	var err error
 	var ok bool
 
-	ret.Info = vpp2papi.HostInfo{HostTitle: title, HostURL: url, HostPubKey: nil, HostSig: nil}
+	info = vpp2papi.HostInfo{HostTitle: title, HostURL: url, HostPubKey: nil, HostSig: nil}
 
 	if useSig {
-		ret.key, err = vpcrypto.NewKey()
+		key, err = vpcrypto.NewKey()
 		if err != nil {
 			return nil, err
 		}
-		pubKey, err = ret.key.ExportPub()
+		pubKey, err = key.ExportPub()
 		if err != nil {
 			return nil, err
 		}
@@ -68,7 +78,7 @@ func NewHost(title, url string, useSig bool, creator HostsRefsCreator) (*Host, e
 		if err != nil || !ok {
 			return nil, err
 		}
-		sig, err = ret.key.Sign(vpp2pdat.HostInfoSigBytes(&(ret.Info)))
+		sig, err = key.Sign(vpp2pdat.HostInfoSigBytes(&info))
 		if err != nil {
 			return nil, err
 		}
@@ -81,21 +91,85 @@ func NewHost(title, url string, useSig bool, creator HostsRefsCreator) (*Host, e
 		sig = []byte("")
 	}
 
-	ret.Info.HostPubKey = pubKey
-	ret.Info.HostSig = sig
+	info.HostPubKey = pubKey
+	info.HostSig = sig
+
+	return newHost(env, &info, key)
+}
+
+// newHost builds a host object from its info and its key, which
+// is nil if the host does not sign. This is shared by NewHost and
+// LoadHost, so that restored hosts are set up just like new ones.
+func newHost(env *Env, info *vpp2papi.HostInfo, key *vpcrypto.Key) (*Host, error) {
+	var ret Host
+	var err error
 
-	_, err = vpp2pdat.CheckHostInfo(&(ret.Info))
+	_, err = vpp2pdat.CheckHostInfo(info)
 	if err != nil {
 		return nil, err
 	}
 
-	ret.creator = creator
+	ret.Info = *info
+	ret.key = key
+	ret.env = env
+	ret.creator = env.hostInfoCatalog
 	ret.localNodeCatalog = NewNodeCatalog()
 	ret.startTime = time.Now()
+	ret.capacity = DefaultCapacity
+	ret.limiter, err = NewLimiter(nil)
+	if err != nil {
+		return nil, err
+	}
+	ret.secure = newSecureStore(SystemClock())
 
 	return &ret, nil
 }
 
+// Env returns the environment the host lives in.
+func (host *Host) Env() *Env {
+	return host.env
+}
+
+// SetCapacity declares the capacity of the host, relatively to a
+// typical host which has a capacity of 1. Node managers use it to
+// decide how many nodes to run on each ring.
+// It's thread-safe.
+func (host *Host) SetCapacity(capacity float64) error {
+	if capacity <= 0 {
+		return fmt.Errorf("bad capacity %f, should be greater than 0", capacity)
+	}
+
+	defer host.capacityAccess.Unlock()
+	host.capacityAccess.Lock()
+
+	host.capacity = capacity
+
+	return nil
+}
+
+// Capacity returns the declared capacity of the host.
+// It's thread-safe.
+func (host *Host) Capacity() float64 {
+	defer host.capacityAccess.RUnlock()
+	host.capacityAccess.RLock()
+
+	return host.capacity
+}
+
+// SetClock sets the clock used to expire secure sessions, and to
+// refill the token buckets of the limiter.
+// Must be called before the host handles any call.
+func (host *Host) SetClock(clock Clock) {
+	host.secure.clock = clock
+	host.limiter.SetClock(clock)
+}
+
+// Limiter returns the limiter which bounds the calls made to the host
+// by remote callers, it can be used to change the limits.
+func (host *Host) Limiter() *Limiter {
+	return host.limiter
+}
+
 // CanSign returns true if the host has a key it can sign with.
 func (host *Host) CanSign() bool {
 	return host.key != nil
@@ -112,6 +186,78 @@ func (host *Host) CheckSig() (bool, error) {
 	return vpp2pdat.HostInfoCheckSig(&host.Info)
 }
 
+// registerSourceHost records the host of a remote caller, if the refs
+// creator is able to do so, so that the caller can be contacted back.
+// Hosts which do not pass the catalog checks are ignored.
+func (host *Host) registerSourceHost(context *vpp2papi.ContextInfo) {
+	registerer, ok := host.creator.(interface {
+		RegisterHost(*vpp2papi.HostInfo) error
+	})
+	if !ok || context.SourceHost == nil || context.SourceNode == nil {
+		return
+	}
+	if host.env.nodeCatalog.GetNode(context.SourceNode.NodeID) != nil {
+		return
+	}
+	err := registerer.RegisterHost(context.SourceHost)
+	if err != nil {
+		vplog.LoggerDebug(host.env.Logger(), "unable to register source host", err)
+	}
+}
+
+// admit checks the limits before handling a call. Calls made by the
+// local nodes of this host are not limited, they're part of what the
+// host does on its own. Those calls are recognized because their source
+// host info is the host struct itself, which a request decoded from
+// the network can never point to, whatever it contains. Nodes of other
+// hosts are limited, even when they live in the same env, as in
+// simulations, and so are remote callers claiming to be this host.
+func (host *Host) admit(context *vpp2papi.ContextInfo) (*callSlot, error) {
+	var release func()
+	var err error
+
+	switch {
+	case context == nil || context.SourceHost == nil:
+		release, err = host.limiter.Admit(nil)
+	case context.SourceHost == &(host.Info):
+		release = func() {}
+	default:
+		release, err = host.limiter.Admit(context.SourceHost.HostPubKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &callSlot{release: release}, nil
+}
+
+// callSlot is a place among the calls the limiter lets the host handle
+// at the same time. It is held until the handler returns, or, once the
+// call is run, until the call is over, which can be long after the
+// handler has returned on timeout.
+type callSlot struct {
+	release func()
+	running bool
+}
+
+// done releases the slot when the handler returns, unless the call
+// is running, in which case the call releases it when it's over.
+func (slot *callSlot) done() {
+	if !slot.running {
+		slot.release()
+	}
+}
+
+// run runs f within timeout, and releases the slot when f is over.
+func (slot *callSlot) run(f func() error, timeout time.Duration) error {
+	slot.running = true
+
+	return vptimeout.Run(func() error {
+		defer slot.release()
+		return f()
+	}, timeout)
+}
+
 // Ping is a simple ping function
 func (host *Host) Ping() error {
 	return nil
@@ -132,6 +278,28 @@ func (host *Host) GetVersion() (*vpcommonapi.Version, error) {
 	return vpapp.DefaultVersion(), nil
 }
 
+// Handshake is called by hosts contacting this one for the first time,
+// to agree on a protocol version and capabilities. Incompatible callers
+// get a vpp2pdat.IncompatibleError, and are expected to give up.
+// The handshake is advisory: the host does not remember who did it,
+// and serves calls from peers which skipped it, relying on the checks
+// each call does anyway. It only spares compatible peers calls they
+// could not interpret, and lets incompatible ones give up early.
+func (host *Host) Handshake(request *vpp2papi.HandshakeRequest) (*vpp2papi.HandshakeResponse, error) {
+	ret := vpp2papi.NewHandshakeResponse()
+	ret.Info = vpp2pdat.DefaultHandshakeInfo()
+
+	protocol, capabilities, err := vpp2pdat.Negotiate(ret.Info, request.Info)
+	if err != nil {
+		vplog.LoggerDebug(host.env.Logger(), "refusing handshake", err)
+		return nil, err
+	}
+	ret.Protocol = protocol
+	ret.Capabilities = capabilities
+
+	return ret, nil
+}
+
 func (host *Host) localNodeStatus() []*vpp2papi.NodeStatus {
 	localNodes := host.localNodeCatalog.ListPtr()
 
@@ -148,12 +316,17 @@ func (host *Host) localNodeStatus() []*vpp2papi.NodeStatus {
 	return ret
 }
 
-// Status is called to get another host status.
+// Status is called to get another host status. RingsRefs contains
+// the rings local nodes are on, so that callers can interpret node IDs.
 func (host *Host) Status() (*vpp2papi.HostStatus, error) {
 	ret := vpp2papi.NewHostStatus()
 
 	ret.ThisHostInfo = &(host.Info)
 	ret.LocalNodeStatus = host.localNodeStatus()
+	ret.RingsRefs = make(map[string]*vpp2papi.RingInfo)
+	for _, localNode := range host.localNodeCatalog.ListPtr() {
+		ret.RingsRefs[vpp2pdat.RingIDToShortString(localNode.ringPtr.Info.RingID)] = &(localNode.ringPtr.Info)
+	}
 
 	nodesList := make([]*vpp2papi.NodeInfo, 0)
 	for _, localNode := range ret.LocalNodeStatus {
@@ -169,6 +342,54 @@ func (host *Host) Status() (*vpp2papi.HostStatus, error) {
 	} else {
 		ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
 	}
+	// peers running within the same env are called directly, so their
+	// hosts are not necessarily in the catalog, add them anyway
+	for _, nodeInfo := range nodesList {
+		if nodeInfo == nil {
+			continue
+		}
+		peer := host.env.nodeCatalog.GetNode(nodeInfo.NodeID)
+		if peer == nil {
+			continue
+		}
+		key := vpp2pdat.HostPubKeyToShortString(peer.hostPtr.Info.HostPubKey)
+		if ret.HostsRefs[key] == nil {
+			ret.HostsRefs[key] = &(peer.hostPtr.Info)
+		}
+	}
+
+	return ret, nil
+}
+
+// Challenge returns a challenge, which the caller must use to authenticate
+// its next request on the target node, if the ring has a password.
+func (host *Host) Challenge(request *vpp2papi.ChallengeRequest) (*vpp2papi.ChallengeResponse, error) {
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+
+	err = node.checkSig(request.Context, vpp2pdat.ChallengeRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := vpp2papi.NewChallengeResponse()
+	ret.Challenge, err = node.newChallenge()
+	if err != nil {
+		return nil, err
+	}
 
 	return ret, nil
 }
@@ -177,7 +398,13 @@ func (host *Host) Status() (*vpp2papi.HostStatus, error) {
 func (host *Host) Lookup(request *vpp2papi.LookupRequest) (*vpp2papi.LookupResponse, error) {
 	var ret *vpp2papi.LookupResponse
 
-	_, err := vpp2pdat.CheckContextInfo(request.Context)
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
 	if err != nil {
 		return nil, err
 	}
@@ -186,6 +413,10 @@ func (host *Host) Lookup(request *vpp2papi.LookupRequest) (*vpp2papi.LookupRespo
 	if node == nil {
 		return nil, fmt.Errorf("unable to find target node locally")
 	}
+	err = node.checkAuth(request.Context, vpp2pdat.LookupRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
 
 	f := func() error {
 		var errF error
@@ -205,7 +436,61 @@ func (host *Host) Lookup(request *vpp2papi.LookupRequest) (*vpp2papi.LookupRespo
 		return nil
 	}
 
-	err = vptimeout.Run(f, node.ringPtr.callTimeout)
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// LookupMany is used to look up several keys at once.
+func (host *Host) LookupMany(request *vpp2papi.LookupManyRequest) (*vpp2papi.LookupManyResponse, error) {
+	var ret *vpp2papi.LookupManyResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckLookupEntries(request.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.LookupManyRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	f := func() error {
+		ret = vpp2papi.NewLookupManyResponse()
+		ret.Results = node.lookupMany(request.Entries)
+		if host.creator != nil {
+			nodesList := make([]*vpp2papi.NodeInfo, 0)
+			for _, v := range ret.Results {
+				if v.Found {
+					nodesList = append(nodesList, v.NodesPath...)
+				}
+			}
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), nil, nodesList)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
 
 	if err != nil {
 		return nil, err
@@ -218,7 +503,13 @@ func (host *Host) Lookup(request *vpp2papi.LookupRequest) (*vpp2papi.LookupRespo
 func (host *Host) GetSuccessors(request *vpp2papi.GetSuccessorsRequest) (*vpp2papi.GetSuccessorsResponse, error) {
 	var ret *vpp2papi.GetSuccessorsResponse
 
-	_, err := vpp2pdat.CheckContextInfo(request.Context)
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
 	if err != nil {
 		return nil, err
 	}
@@ -227,6 +518,10 @@ func (host *Host) GetSuccessors(request *vpp2papi.GetSuccessorsRequest) (*vpp2pa
 	if node == nil {
 		return nil, fmt.Errorf("unable to find target node locally")
 	}
+	err = node.checkAuth(request.Context, vpp2pdat.GetSuccessorsRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
 
 	f := func() error {
 		ret = vpp2papi.NewGetSuccessorsResponse()
@@ -240,7 +535,7 @@ func (host *Host) GetSuccessors(request *vpp2papi.GetSuccessorsRequest) (*vpp2pa
 		return nil
 	}
 
-	err = vptimeout.Run(f, node.ringPtr.callTimeout)
+	err = slot.run(f, node.ringPtr.callTimeout)
 
 	if err != nil {
 		return nil, err
@@ -254,7 +549,13 @@ func (host *Host) GetPredecessor(request *vpp2papi.GetPredecessorRequest) (*vpp2
 	var ret *vpp2papi.GetPredecessorResponse
 	var hostsRefs []*vpp2papi.NodeInfo
 
-	_, err := vpp2pdat.CheckContextInfo(request.Context)
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
 	if err != nil {
 		return nil, err
 	}
@@ -263,6 +564,10 @@ func (host *Host) GetPredecessor(request *vpp2papi.GetPredecessorRequest) (*vpp2
 	if node == nil {
 		return nil, fmt.Errorf("unable to find node locally")
 	}
+	err = node.checkAuth(request.Context, vpp2pdat.GetPredecessorRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
 
 	f := func() error {
 		ret = vpp2papi.NewGetPredecessorResponse()
@@ -281,7 +586,7 @@ func (host *Host) GetPredecessor(request *vpp2papi.GetPredecessorRequest) (*vpp2
 		return nil
 	}
 
-	err = vptimeout.Run(f, node.ringPtr.callTimeout)
+	err = slot.run(f, node.ringPtr.callTimeout)
 
 	if err != nil {
 		return nil, err
@@ -296,7 +601,13 @@ func (host *Host) Sync(request *vpp2papi.SyncRequest) (*vpp2papi.SyncResponse, e
 	var ret *vpp2papi.SyncResponse
 	var hostsRefs []*vpp2papi.NodeInfo
 
-	_, err := vpp2pdat.CheckContextInfo(request.Context)
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
 	if err != nil {
 		return nil, err
 	}
@@ -305,6 +616,11 @@ func (host *Host) Sync(request *vpp2papi.SyncRequest) (*vpp2papi.SyncResponse, e
 	if node == nil {
 		return nil, fmt.Errorf("unable to find node locally")
 	}
+	err = node.checkAuth(request.Context, vpp2pdat.SyncRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+	host.registerSourceHost(request.Context)
 
 	f := func() error {
 		ret = vpp2papi.NewSyncResponse()
@@ -332,7 +648,683 @@ func (host *Host) Sync(request *vpp2papi.SyncRequest) (*vpp2papi.SyncResponse, e
 		return nil
 	}
 
-	err = vptimeout.Run(f, node.ringPtr.callTimeout)
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Put is called to store a value on a ring. Replicas are only
+// accepted from the node holding the key.
+func (host *Host) Put(request *vpp2papi.PutRequest) (*vpp2papi.PutResponse, error) {
+	var ret *vpp2papi.PutResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckKey(request.Key)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckValue(request.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.PutRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+	if request.Replica {
+		err = node.checkKeyOwner(request.Context.SourceNode, request.Key, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f := func() error {
+		var errF error
+		var nbCopy int
+
+		ret = vpp2papi.NewPutResponse()
+		nbCopy, ret.NodesPath, errF = node.Put(request.Key, request.Value, request.Replica)
+		if errF != nil {
+			return errF
+		}
+		ret.NbCopy = int32(nbCopy)
+		if host.creator != nil {
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), nil, ret.NodesPath)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Get is called to retrieve a value from a ring.
+func (host *Host) Get(request *vpp2papi.GetRequest) (*vpp2papi.GetResponse, error) {
+	var ret *vpp2papi.GetResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckKey(request.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.GetRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	f := func() error {
+		var errF error
+
+		ret = vpp2papi.NewGetResponse()
+		ret.Found, ret.Value, ret.NodesPath, errF = node.Get(request.Key, request.Replica)
+		if errF != nil {
+			return errF
+		}
+		if host.creator != nil {
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), nil, ret.NodesPath)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Delete is called to remove a value from a ring. Replicas are only
+// removed on behalf of the node holding the key.
+func (host *Host) Delete(request *vpp2papi.DeleteRequest) (*vpp2papi.DeleteResponse, error) {
+	var ret *vpp2papi.DeleteResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckKey(request.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.DeleteRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+	if request.Replica {
+		err = node.checkKeyOwner(request.Context.SourceNode, request.Key, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f := func() error {
+		var errF error
+		var nbCopy int
+
+		ret = vpp2papi.NewDeleteResponse()
+		nbCopy, ret.NodesPath, errF = node.Delete(request.Key, request.Replica)
+		if errF != nil {
+			return errF
+		}
+		ret.NbCopy = int32(nbCopy)
+		if host.creator != nil {
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), nil, ret.NodesPath)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Merkle is called by a node to compare the entries it owns with the
+// copies held by one of its replicas, and to repair them.
+func (host *Host) Merkle(request *vpp2papi.MerkleRequest) (*vpp2papi.MerkleResponse, error) {
+	var ret *vpp2papi.MerkleResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckNodeID(request.RangeStart)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckNodeID(request.RangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckMerklePrefixes(request.Prefixes)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckDataEntries(request.Entries)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckWantedKeys(request.WantedKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.MerkleRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+	err = node.checkRangeOwner(request.Context.SourceNode, request.RangeStart, request.RangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	f := func() error {
+		ret = vpp2papi.NewMerkleResponse()
+		ret.Nodes, ret.Entries = node.merkle(request.RangeStart, request.RangeEnd, request.Prefixes, request.Entries, request.WantedKeys)
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Leave is called by a node which leaves the ring, so that the target
+// node can splice the ring.
+func (host *Host) Leave(request *vpp2papi.LeaveRequest) (*vpp2papi.LeaveResponse, error) {
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range request.SuccessorNodes {
+		_, err = vpp2pdat.CheckNodeInfo(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if request.PredecessorNode != nil {
+		_, err = vpp2pdat.CheckNodeInfo(request.PredecessorNode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.LeaveRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	node.peerLeft(request.Context.SourceNode, request.SuccessorNodes, request.PredecessorNode)
+
+	ret := vpp2papi.NewLeaveResponse()
+	ret.Done = true
+
+	return ret, nil
+}
+
+// AnnounceRing is called to publish a ring in a ring directory.
+func (host *Host) AnnounceRing(request *vpp2papi.AnnounceRingRequest) (*vpp2papi.AnnounceRingResponse, error) {
+	var ret *vpp2papi.AnnounceRingResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAnnouncedRing(request.Ring)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.AnnounceRingRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	f := func() error {
+		var errF error
+		var nbCopy int
+
+		ret = vpp2papi.NewAnnounceRingResponse()
+		nbCopy, ret.NodesPath, errF = node.AnnounceRing(request.Ring, request.Replica)
+		if errF != nil {
+			return errF
+		}
+		ret.NbCopy = int32(nbCopy)
+		if host.creator != nil {
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), nil, ret.NodesPath)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// ListRings is called to search a ring directory.
+func (host *Host) ListRings(request *vpp2papi.ListRingsRequest) (*vpp2papi.ListRingsResponse, error) {
+	var ret *vpp2papi.ListRingsResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	if len(request.AppID) > 0 {
+		_, err = vpp2pdat.CheckID(request.AppID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = vpp2pdat.CheckTitlePrefix(request.TitlePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.ListRingsRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	f := func() error {
+		var errF error
+
+		ret = vpp2papi.NewListRingsResponse()
+		ret.Rings, ret.NodesPath, errF = node.ListRings(request.AppID, request.TitlePrefix, request.Replica)
+		if errF != nil {
+			return errF
+		}
+		if host.creator != nil {
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), ret.Rings, ret.NodesPath)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Subscribe is called to subscribe a node to a topic.
+func (host *Host) Subscribe(request *vpp2papi.SubscribeRequest) (*vpp2papi.SubscribeResponse, error) {
+	var ret *vpp2papi.SubscribeResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckTopic(request.Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.SubscribeRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+	err = node.checkSubscriber(request.Context.SourceNode, request.Subscriber, request.Topic, request.Replica)
+	if err != nil {
+		return nil, err
+	}
+	// the node holding the topic needs to contact the subscriber
+	// later to deliver messages, so remember its host
+	host.registerSourceHost(request.Context)
+
+	f := func() error {
+		var errF error
+		var nbCopy int
+
+		ret = vpp2papi.NewSubscribeResponse()
+		nbCopy, ret.NodesPath, errF = node.subscribe(request.Topic, request.Subscriber, request.Replica)
+		if errF != nil {
+			return errF
+		}
+		ret.NbCopy = int32(nbCopy)
+		if host.creator != nil {
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), nil, ret.NodesPath)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Unsubscribe is called to unsubscribe a node from a topic.
+func (host *Host) Unsubscribe(request *vpp2papi.UnsubscribeRequest) (*vpp2papi.UnsubscribeResponse, error) {
+	var ret *vpp2papi.UnsubscribeResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckTopic(request.Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.UnsubscribeRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+	err = node.checkSubscriber(request.Context.SourceNode, request.Subscriber, request.Topic, request.Replica)
+	if err != nil {
+		return nil, err
+	}
+
+	f := func() error {
+		var errF error
+		var nbCopy int
+
+		ret = vpp2papi.NewUnsubscribeResponse()
+		nbCopy, ret.NodesPath, errF = node.unsubscribe(request.Topic, request.Subscriber, request.Replica)
+		if errF != nil {
+			return errF
+		}
+		ret.NbCopy = int32(nbCopy)
+		if host.creator != nil {
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), nil, ret.NodesPath)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Publish is called to send a message to the subscribers of a topic.
+func (host *Host) Publish(request *vpp2papi.PublishRequest) (*vpp2papi.PublishResponse, error) {
+	var ret *vpp2papi.PublishResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckTopic(request.Topic)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckMessage(request.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.PublishRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	f := func() error {
+		var errF error
+		var nbDelivered int
+
+		ret = vpp2papi.NewPublishResponse()
+		nbDelivered, ret.NodesPath, errF = node.publish(request.Topic, request.Message, request.Deliver)
+		if errF != nil {
+			return errF
+		}
+		ret.NbDelivered = int32(nbDelivered)
+		if host.creator != nil {
+			ret.HostsRefs = host.creator.CreateHostsRefs(&(host.Info), nil, ret.NodesPath)
+		} else {
+			ret.HostsRefs = make(map[string]*vpp2papi.HostInfo)
+		}
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// secureSessionKey returns the key of the session a secure message
+// is sent within. If the message carries an encrypted session key, it
+// is decrypted and recorded, else the session must be already known.
+// Returns nil if the session is unknown.
+func (host *Host) secureSessionKey(sourceHost *vpp2papi.HostInfo, sessionID, encryptedKey []byte) ([]byte, error) {
+	if len(encryptedKey) == 0 {
+		return host.secure.incomingKey(sessionID, sourceHost.HostPubKey), nil
+	}
+
+	key, err := host.key.Decrypt(encryptedKey)
+	if err != nil {
+		return nil, vperror.Chain(err, "unable to decrypt session key")
+	}
+	if len(key) != vpp2pdat.SessionKeyNbBytes {
+		return nil, fmt.Errorf("bad session key len=%d, should be %d", len(key), vpp2pdat.SessionKeyNbBytes)
+	}
+	err = host.secure.addIncoming(sessionID, key, sourceHost.HostPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// SecureMessage is called to send an encrypted message to a node.
+func (host *Host) SecureMessage(request *vpp2papi.SecureMessageRequest) (*vpp2papi.SecureMessageResponse, error) {
+	var ret *vpp2papi.SecureMessageResponse
+
+	slot, err := host.admit(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer slot.done()
+
+	_, err = vpp2pdat.CheckContextInfo(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckSessionID(request.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckSessionKey(request.SessionKey)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vpp2pdat.CheckEncryptedPayload(request.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	node := host.localNodeCatalog.GetNode(request.Context.TargetNodeID)
+	if node == nil {
+		return nil, fmt.Errorf("unable to find target node locally")
+	}
+	err = node.checkAuth(request.Context, vpp2pdat.SecureMessageRequestSigBytes(request), request.Sig)
+	if err != nil {
+		return nil, err
+	}
+	if !host.CanSign() {
+		return nil, fmt.Errorf("host has no key, unable to receive secure messages")
+	}
+
+	f := func() error {
+		var errF error
+		var key []byte
+		var payload []byte
+
+		ret = vpp2papi.NewSecureMessageResponse()
+		key, errF = host.secureSessionKey(request.Context.SourceHost, request.SessionID, request.SessionKey)
+		if errF != nil {
+			return errF
+		}
+		if key == nil {
+			ret.UnknownSession = true
+			return nil
+		}
+		payload, errF = vpcrypto.SymDecrypt(request.Payload, key)
+		if errF != nil {
+			return errF
+		}
+		ret.Delivered = node.receiveSecure(request.Context.SourceNode, payload)
+		return nil
+	}
+
+	err = slot.run(f, node.ringPtr.callTimeout)
 
 	if err != nil {
 		return nil, err
@@ -20,6 +20,9 @@
 package vpp2p
 
 import (
+	"github.com/ufoot/vapor/go/vpapp"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
 	"testing"
 )
 
@@ -27,7 +30,9 @@ const testTitle = "This is a title"
 const testURL = "http://thisisatestwebsite7896538.com"
 
 func TestNewHost(t *testing.T) {
-	host, err := NewHost(testTitle, testURL, true, GlobalHostInfoCatalog())
+	env := NewEnv()
+
+	host, err := NewHost(env, testTitle, testURL, true)
 	if err != nil {
 		t.Error("unable to create host with a valid pubKey", err)
 	}
@@ -44,7 +49,7 @@ func TestNewHost(t *testing.T) {
 		t.Error("failed to report a broken sig", err)
 	}
 
-	host, err = NewHost(testTitle, testURL, false, GlobalHostInfoCatalog())
+	host, err = NewHost(env, testTitle, testURL, false)
 	if err != nil {
 		t.Error("unable to create host with a dummy pubKey", err)
 	}
@@ -56,3 +61,26 @@ func TestNewHost(t *testing.T) {
 		t.Error("sig reported as wrong when it's legal to have an empty sig")
 	}
 }
+
+func TestHostHandshake(t *testing.T) {
+	env := NewEnv()
+
+	host, err := NewHost(env, testTitle, testURL, false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	response, err := host.Handshake(&vpp2papi.HandshakeRequest{Info: vpp2pdat.DefaultHandshakeInfo()})
+	if err != nil {
+		t.Fatal("handshake failed", err)
+	}
+	if response.Protocol != vpp2pdat.MaxProtocol {
+		t.Errorf("bad protocol %d", response.Protocol)
+	}
+
+	info := vpp2pdat.DefaultHandshakeInfo()
+	info.Package = vpapp.NewPackage("other", "Other", "", "", "", "")
+	_, err = host.Handshake(&vpp2papi.HandshakeRequest{Info: info})
+	if !vpp2pdat.IsIncompatible(err) {
+		t.Error("incompatible peer not refused", err)
+	}
+}
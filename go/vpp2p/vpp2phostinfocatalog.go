@@ -20,31 +20,173 @@
 package vpp2p
 
 import (
+	"container/list"
+	"fmt"
+	"github.com/ufoot/vapor/go/vperror"
 	"github.com/ufoot/vapor/go/vpp2papi"
 	"github.com/ufoot/vapor/go/vpp2pdat"
 	"sync"
+	"time"
 )
 
+const (
+	// DefaultHostInfoTTL is how long a host stays in a catalog
+	// when it's not seen any more.
+	DefaultHostInfoTTL = 24 * time.Hour
+	// DefaultHostInfoMaxSize is the default maximum number of hosts
+	// in a catalog, once reached, the least recently used hosts
+	// are evicted.
+	DefaultHostInfoMaxSize = 10000
+)
+
+// hostInfoEntry is a host known by a catalog, with the last time
+// it has been seen, wether it has ever been contacted directly,
+// and its place in the LRU list.
+type hostInfoEntry struct {
+	info      *vpp2papi.HostInfo
+	lastSeen  time.Time
+	contacted bool
+	elem      *list.Element
+}
+
 // HostInfoCatalog is structure used to contain locally-known hosts.
+// Hosts are checked before being inserted, they expire when they
+// have not been seen for a while, and the catalog has a maximum size.
+// Only direct contact, that is, RegisterHost, counts as seeing a host,
+// refs given by other hosts can only add unknown hosts. When the
+// catalog is full, unsigned or never contacted hosts are evicted
+// first, then the least recently used ones.
 type HostInfoCatalog struct {
-	access sync.RWMutex
-	hosts  map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*vpp2papi.HostInfo
+	access  sync.Mutex
+	hosts   map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*hostInfoEntry
+	lru     *list.List
+	ttl     time.Duration
+	maxSize int
+	clock   Clock
 }
 
 type HostsRefsCreator interface {
 	CreateHostsRefs(locallHost *vpp2papi.HostInfo, rings []*vpp2papi.RingInfo, nodes []*vpp2papi.NodeInfo) map[string]*vpp2papi.HostInfo
 }
 
-var globalHostInfoCatalog = NewHostInfoCatalog()
-
-// NewHostInfoCatalog creates a new instance of a local host catalog
+// NewHostInfoCatalog creates a new instance of a local host catalog,
+// using DefaultHostInfoTTL and DefaultHostInfoMaxSize.
 func NewHostInfoCatalog() *HostInfoCatalog {
-	return &HostInfoCatalog{hosts: make(map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*vpp2papi.HostInfo)}
+	return &HostInfoCatalog{hosts: make(map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*hostInfoEntry), lru: list.New(), ttl: DefaultHostInfoTTL, maxSize: DefaultHostInfoMaxSize, clock: SystemClock()}
+}
+
+// SetTTL sets how long a host stays in the catalog when it's not seen.
+// It's thread-safe.
+func (c *HostInfoCatalog) SetTTL(ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("bad TTL %s", ttl)
+	}
+
+	defer c.access.Unlock()
+	c.access.Lock()
+
+	c.ttl = ttl
+
+	return nil
+}
+
+// SetMaxSize sets the maximum number of hosts in the catalog, evicting
+// the least recently used ones if there are too many.
+// It's thread-safe.
+func (c *HostInfoCatalog) SetMaxSize(maxSize int) error {
+	if maxSize < 1 {
+		return fmt.Errorf("bad max size %d", maxSize)
+	}
+
+	defer c.access.Unlock()
+	c.access.Lock()
+
+	c.maxSize = maxSize
+	c.evict()
+
+	return nil
+}
+
+// SetClock sets the clock used to track when hosts are seen.
+// It's thread-safe.
+func (c *HostInfoCatalog) SetClock(clock Clock) {
+	defer c.access.Unlock()
+	c.access.Lock()
+
+	c.clock = clock
+}
+
+// lookup returns a valid entry, removing it if it's expired.
+// Must be called with the lock held.
+func (c *HostInfoCatalog) lookup(hostPubKey []byte) *hostInfoEntry {
+	hostPubKeyBuf := vpp2pdat.HostPubKeyToBuf(hostPubKey)
+
+	entry := c.hosts[hostPubKeyBuf]
+	if entry == nil {
+		return nil
+	}
+	if c.clock.Now().Sub(entry.lastSeen) > c.ttl {
+		c.lru.Remove(entry.elem)
+		delete(c.hosts, hostPubKeyBuf)
+		return nil
+	}
+
+	return entry
 }
 
-// GlobalHostInfoCatalog returns a catalog containing all local hosts.
-func GlobalHostInfoCatalog() *HostInfoCatalog {
-	return globalHostInfoCatalog
+// evict removes hosts until the catalog is not too big. The least
+// recently used of the unsigned or never contacted hosts go first,
+// then the least recently used hosts. Must be called with the lock held.
+func (c *HostInfoCatalog) evict() {
+	for c.lru.Len() > c.maxSize {
+		victim := c.lru.Back()
+		for elem := victim; elem != nil; elem = elem.Prev() {
+			entry := elem.Value.(*hostInfoEntry)
+			if !entry.contacted || !vpp2pdat.HostInfoIsSigned(entry.info) {
+				victim = elem
+				break
+			}
+		}
+		entry := c.lru.Remove(victim).(*hostInfoEntry)
+		delete(c.hosts, vpp2pdat.HostPubKeyToBuf(entry.info.HostPubKey))
+	}
+}
+
+// insert records a host, or updates it if it's already known. A
+// known host can only be replaced by a different, signed, host info,
+// so that one can't hijack an unsigned host by giving another URL.
+// If direct is true, the host has been contacted directly, and is
+// marked as seen, else a known host keeps its last seen time and
+// LRU position. Must be called with the lock held.
+func (c *HostInfoCatalog) insert(host *vpp2papi.HostInfo, direct bool) error {
+	_, err := vpp2pdat.CheckHostInfo(host)
+	if err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	entry := c.lookup(host.HostPubKey)
+	if entry != nil {
+		if entry.info.HostURL != host.HostURL || entry.info.HostTitle != host.HostTitle {
+			if !vpp2pdat.HostInfoIsSigned(host) {
+				return fmt.Errorf("unsigned host info can't replace a known host")
+			}
+			entry.info = host
+		}
+		if direct {
+			entry.lastSeen = now
+			entry.contacted = true
+			c.lru.MoveToFront(entry.elem)
+		}
+		return nil
+	}
+
+	entry = &hostInfoEntry{info: host, lastSeen: now, contacted: direct}
+	entry.elem = c.lru.PushFront(entry)
+	c.hosts[vpp2pdat.HostPubKeyToBuf(host.HostPubKey)] = entry
+	c.evict()
+
+	return nil
 }
 
 // HasHost returns true if the host exists in the catalog.
@@ -54,27 +196,46 @@ func (c *HostInfoCatalog) HasHost(hostPubKey []byte) bool {
 }
 
 // GetHost returns a handler which makes possible API calls on it.
+// Expired hosts are ignored.
 // It's thread-safe.
 func (c *HostInfoCatalog) GetHost(hostPubKey []byte) *vpp2papi.HostInfo {
-	hostPubKeyBuf := vpp2pdat.HostPubKeyToBuf(hostPubKey)
-
-	defer c.access.RUnlock()
-	c.access.RLock()
+	defer c.access.Unlock()
+	c.access.Lock()
 
-	n := c.hosts[hostPubKeyBuf]
+	entry := c.lookup(hostPubKey)
+	if entry == nil {
+		return nil
+	}
+	c.lru.MoveToFront(entry.elem)
 
-	return n
+	return entry.info
 }
 
-// RegisterHost registers a host within the catalog.
+// LastSeen returns the last time a host has been registered, or
+// when it was first heard of if it never has been, and false if
+// the host is not in the catalog.
 // It's thread-safe.
-func (c *HostInfoCatalog) RegisterHost(host *vpp2papi.HostInfo) {
-	hostIDBuf := vpp2pdat.HostPubKeyToBuf(host.HostPubKey)
+func (c *HostInfoCatalog) LastSeen(hostPubKey []byte) (time.Time, bool) {
+	defer c.access.Unlock()
+	c.access.Lock()
+
+	entry := c.lookup(hostPubKey)
+	if entry == nil {
+		return time.Time{}, false
+	}
+
+	return entry.lastSeen, true
+}
 
+// RegisterHost registers a host within the catalog, after
+// checking it, and its signature if any. This is for hosts which
+// have been contacted directly, they are marked as seen.
+// It's thread-safe.
+func (c *HostInfoCatalog) RegisterHost(host *vpp2papi.HostInfo) error {
 	defer c.access.Unlock()
 	c.access.Lock()
 
-	c.hosts[hostIDBuf] = host
+	return c.insert(host, true)
 }
 
 // UnregisterHost unregisters a host within the catalog.
@@ -85,7 +246,11 @@ func (c *HostInfoCatalog) UnregisterHost(host *vpp2papi.HostInfo) {
 	defer c.access.Unlock()
 	c.access.Lock()
 
-	delete(c.hosts, hostIDBuf)
+	entry := c.hosts[hostIDBuf]
+	if entry != nil {
+		c.lru.Remove(entry.elem)
+		delete(c.hosts, hostIDBuf)
+	}
 }
 
 // CreateHostsRefs returns a list of known hosts for a given set
@@ -95,16 +260,15 @@ func (c *HostInfoCatalog) UnregisterHost(host *vpp2papi.HostInfo) {
 func (c *HostInfoCatalog) CreateHostsRefs(localHost *vpp2papi.HostInfo, rings []*vpp2papi.RingInfo, nodes []*vpp2papi.NodeInfo) map[string]*vpp2papi.HostInfo {
 	ret := make(map[string]*vpp2papi.HostInfo)
 
-	defer c.access.RUnlock()
-	c.access.RLock()
+	defer c.access.Unlock()
+	c.access.Lock()
 
 	if nodes != nil {
 		for _, value := range nodes {
 			if value != nil && value.HostPubKey != nil {
-				hostPubKeyBuf := vpp2pdat.HostPubKeyToBuf(value.HostPubKey)
-				host := c.hosts[hostPubKeyBuf]
-				if host != nil {
-					ret[vpp2pdat.HostPubKeyToShortString(value.HostPubKey)] = host
+				entry := c.lookup(value.HostPubKey)
+				if entry != nil {
+					ret[vpp2pdat.HostPubKeyToShortString(value.HostPubKey)] = entry.info
 				}
 			}
 		}
@@ -112,10 +276,9 @@ func (c *HostInfoCatalog) CreateHostsRefs(localHost *vpp2papi.HostInfo, rings []
 	if rings != nil {
 		for _, value := range rings {
 			if value != nil && value.HostPubKey != nil {
-				hostPubKeyBuf := vpp2pdat.HostPubKeyToBuf(value.HostPubKey)
-				host := c.hosts[hostPubKeyBuf]
-				if host != nil {
-					ret[vpp2pdat.HostPubKeyToShortString(value.HostPubKey)] = host
+				entry := c.lookup(value.HostPubKey)
+				if entry != nil {
+					ret[vpp2pdat.HostPubKeyToShortString(value.HostPubKey)] = entry.info
 				}
 			}
 		}
@@ -133,11 +296,11 @@ func (c *HostInfoCatalog) CreateHostsRefs(localHost *vpp2papi.HostInfo, rings []
 func (c *HostInfoCatalog) HasAllHostsRefs(refs map[string]*vpp2papi.HostInfo) bool {
 	ret := true
 
-	defer c.access.RUnlock()
-	c.access.RLock()
+	defer c.access.Unlock()
+	c.access.Lock()
 
 	for _, value := range refs {
-		if c.hosts[vpp2pdat.HostPubKeyToBuf(value.HostPubKey)] == nil {
+		if value == nil || c.lookup(value.HostPubKey) == nil {
 			ret = false
 		}
 	}
@@ -146,30 +309,79 @@ func (c *HostInfoCatalog) HasAllHostsRefs(refs map[string]*vpp2papi.HostInfo) bo
 }
 
 // UpdateHostsRefs updates the host catalog according to a set of refs.
-func (c *HostInfoCatalog) UpdateHostsRefs(refs map[string]*vpp2papi.HostInfo) {
-	if c.HasAllHostsRefs(refs) {
-		return
-	}
+// Each host info is checked, including its signature, before being
+// recorded. Refs are given by other hosts, so known hosts are not
+// marked as seen, only RegisterHost does this. Invalid refs are
+// skipped, and reported by the returned error.
+// It's thread-safe.
+func (c *HostInfoCatalog) UpdateHostsRefs(refs map[string]*vpp2papi.HostInfo) error {
+	var ret error
 
 	defer c.access.Unlock()
 	c.access.Lock()
 
 	for _, value := range refs {
-		c.hosts[vpp2pdat.HostPubKeyToBuf(value.HostPubKey)] = value
+		if value == nil {
+			continue
+		}
+		err := c.insert(value, false)
+		if err != nil && ret == nil {
+			ret = vperror.Chainf(err, "invalid host ref %s", vpp2pdat.HostPubKeyToShortString(value.HostPubKey))
+		}
 	}
+
+	return ret
+}
+
+// Purge removes expired hosts from the catalog, and returns
+// how many have been removed.
+// It's thread-safe.
+func (c *HostInfoCatalog) Purge() int {
+	ret := 0
+
+	defer c.access.Unlock()
+	c.access.Lock()
+
+	now := c.clock.Now()
+	for elem := c.lru.Back(); elem != nil; {
+		prev := elem.Prev()
+		entry := elem.Value.(*hostInfoEntry)
+		if now.Sub(entry.lastSeen) > c.ttl {
+			c.lru.Remove(elem)
+			delete(c.hosts, vpp2pdat.HostPubKeyToBuf(entry.info.HostPubKey))
+			ret++
+		}
+		elem = prev
+	}
+
+	return ret
 }
 
-// List returns a list of known hosts. It returns static
-// data about the host, not the hosts themselves.
+// Len returns the number of hosts in the catalog, including
+// expired ones which have not been purged yet.
+// It's thread-safe.
+func (c *HostInfoCatalog) Len() int {
+	defer c.access.Unlock()
+	c.access.Lock()
+
+	return len(c.hosts)
+}
+
+// List returns a list of known hosts, most recently used first.
+// It returns static data about the host, not the hosts themselves.
+// Expired hosts are ignored.
+// It's thread-safe.
 func (c *HostInfoCatalog) List() []*vpp2papi.HostInfo {
-	defer c.access.RUnlock()
-	c.access.RLock()
-
-	i := 0
-	ret := make([]*vpp2papi.HostInfo, len(c.hosts))
-	for _, value := range c.hosts {
-		ret[i] = value
-		i++
+	defer c.access.Unlock()
+	c.access.Lock()
+
+	now := c.clock.Now()
+	ret := make([]*vpp2papi.HostInfo, 0, len(c.hosts))
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*hostInfoEntry)
+		if now.Sub(entry.lastSeen) <= c.ttl {
+			ret = append(ret, entry.info)
+		}
 	}
 
 	return ret
@@ -24,73 +24,75 @@ import (
 	"github.com/ufoot/vapor/go/vpp2papi"
 	"github.com/ufoot/vapor/go/vpp2pdat"
 	"testing"
+	"time"
 )
 
-func TestGlobalHostInfoCatalog(t *testing.T) {
+func TestHostInfoCatalog(t *testing.T) {
 	var host1, host2, host3 *Host
 	var ring *Ring
 	var node1, node2, node3 *Node
 	var err error
+	var env = NewEnv()
 
-	host1, err = NewHost(fmt.Sprintf("%s 1", testTitle), fmt.Sprintf("%s1", testURL), false, GlobalHostInfoCatalog())
+	host1, err = NewHost(env, fmt.Sprintf("%s 1", testTitle), fmt.Sprintf("%s1", testURL), false)
 	if err != nil {
 		t.Error("unable to create host 1", err)
 	}
-	host2, err = NewHost(fmt.Sprintf("%s 2", testTitle), fmt.Sprintf("%s2", testURL), false, GlobalHostInfoCatalog())
+	host2, err = NewHost(env, fmt.Sprintf("%s 2", testTitle), fmt.Sprintf("%s2", testURL), false)
 	if err != nil {
 		t.Error("unable to create host 2", err)
 	}
-	host3, err = NewHost(fmt.Sprintf("%s 3", testTitle), fmt.Sprintf("%s3", testURL), false, GlobalHostInfoCatalog())
+	host3, err = NewHost(env, fmt.Sprintf("%s 3", testTitle), fmt.Sprintf("%s3", testURL), false)
 	if err != nil {
 		t.Error("unable to create host 3", err)
 	}
 
-	if GlobalHostInfoCatalog().HasHost(host1.Info.HostPubKey) {
-		t.Error("global catalog has host1, but it's not registered yet")
+	if env.HostInfoCatalog().HasHost(host1.Info.HostPubKey) {
+		t.Error("env catalog has host1, but it's not registered yet")
 	}
-	if GlobalHostInfoCatalog().HasHost(host2.Info.HostPubKey) {
-		t.Error("global catalog has host2, but it's not registered yet")
+	if env.HostInfoCatalog().HasHost(host2.Info.HostPubKey) {
+		t.Error("env catalog has host2, but it's not registered yet")
 	}
-	GlobalHostInfoCatalog().RegisterHost(&(host1.Info))
-	if !GlobalHostInfoCatalog().HasHost(host1.Info.HostPubKey) {
-		t.Error("global catalog does not have host1, but it has been registered")
+	env.HostInfoCatalog().RegisterHost(&(host1.Info))
+	if !env.HostInfoCatalog().HasHost(host1.Info.HostPubKey) {
+		t.Error("env catalog does not have host1, but it has been registered")
 	}
-	if GlobalHostInfoCatalog().HasHost(host2.Info.HostPubKey) {
-		t.Error("global catalog has host2, but it's not registered yet")
+	if env.HostInfoCatalog().HasHost(host2.Info.HostPubKey) {
+		t.Error("env catalog has host2, but it's not registered yet")
 	}
-	GlobalHostInfoCatalog().RegisterHost(&(host2.Info))
-	if !GlobalHostInfoCatalog().HasHost(host1.Info.HostPubKey) {
-		t.Error("global catalog does not have host1, but it has been registered")
+	env.HostInfoCatalog().RegisterHost(&(host2.Info))
+	if !env.HostInfoCatalog().HasHost(host1.Info.HostPubKey) {
+		t.Error("env catalog does not have host1, but it has been registered")
 	}
-	if !GlobalHostInfoCatalog().HasHost(host2.Info.HostPubKey) {
-		t.Error("global catalog does not have host2, but it has been registered")
+	if !env.HostInfoCatalog().HasHost(host2.Info.HostPubKey) {
+		t.Error("env catalog does not have host2, but it has been registered")
 	}
-	if len(GlobalHostInfoCatalog().List()) != 2 {
-		t.Error("global catalog length should be 2")
+	if len(env.HostInfoCatalog().List()) != 2 {
+		t.Error("env catalog length should be 2")
 	}
-	GlobalHostInfoCatalog().UnregisterHost(&(host1.Info))
-	if GlobalHostInfoCatalog().HasHost(host1.Info.HostPubKey) {
-		t.Error("global catalog has host1, but it's not registered yet")
+	env.HostInfoCatalog().UnregisterHost(&(host1.Info))
+	if env.HostInfoCatalog().HasHost(host1.Info.HostPubKey) {
+		t.Error("env catalog has host1, but it's not registered yet")
 	}
-	if !GlobalHostInfoCatalog().HasHost(host2.Info.HostPubKey) {
-		t.Error("global catalog does not have host2, but it has been registered")
+	if !env.HostInfoCatalog().HasHost(host2.Info.HostPubKey) {
+		t.Error("env catalog does not have host2, but it has been registered")
 	}
-	if len(GlobalHostInfoCatalog().List()) != 1 {
-		t.Error("global catalog length should be 1")
+	if len(env.HostInfoCatalog().List()) != 1 {
+		t.Error("env catalog length should be 1")
 	}
-	ring, err = NewRing(host1, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	ring, err = NewRing(env, host1, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
 	if err != nil {
 		t.Error("unable to create ring", err)
 	}
-	node1, err = NewNode(host1, ring, nil, GlobalNodeCatalog())
+	node1, err = NewNode(env, host1, ring, nil)
 	if err != nil {
 		t.Error("unable to create node1", err)
 	}
-	node2, err = NewNode(host2, ring, nil, GlobalNodeCatalog())
+	node2, err = NewNode(env, host2, ring, nil)
 	if err != nil {
 		t.Error("unable to create node2", err)
 	}
-	node3, err = NewNode(host3, ring, nil, GlobalNodeCatalog())
+	node3, err = NewNode(env, host3, ring, nil)
 	if err != nil {
 		t.Error("unable to create node3", err)
 	}
@@ -100,6 +102,185 @@ func TestGlobalHostInfoCatalog(t *testing.T) {
 	nodesList[2] = node3.Status.Info
 	ringsList := make([]*vpp2papi.RingInfo, 1)
 	ringsList[0] = &(ring.Info)
-	refs := GlobalHostInfoCatalog().CreateHostsRefs(&(host1.Info), ringsList, nodesList)
-	GlobalHostInfoCatalog().UpdateHostsRefs(refs)
+	refs := env.HostInfoCatalog().CreateHostsRefs(&(host1.Info), ringsList, nodesList)
+	err = env.HostInfoCatalog().UpdateHostsRefs(refs)
+	if err != nil {
+		t.Error("unable to update hosts refs", err)
+	}
+	if !env.HostInfoCatalog().HasHost(host1.Info.HostPubKey) {
+		t.Error("env catalog does not have host1, but it was in refs")
+	}
+}
+
+type testHostInfoClock struct {
+	now time.Time
+}
+
+func (c *testHostInfoClock) Now() time.Time {
+	return c.now
+}
+
+func TestHostInfoCatalogChecks(t *testing.T) {
+	env := NewEnv()
+
+	signedHost, err := NewHost(env, testTitle, testURL+"/signed", true)
+	if err != nil {
+		t.Fatal("unable to create signed host", err)
+	}
+	unsignedHost, err := NewHost(env, testTitle, testURL+"/unsigned", false)
+	if err != nil {
+		t.Fatal("unable to create unsigned host", err)
+	}
+	c := NewHostInfoCatalog()
+
+	forged := signedHost.Info
+	forged.HostURL = testURL + "/forged"
+	err = c.UpdateHostsRefs(map[string]*vpp2papi.HostInfo{"forged": &forged})
+	if err == nil || c.HasHost(forged.HostPubKey) {
+		t.Error("forged host info accepted")
+	}
+	err = c.RegisterHost(&forged)
+	if err == nil || c.HasHost(forged.HostPubKey) {
+		t.Error("forged host info registered")
+	}
+
+	err = c.UpdateHostsRefs(map[string]*vpp2papi.HostInfo{"signed": &(signedHost.Info), "unsigned": &(unsignedHost.Info)})
+	if err != nil {
+		t.Fatal("unable to update hosts refs", err)
+	}
+	hijacked := unsignedHost.Info
+	hijacked.HostURL = testURL + "/hijacked"
+	err = c.RegisterHost(&hijacked)
+	if err == nil || c.GetHost(unsignedHost.Info.HostPubKey).HostURL != unsignedHost.Info.HostURL {
+		t.Error("unsigned host info replaced a known host")
+	}
+
+	moved := signedHost.Info
+	moved.HostURL = testURL + "/moved"
+	moved.HostSig, err = signedHost.key.Sign(vpp2pdat.HostInfoSigBytes(&moved))
+	if err != nil {
+		t.Fatal("unable to sign host info", err)
+	}
+	err = c.RegisterHost(&moved)
+	if err != nil || c.GetHost(moved.HostPubKey).HostURL != moved.HostURL {
+		t.Error("signed host info did not replace a known host", err)
+	}
+}
+
+func TestHostInfoCatalogExpiry(t *testing.T) {
+	var hosts [4]*Host
+	var err error
+
+	env := NewEnv()
+	clock := &testHostInfoClock{now: time.Unix(1000000000, 0)}
+	c := NewHostInfoCatalog()
+	c.SetClock(clock)
+	err = c.SetTTL(time.Hour)
+	if err != nil {
+		t.Fatal("unable to set TTL", err)
+	}
+	err = c.SetMaxSize(3)
+	if err != nil {
+		t.Fatal("unable to set max size", err)
+	}
+
+	for i := range hosts {
+		hosts[i], err = NewHost(env, testTitle, fmt.Sprintf("%s/expiry/%d", testURL, i), false)
+		if err != nil {
+			t.Fatal("unable to create host", err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		err = c.RegisterHost(&(hosts[i].Info))
+		if err != nil {
+			t.Fatal("unable to register host", err)
+		}
+		clock.now = clock.now.Add(time.Minute)
+	}
+	// host 0 is used, so host 1 becomes the least recently used one
+	if !c.HasHost(hosts[0].Info.HostPubKey) {
+		t.Fatal("host 0 not in catalog")
+	}
+	err = c.RegisterHost(&(hosts[3].Info))
+	if err != nil {
+		t.Fatal("unable to register host", err)
+	}
+	if c.Len() != 3 {
+		t.Errorf("bad catalog size %d", c.Len())
+	}
+	if c.HasHost(hosts[1].Info.HostPubKey) {
+		t.Error("least recently used host not evicted")
+	}
+	if !c.HasHost(hosts[0].Info.HostPubKey) || !c.HasHost(hosts[2].Info.HostPubKey) || !c.HasHost(hosts[3].Info.HostPubKey) {
+		t.Error("recently used host evicted")
+	}
+
+	lastSeen, ok := c.LastSeen(hosts[3].Info.HostPubKey)
+	if !ok || !lastSeen.Equal(clock.now) {
+		t.Error("bad last seen time", lastSeen)
+	}
+	clock.now = clock.now.Add(time.Hour - time.Minute)
+	// refs given by other hosts do not count as seeing host 2
+	err = c.UpdateHostsRefs(map[string]*vpp2papi.HostInfo{"2": &(hosts[2].Info)})
+	if err != nil {
+		t.Error("unable to update hosts refs", err)
+	}
+	err = c.RegisterHost(&(hosts[3].Info))
+	if err != nil {
+		t.Error("unable to register host", err)
+	}
+	clock.now = clock.now.Add(2 * time.Minute)
+	if len(c.List()) != 1 || c.HasHost(hosts[0].Info.HostPubKey) {
+		t.Error("expired hosts still listed")
+	}
+	if c.Purge() != 1 || c.Len() != 1 {
+		t.Errorf("bad purge, catalog size is %d", c.Len())
+	}
+	if !c.HasHost(hosts[3].Info.HostPubKey) {
+		t.Error("host seen recently has expired")
+	}
+}
+
+func TestHostInfoCatalogEviction(t *testing.T) {
+	var hosts [5]*Host
+	var err error
+
+	env := NewEnv()
+	c := NewHostInfoCatalog()
+	err = c.SetMaxSize(3)
+	if err != nil {
+		t.Fatal("unable to set max size", err)
+	}
+	for i := range hosts {
+		// host 2 is the only unsigned one
+		hosts[i], err = NewHost(env, testTitle, fmt.Sprintf("%s/eviction/%d", testURL, i), i != 2)
+		if err != nil {
+			t.Fatal("unable to create host", err)
+		}
+	}
+
+	// host 1 is only known from refs, it has never been contacted
+	for _, err = range []error{
+		c.RegisterHost(&(hosts[0].Info)),
+		c.UpdateHostsRefs(map[string]*vpp2papi.HostInfo{"1": &(hosts[1].Info)}),
+		c.RegisterHost(&(hosts[3].Info)),
+		c.RegisterHost(&(hosts[2].Info)),
+	} {
+		if err != nil {
+			t.Fatal("unable to insert host", err)
+		}
+	}
+	if c.HasHost(hosts[1].Info.HostPubKey) || !c.HasHost(hosts[0].Info.HostPubKey) {
+		t.Error("contacted host evicted before a host known from refs")
+	}
+	err = c.RegisterHost(&(hosts[4].Info))
+	if err != nil {
+		t.Fatal("unable to register host", err)
+	}
+	if c.HasHost(hosts[2].Info.HostPubKey) || !c.HasHost(hosts[0].Info.HostPubKey) {
+		t.Error("signed host evicted before an unsigned host")
+	}
+	if c.Len() != 3 {
+		t.Errorf("bad catalog size %d", c.Len())
+	}
 }
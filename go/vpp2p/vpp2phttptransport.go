@@ -0,0 +1,134 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const httpContentType = "application/x-thrift"
+
+// httpTransport is a Thrift transport which posts calls to an URL,
+// and reads replies from the response body. Unlike the Thrift HTTP
+// client, it has a timeout, which covers the whole request, from
+// connection to the end of the reply.
+type httpTransport struct {
+	url      string
+	client   *http.Client
+	request  *bytes.Buffer
+	response *http.Response
+}
+
+func newHTTPTransport(url string, timeout time.Duration) *httpTransport {
+	return &httpTransport{url: url, client: &http.Client{Timeout: timeout}, request: new(bytes.Buffer)}
+}
+
+func (t *httpTransport) closeResponse() error {
+	var err error
+
+	if t.response != nil {
+		err = t.response.Body.Close()
+		t.response = nil
+	}
+
+	return err
+}
+
+// Open does nothing, connections are handled by the HTTP client.
+func (t *httpTransport) Open() error {
+	return nil
+}
+
+// IsOpen returns true until the transport is closed.
+func (t *httpTransport) IsOpen() bool {
+	return t.request != nil
+}
+
+// Close releases the pending response, if any.
+func (t *httpTransport) Close() error {
+	if t.request != nil {
+		t.request.Reset()
+		t.request = nil
+	}
+
+	return t.closeResponse()
+}
+
+// Read reads the body of the last response.
+func (t *httpTransport) Read(buf []byte) (int, error) {
+	if t.response == nil {
+		return 0, thrift.NewTTransportException(thrift.NOT_OPEN, "no response, request has not been sent")
+	}
+	n, err := t.response.Body.Read(buf)
+	if n > 0 && (err == nil || err == io.EOF) {
+		return n, nil
+	}
+
+	return n, thrift.NewTTransportExceptionFromError(err)
+}
+
+// Write appends data to the request, it is sent on Flush.
+func (t *httpTransport) Write(buf []byte) (int, error) {
+	if t.request == nil {
+		return 0, thrift.NewTTransportException(thrift.NOT_OPEN, "transport is closed")
+	}
+
+	return t.request.Write(buf)
+}
+
+// Flush posts the request, and waits for the response headers.
+func (t *httpTransport) Flush() error {
+	if t.request == nil {
+		return thrift.NewTTransportException(thrift.NOT_OPEN, "transport is closed")
+	}
+	t.closeResponse()
+
+	request, err := http.NewRequest("POST", t.url, bytes.NewReader(t.request.Bytes()))
+	t.request.Reset()
+	if err != nil {
+		return thrift.NewTTransportExceptionFromError(err)
+	}
+	request.Header.Set("Content-Type", httpContentType)
+	response, err := t.client.Do(request)
+	if err != nil {
+		return thrift.NewTTransportExceptionFromError(err)
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return thrift.NewTTransportException(thrift.UNKNOWN_TRANSPORT_EXCEPTION, "HTTP response code "+strconv.Itoa(response.StatusCode))
+	}
+	t.response = response
+
+	return nil
+}
+
+// RemainingBytes returns the size of the response, if known.
+func (t *httpTransport) RemainingBytes() uint64 {
+	if t.response != nil && t.response.ContentLength >= 0 {
+		return uint64(t.response.ContentLength)
+	}
+
+	return ^uint64(0)
+}
@@ -0,0 +1,228 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"fmt"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLimitRate is the number of calls per second a given
+	// source host can make, on the long run.
+	DefaultLimitRate = 200.0
+	// DefaultLimitBurst is the number of calls a given source host
+	// can make at once, after it has been idle for a while.
+	DefaultLimitBurst = 400
+	// DefaultMaxConcurrentCalls is the number of calls a host handles
+	// at the same time, all sources included.
+	DefaultMaxConcurrentCalls = 256
+	// DefaultMaxLimitedSources is the number of source hosts a
+	// limiter keeps track of.
+	DefaultMaxLimitedSources = 10000
+)
+
+// LimiterConfig contains the limits applied by a host to its callers.
+type LimiterConfig struct {
+	// Rate is the number of calls per second a given source host can make.
+	Rate float64
+	// Burst is the number of calls a given source host can make at once.
+	Burst int
+	// MaxConcurrentCalls is the number of calls handled at the same time.
+	MaxConcurrentCalls int
+	// MaxSources is the number of source hosts tracked.
+	MaxSources int
+}
+
+// DefaultLimiterConfig returns the default limits.
+func DefaultLimiterConfig() *LimiterConfig {
+	return &LimiterConfig{Rate: DefaultLimitRate, Burst: DefaultLimitBurst, MaxConcurrentCalls: DefaultMaxConcurrentCalls, MaxSources: DefaultMaxLimitedSources}
+}
+
+// OverloadError is returned when a call is rejected because the
+// caller makes too many calls, or because the host is too busy.
+// Callers should wait before trying again.
+type OverloadError struct {
+	// Reason explains, in plain text, why the call is rejected.
+	Reason string
+}
+
+// Error returns a description of the overload.
+func (e *OverloadError) Error() string {
+	return fmt.Sprintf("host overloaded: %s", e.Reason)
+}
+
+// IsOverload returns true if err is an OverloadError.
+func IsOverload(err error) bool {
+	_, ok := err.(*OverloadError)
+	return ok
+}
+
+// tokenBucket holds the calls a source host can still make.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter bounds the rate of calls made by each source host, using
+// a token bucket per host, and the number of calls handled at the
+// same time, whatever their source. It never blocks, calls which
+// exceed the limits are rejected with an OverloadError.
+type Limiter struct {
+	access  sync.Mutex
+	config  LimiterConfig
+	clock   Clock
+	buckets map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*tokenBucket
+	nbCalls int
+}
+
+// NewLimiter creates a new limiter, a nil config means the defaults.
+func NewLimiter(config *LimiterConfig) (*Limiter, error) {
+	var ret Limiter
+
+	ret.clock = SystemClock()
+	ret.buckets = make(map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*tokenBucket)
+	err := ret.SetConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// SetConfig changes the limits, a nil config means the defaults.
+// Calls being handled are not affected.
+// It's thread-safe.
+func (l *Limiter) SetConfig(config *LimiterConfig) error {
+	if config == nil {
+		config = DefaultLimiterConfig()
+	}
+	if config.Rate <= 0 || config.Burst < 1 || config.MaxConcurrentCalls < 1 || config.MaxSources < 1 {
+		return fmt.Errorf("bad limiter config, rate=%f burst=%d maxConcurrentCalls=%d maxSources=%d", config.Rate, config.Burst, config.MaxConcurrentCalls, config.MaxSources)
+	}
+
+	defer l.access.Unlock()
+	l.access.Lock()
+
+	l.config = *config
+	l.buckets = make(map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*tokenBucket)
+
+	return nil
+}
+
+// Config returns the current limits.
+// It's thread-safe.
+func (l *Limiter) Config() *LimiterConfig {
+	defer l.access.Unlock()
+	l.access.Lock()
+
+	ret := l.config
+
+	return &ret
+}
+
+// SetClock sets the clock used to refill token buckets.
+// It's thread-safe.
+func (l *Limiter) SetClock(clock Clock) {
+	defer l.access.Unlock()
+	l.access.Lock()
+
+	l.clock = clock
+}
+
+// NbCalls returns the number of calls being handled.
+// It's thread-safe.
+func (l *Limiter) NbCalls() int {
+	defer l.access.Unlock()
+	l.access.Lock()
+
+	return l.nbCalls
+}
+
+// refill adds the tokens earned since the bucket was last used.
+// Must be called with the lock held.
+func (l *Limiter) refill(bucket *tokenBucket, now time.Time) {
+	elapsed := now.Sub(bucket.last).Seconds()
+	if elapsed > 0 {
+		bucket.tokens += elapsed * l.config.Rate
+		bucket.last = now
+	}
+	if bucket.tokens > float64(l.config.Burst) {
+		bucket.tokens = float64(l.config.Burst)
+	}
+}
+
+// purge forgets about sources whose bucket is full, since they
+// would get a new full bucket anyway.
+// Must be called with the lock held.
+func (l *Limiter) purge(now time.Time) {
+	for k, v := range l.buckets {
+		l.refill(v, now)
+		if v.tokens >= float64(l.config.Burst) {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// Admit checks whether a call from the host with the given public key
+// can be handled. If it can, the returned function must be called once
+// the call is over. If it can't, an OverloadError is returned.
+// It's thread-safe.
+func (l *Limiter) Admit(hostPubKey []byte) (func(), error) {
+	key := vpp2pdat.HostPubKeyToBuf(hostPubKey)
+
+	defer l.access.Unlock()
+	l.access.Lock()
+
+	if l.nbCalls >= l.config.MaxConcurrentCalls {
+		return nil, &OverloadError{Reason: fmt.Sprintf("too many concurrent calls, max=%d", l.config.MaxConcurrentCalls)}
+	}
+	now := l.clock.Now()
+	bucket := l.buckets[key]
+	if bucket == nil {
+		if len(l.buckets) >= l.config.MaxSources {
+			l.purge(now)
+		}
+		if len(l.buckets) >= l.config.MaxSources {
+			return nil, &OverloadError{Reason: fmt.Sprintf("too many sources, max=%d", l.config.MaxSources)}
+		}
+		bucket = &tokenBucket{tokens: float64(l.config.Burst), last: now}
+		l.buckets[key] = bucket
+	}
+	l.refill(bucket, now)
+	if bucket.tokens < 1 {
+		return nil, &OverloadError{Reason: fmt.Sprintf("too many calls from source, rate=%f burst=%d", l.config.Rate, l.config.Burst)}
+	}
+	bucket.tokens--
+	l.nbCalls++
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			l.access.Lock()
+			l.nbCalls--
+			l.access.Unlock()
+		})
+	}
+
+	return release, nil
+}
@@ -0,0 +1,204 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	clock := &testHostInfoClock{now: time.Unix(1000000, 0)}
+	sourceA := []byte("source A public key")
+	sourceB := []byte("source B public key")
+	sourceC := []byte("source C public key")
+
+	_, err := NewLimiter(&LimiterConfig{Rate: 0, Burst: 1, MaxConcurrentCalls: 1, MaxSources: 1})
+	if err == nil {
+		t.Error("null rate accepted")
+	}
+	limiter, err := NewLimiter(&LimiterConfig{Rate: 10, Burst: 2, MaxConcurrentCalls: 3, MaxSources: 2})
+	if err != nil {
+		t.Fatal("unable to create limiter", err)
+	}
+	limiter.SetClock(clock)
+
+	releases := make([]func(), 0)
+	for i := 0; i < 2; i++ {
+		release, err := limiter.Admit(sourceA)
+		if err != nil {
+			t.Fatal("call rejected within burst", err)
+		}
+		releases = append(releases, release)
+	}
+	_, err = limiter.Admit(sourceA)
+	if !IsOverload(err) {
+		t.Error("call accepted beyond burst", err)
+	}
+	clock.now = clock.now.Add(100 * time.Millisecond)
+	release, err := limiter.Admit(sourceA)
+	if err != nil {
+		t.Fatal("call rejected after refill", err)
+	}
+	releases = append(releases, release)
+
+	_, err = limiter.Admit(sourceB)
+	if !IsOverload(err) {
+		t.Error("call accepted beyond concurrency cap", err)
+	}
+	for _, release := range releases {
+		release()
+		release()
+	}
+	if limiter.NbCalls() != 0 {
+		t.Errorf("bad number of calls %d", limiter.NbCalls())
+	}
+	release, err = limiter.Admit(sourceB)
+	if err != nil {
+		t.Fatal("call rejected after release", err)
+	}
+	release()
+
+	_, err = limiter.Admit(sourceC)
+	if !IsOverload(err) {
+		t.Error("call accepted beyond max sources", err)
+	}
+	clock.now = clock.now.Add(time.Second)
+	release, err = limiter.Admit(sourceC)
+	if err != nil {
+		t.Error("call rejected after idle sources purge", err)
+	} else {
+		release()
+	}
+}
+
+func TestHostLimiter(t *testing.T) {
+	env := NewEnv()
+	host, err := NewHost(env, testTitle, testURL+"/limiter", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	ring, err := NewRing(env, host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	node, err := NewNode(env, host, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	node.Start()
+	defer node.Stop()
+	err = host.Limiter().SetConfig(&LimiterConfig{Rate: 0.001, Burst: 1, MaxConcurrentCalls: 10, MaxSources: 10})
+	if err != nil {
+		t.Fatal("unable to set limits", err)
+	}
+
+	// calls made by local nodes are never limited
+	for i := 0; i < 3; i++ {
+		_, err = host.GetSuccessors(&vpp2papi.GetSuccessorsRequest{Context: node.contextInfo(node.Status.Info.NodeID)})
+		if err != nil {
+			t.Error("unable to get successors", err)
+		}
+	}
+
+	// a request decoded from the network has its own copy of host info,
+	// claiming to come from this host does not bypass the limits
+	forgedHostInfo := host.Info
+	context := node.contextInfo(node.Status.Info.NodeID)
+	context.SourceHost = &forgedHostInfo
+	_, err = host.GetSuccessors(&vpp2papi.GetSuccessorsRequest{Context: context})
+	if err != nil {
+		t.Error("unable to get successors", err)
+	}
+	context = node.contextInfo(node.Status.Info.NodeID)
+	context.SourceHost = &forgedHostInfo
+	_, err = host.GetSuccessors(&vpp2papi.GetSuccessorsRequest{Context: context})
+	if !IsOverload(err) {
+		t.Error("forged local call not limited", err)
+	}
+
+	// calls made by nodes of another host are limited, even within
+	// the same env, as in simulations
+	otherHost, err := NewHost(env, testTitle, testURL+"/limiter/other", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	otherNode, err := NewNode(env, otherHost, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	otherNode.Start()
+	defer otherNode.Stop()
+	_, err = host.GetSuccessors(&vpp2papi.GetSuccessorsRequest{Context: otherNode.contextInfo(node.Status.Info.NodeID)})
+	if err != nil {
+		t.Error("unable to get successors", err)
+	}
+	_, err = host.GetSuccessors(&vpp2papi.GetSuccessorsRequest{Context: otherNode.contextInfo(node.Status.Info.NodeID)})
+	if !IsOverload(err) {
+		t.Error("call from another host not limited", err)
+	}
+	if host.Limiter().NbCalls() != 0 {
+		t.Errorf("bad number of calls %d", host.Limiter().NbCalls())
+	}
+}
+
+func TestCallSlot(t *testing.T) {
+	limiter, err := NewLimiter(&LimiterConfig{Rate: 10, Burst: 10, MaxConcurrentCalls: 1, MaxSources: 1})
+	if err != nil {
+		t.Fatal("unable to create limiter", err)
+	}
+	source := []byte("source")
+
+	release, err := limiter.Admit(source)
+	if err != nil {
+		t.Fatal("call rejected", err)
+	}
+	slot := &callSlot{release: release}
+	unblock := make(chan bool)
+	finished := make(chan bool)
+	err = slot.run(func() error {
+		<-unblock
+		finished <- true
+		return nil
+	}, 10*time.Millisecond)
+	if err == nil {
+		t.Error("slow call did not time out")
+	}
+	slot.done()
+
+	// the call goes on after the timeout, so it still holds its slot
+	if limiter.NbCalls() != 1 {
+		t.Errorf("slot released before the call is over, %d calls", limiter.NbCalls())
+	}
+	_, err = limiter.Admit(source)
+	if !IsOverload(err) {
+		t.Error("call admitted while a timed out call is still running", err)
+	}
+	close(unblock)
+	<-finished
+	for i := 0; i < 100 && limiter.NbCalls() != 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if limiter.NbCalls() != 0 {
+		t.Errorf("slot not released once the call is over, %d calls", limiter.NbCalls())
+	}
+}
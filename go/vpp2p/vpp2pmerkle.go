@@ -0,0 +1,162 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"github.com/ufoot/vapor/go/vpsum"
+	"sort"
+)
+
+// merkleEmptyHash is the hash of any subtree which contains no entry,
+// this way two empty subtrees always match, whatever their depth.
+var merkleEmptyHash = vpsum.Checksum256(nil)
+
+// merkleTree is a Merkle tree of the entries held in a store,
+// within a given key range. Each entry goes in a leaf which
+// depends on the checksum of its key, so that entries are spread
+// evenly, even if the range is only a small slice of the ring.
+// Trees built by different nodes, over the same range, can then
+// be compared level by level, and only the differing subtrees
+// need to be walked.
+type merkleTree struct {
+	leaves map[string][]*vpp2papi.DataDigest
+	counts map[string]int
+	hashes map[string][]byte
+}
+
+// digestList sorts digests by key.
+type digestList []*vpp2papi.DataDigest
+
+func (l digestList) Len() int {
+	return len(l)
+}
+
+func (l digestList) Less(i, j int) bool {
+	return bytes.Compare(l[i].Key, l[j].Key) < 0
+}
+
+func (l digestList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+// merklePath returns the path of the leaf holding a key, one
+// byte per level, each byte being the index of a child.
+func merklePath(key []byte) []byte {
+	sum := vpsum.Checksum256(key)
+	ret := make([]byte, vpp2pdat.MerkleDepth)
+	for i := range ret {
+		// with the default fanout of 16, each level uses 4 bits
+		ret[i] = (sum[i/2] >> (4 * uint(1-i%2))) % vpp2pdat.MerkleFanout
+	}
+
+	return ret
+}
+
+// newMerkleTree builds the Merkle tree of a set of keys and values,
+// values being in the same order than keys.
+func newMerkleTree(keys, values [][]byte) *merkleTree {
+	tree := &merkleTree{leaves: make(map[string][]*vpp2papi.DataDigest), counts: make(map[string]int), hashes: make(map[string][]byte)}
+
+	for i, key := range keys {
+		path := merklePath(key)
+		tree.leaves[string(path)] = append(tree.leaves[string(path)], &vpp2papi.DataDigest{Key: key, Hash: vpsum.Checksum256(values[i])})
+		for j := 0; j <= len(path); j++ {
+			tree.counts[string(path[:j])]++
+		}
+	}
+	for _, digests := range tree.leaves {
+		sort.Sort(digestList(digests))
+	}
+
+	return tree
+}
+
+// hash returns the hash of the node at prefix.
+func (tree *merkleTree) hash(prefix []byte) []byte {
+	if tree.counts[string(prefix)] == 0 {
+		return merkleEmptyHash
+	}
+	if ret, ok := tree.hashes[string(prefix)]; ok {
+		return ret
+	}
+
+	var bufs [][]byte
+	if len(prefix) >= vpp2pdat.MerkleDepth {
+		for _, v := range tree.leaves[string(prefix)] {
+			bufs = append(bufs, v.Key, v.Hash)
+		}
+	} else {
+		bufs = tree.children(prefix)
+	}
+	ret := vpsum.Checksum256(bytes.Join(bufs, nil))
+	tree.hashes[string(prefix)] = ret
+
+	return ret
+}
+
+// children returns the hashes of the children of the node at prefix.
+func (tree *merkleTree) children(prefix []byte) [][]byte {
+	ret := make([][]byte, vpp2pdat.MerkleFanout)
+	for i := range ret {
+		ret[i] = tree.hash(merkleChild(prefix, i))
+	}
+
+	return ret
+}
+
+// node describes the node at prefix, giving the hashes of its
+// children if it's an inner node, or its digests if it's a leaf.
+func (tree *merkleTree) node(prefix []byte) *vpp2papi.MerkleNode {
+	ret := vpp2papi.NewMerkleNode()
+	ret.Prefix = prefix
+	ret.Hash = tree.hash(prefix)
+	if len(prefix) >= vpp2pdat.MerkleDepth {
+		ret.Digests = tree.leaves[string(prefix)]
+		if ret.Digests == nil {
+			ret.Digests = make([]*vpp2papi.DataDigest, 0)
+		}
+	} else {
+		ret.Children = tree.children(prefix)
+	}
+
+	return ret
+}
+
+// digests returns the digests of the leaf at prefix, indexed by key.
+func (tree *merkleTree) digests(prefix []byte) map[string][]byte {
+	ret := make(map[string][]byte)
+	for _, v := range tree.leaves[string(prefix)] {
+		ret[string(v.Key)] = v.Hash
+	}
+
+	return ret
+}
+
+// merkleChild returns the path of the i-th child of the node at prefix.
+func merkleChild(prefix []byte, i int) []byte {
+	ret := make([]byte, len(prefix)+1)
+	copy(ret, prefix)
+	ret[len(prefix)] = byte(i)
+
+	return ret
+}
@@ -0,0 +1,86 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+)
+
+func TestMerkleTree(t *testing.T) {
+	const nbKeys = 100
+
+	keys := make([][]byte, nbKeys)
+	values := make([][]byte, nbKeys)
+	for i := range keys {
+		keys[i] = vpsum.Checksum256([]byte(fmt.Sprintf("level item %d", i)))
+		values[i] = []byte(fmt.Sprintf("map %d", i))
+	}
+
+	tree1 := newMerkleTree(keys, values)
+	tree2 := newMerkleTree(keys, values)
+	if !bytes.Equal(tree1.hash(nil), tree2.hash(nil)) {
+		t.Error("same entries give different root hashes")
+	}
+	if bytes.Equal(tree1.hash(nil), merkleEmptyHash) {
+		t.Error("root hash of a non-empty tree is the empty hash")
+	}
+	if !bytes.Equal(newMerkleTree(nil, nil).hash(nil), merkleEmptyHash) {
+		t.Error("root hash of an empty tree is not the empty hash")
+	}
+
+	// order of entries does not matter
+	reversedKeys := make([][]byte, nbKeys)
+	reversedValues := make([][]byte, nbKeys)
+	for i := range keys {
+		reversedKeys[i] = keys[nbKeys-1-i]
+		reversedValues[i] = values[nbKeys-1-i]
+	}
+	if !bytes.Equal(tree1.hash(nil), newMerkleTree(reversedKeys, reversedValues).hash(nil)) {
+		t.Error("entries order changes the root hash")
+	}
+
+	values[0] = []byte("another map")
+	tree3 := newMerkleTree(keys, values)
+	if bytes.Equal(tree1.hash(nil), tree3.hash(nil)) {
+		t.Error("different values give the same root hash")
+	}
+	path := merklePath(keys[0])
+	if len(path) != vpp2pdat.MerkleDepth {
+		t.Errorf("bad path len %d!=%d", len(path), vpp2pdat.MerkleDepth)
+	}
+	for i := 0; i <= len(path); i++ {
+		if bytes.Equal(tree1.hash(path[:i]), tree3.hash(path[:i])) {
+			t.Errorf("same hash at level %d, on the path of the changed value", i)
+		}
+	}
+	node := tree3.node(path)
+	_, ok := tree3.digests(path)[string(keys[0])]
+	if len(node.Children) != 0 || len(node.Digests) == 0 || !ok {
+		t.Error("leaf does not contain the changed key")
+	}
+	node = tree3.node(nil)
+	if len(node.Children) != vpp2pdat.MerkleFanout {
+		t.Errorf("bad number of children %d!=%d", len(node.Children), vpp2pdat.MerkleFanout)
+	}
+}
@@ -20,6 +20,8 @@
 package vpp2p
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/ufoot/vapor/go/vpid"
 	"github.com/ufoot/vapor/go/vplog"
 	"github.com/ufoot/vapor/go/vpp2papi"
@@ -27,6 +29,7 @@ import (
 	"github.com/ufoot/vapor/go/vpsum"
 	"math/big"
 	"sync"
+	"time"
 )
 
 const (
@@ -44,16 +47,39 @@ type Node struct {
 	// Status about the node
 	Status vpp2papi.NodeStatus
 
+	env     *Env
 	hostPtr *Host
 	ringPtr *Ring
 
 	registerers []NodeRegisterer
 	up          bool
+	syncStop    chan bool
+	autoSync    bool
+	clock       Clock
+
+	peersAccess sync.Mutex
+	lastSeen    map[[vpp2pdat.NodeIDBufNbBytes]byte]time.Time
+
+	store     *dataStore
+	topics    *topicStore
+	directory *ringDirectory
+
+	handlersAccess sync.RWMutex
+	handlers       map[string]MessageHandler
+	secureHandler  SecureMessageHandler
+
+	challengesAccess sync.Mutex
+	challenges       map[[vpp2pdat.ChallengeNbBytes]byte]time.Time
+	nonces           map[[vpp2pdat.HostPubKeyBufNbBytes]byte]map[[vpp2pdat.NonceNbBytes]byte]time.Time
 
 	successorsAccess  sync.RWMutex
 	predecessorAccess sync.RWMutex
 	dAccess           sync.RWMutex
 
+	// predecessors are the NbCopy nodes preceding this one, the
+	// closest first, used to check the nodes pushing replicas.
+	predecessors []*vpp2papi.NodeInfo
+
 	// Successors is list of successing nodes within the ring,
 	// use 1st elem for direct successor.
 	Successor []vpp2papi.VpP2pApi
@@ -83,13 +109,43 @@ func (ria *ringIDAppender) Transform(nodeID []byte) []byte {
 }
 
 // NewNode builds a new node object. Host and Ring are required,
-// nodeID is optional, by default a new nodeID is provided.
-func NewNode(host *Host, ring *Ring, nodeID []byte, registerer NodeRegisterer) (*Node, error) {
+// and must live within env, nodeID is optional, by default a new
+// nodeID is provided.
+func NewNode(env *Env, host *Host, ring *Ring, nodeID []byte) (*Node, error) {
+	return newNode(env, host, ring, nodeID, nil)
+}
+
+// NodeFromInfo builds a node object from its static info, typically
+// a node which has been saved before. This avoids generating a new
+// nodeID, so the node keeps its place on the ring.
+func NodeFromInfo(env *Env, host *Host, ring *Ring, nodeInfo *vpp2papi.NodeInfo) (*Node, error) {
+	if !bytes.Equal(nodeInfo.RingID, ring.Info.RingID) {
+		return nil, fmt.Errorf("node does not belong to ring")
+	}
+	if !bytes.Equal(nodeInfo.HostPubKey, host.Info.HostPubKey) {
+		return nil, fmt.Errorf("node does not belong to host")
+	}
+	if nodeInfo.NodeID == nil || len(nodeInfo.NodeID) != vpp2pdat.NodeIDBufNbBytes {
+		return nil, fmt.Errorf("bad node ID")
+	}
+
+	return newNode(env, host, ring, nodeInfo.NodeID, nodeInfo.NodeSig)
+}
+
+func newNode(env *Env, host *Host, ring *Ring, nodeID, nodeSig []byte) (*Node, error) {
 	var ret Node
 	var info vpp2papi.NodeInfo
 	var err error
 	var sig []byte
 
+	if host.env != env {
+		return nil, fmt.Errorf("host does not live within env")
+	}
+	if ring.env != env {
+		return nil, fmt.Errorf("ring does not live within env")
+	}
+
+	ret.env = env
 	ret.hostPtr = host
 	ret.ringPtr = ring
 	ret.Status.Info = &info
@@ -99,15 +155,22 @@ func NewNode(host *Host, ring *Ring, nodeID []byte, registerer NodeRegisterer) (
 	ret.resetSuccessors()
 	ret.resetD()
 	ret.resetPredecessor()
+	ret.lastSeen = make(map[[vpp2pdat.NodeIDBufNbBytes]byte]time.Time)
+	ret.store = newDataStore(SystemClock())
+	ret.topics = newTopicStore(SystemClock())
+	ret.handlers = make(map[string]MessageHandler)
+	ret.directory = newRingDirectory(SystemClock())
+	ret.challenges = make(map[[vpp2pdat.ChallengeNbBytes]byte]time.Time)
+	ret.nonces = make(map[[vpp2pdat.HostPubKeyBufNbBytes]byte]map[[vpp2pdat.NonceNbBytes]byte]time.Time)
+	ret.autoSync = true
+	ret.clock = SystemClock()
 
 	// by doing this, nodes will always be (un)registerered within hosts
-	// and the global node register. This is usefull when one wants to
+	// and the env node register. This is usefull when one wants to
 	// quickly access them by reference.
-	ret.registerers = make([]NodeRegisterer, 1)
+	ret.registerers = make([]NodeRegisterer, 2)
 	ret.registerers[0] = host.localNodeCatalog
-	if registerer != nil {
-		ret.registerers = append(ret.registerers, registerer)
-	}
+	ret.registerers[1] = env.nodeCatalog
 
 	ret.Status.Info.RingID = make([]byte, len(ring.Info.RingID))
 	copy(ret.Status.Info.RingID, ring.Info.RingID)
@@ -118,16 +181,27 @@ func NewNode(host *Host, ring *Ring, nodeID []byte, registerer NodeRegisterer) (
 		for i, v := range nodeID {
 			ret.Status.Info.NodeID[i] = v
 		}
+		sig = nodeSig
+		if sig == nil {
+			sig = []byte("")
+		}
 	} else {
 		var intNodeID *big.Int
 
 		ria := ringIDAppender{ringID: ring.Info.RingID}
 		if host.CanSign() {
-			intNodeID, sig, _, err = vpid.GenerateID256(host.key, nil, &ria, NodeKeySeconds, NodeKeyZeroes)
+			zeroes := NodeKeyZeroes
+			if int(ring.Info.Config.MinNodeZeroes) > zeroes {
+				zeroes = int(ring.Info.Config.MinNodeZeroes)
+			}
+			intNodeID, sig, _, err = vpid.GenerateID256(host.key, nil, &ria, NodeKeySeconds, zeroes)
 			if err != nil {
 				return nil, err
 			}
 		} else {
+			if ring.Info.Config.MinNodeZeroes > 0 {
+				return nil, fmt.Errorf("ring requires signed nodes with %d zeroes, but host can't sign", ring.Info.Config.MinNodeZeroes)
+			}
 			intNodeID, _, _, err = vpid.GenerateID256(nil, nil, &ria, NodeKeySeconds, NodeKeyZeroes)
 			if err != nil {
 				return nil, err
@@ -144,6 +218,10 @@ func NewNode(host *Host, ring *Ring, nodeID []byte, registerer NodeRegisterer) (
 	if err != nil {
 		return nil, err
 	}
+	err = ret.checkPeer(ret.Status.Info)
+	if err != nil {
+		return nil, err
+	}
 
 	return &ret, nil
 }
@@ -167,10 +245,12 @@ func (node *Node) resetPredecessor() {
 	node.predecessorAccess.Lock()
 
 	node.Status.Predecessor = node.Status.Info
+	node.predecessors = nil
 }
 
 // Start starts the node, that is, makes it available and registers it into
-// all the local node catalogs.
+// all the local node catalogs. Unless disabled with SetAutoSync, it also
+// launches the background stabilization loop, which runs every SyncDelay seconds.
 func (node *Node) Start() {
 	node.up = true
 	if node.registerers != nil {
@@ -178,11 +258,19 @@ func (node *Node) Start() {
 			r.RegisterNode(node)
 		}
 	}
+	if node.autoSync && node.syncStop == nil {
+		node.syncStop = make(chan bool)
+		go node.syncLoop(node.syncStop)
+	}
 }
 
 // Stop stops the node, that is, makes it unavailable and unregisters it from
 // all the local node catalogs.
 func (node *Node) Stop() {
+	if node.syncStop != nil {
+		close(node.syncStop)
+		node.syncStop = nil
+	}
 	if node.registerers != nil {
 		for _, r := range node.registerers {
 			r.UnregisterNode(node)
@@ -191,6 +279,29 @@ func (node *Node) Stop() {
 	node.up = false
 }
 
+// SetAutoSync enables or disables the background stabilization loop
+// launched by Start, it's enabled by default. When disabled, SyncStep
+// must be called explicitly, which is what simulations do to schedule
+// rounds in a reproducible way. Must be called before the node is started.
+func (node *Node) SetAutoSync(autoSync bool) {
+	node.autoSync = autoSync
+}
+
+// SetClock sets the clock used to track peers, challenges and
+// request timestamps, and to expire data, subscriptions and ring announcements.
+// Must be called before the node is started.
+func (node *Node) SetClock(clock Clock) {
+	node.clock = clock
+	node.store.clock = clock
+	node.topics.clock = clock
+	node.directory.clock = clock
+}
+
+// Env returns the environment the node lives in.
+func (node *Node) Env() *Env {
+	return node.env
+}
+
 // Up tells wether the node is up or not.
 func (node *Node) Up() bool {
 	return node.up
@@ -199,15 +310,21 @@ func (node *Node) Up() bool {
 // GetImaginaryNode returns the ID of the imaginary node
 // for a lookup. This is to be used for the first lookup
 // step. Technically you could use any number but choosing
-// it properly makes search faster. Note that keyShift is
-// just key on the first iteration.
+// it properly makes search faster. It should be used along
+// with the keyShift returned by GetKeyShift.
 func (node *Node) GetImaginaryNode(key []byte) []byte {
+	return node.imaginaryNodeFrom(node.Status.Info.NodeID, key)
+}
+
+// imaginaryNodeFrom returns the ID of the imaginary node for a lookup
+// which would start on another node, typically a bootstrap node.
+func (node *Node) imaginaryNodeFrom(nodeID, key []byte) []byte {
 	walker := node.ringPtr.walker
 
 	zeroID := walker.Zero()
 
 	// shift stuff to the right, pad with zeroes
-	nodePart := walker.BackwardElem(node.Status.Info.NodeID, zeroID, walker.N()-int(node.ringPtr.Info.Config.NbStep))
+	nodePart := walker.BackwardElem(nodeID, zeroID, walker.N()-int(node.ringPtr.Info.Config.NbStep))
 	// incr by one to make sure it's *after* us, regardless
 	// of the fact what we're going to add might be smaller
 	// than what we substract by putting zeroes
@@ -222,9 +339,32 @@ func (node *Node) GetImaginaryNode(key []byte) []byte {
 	return walker.Add(nodePart, keyPart)
 }
 
-/*
-// Lookup a key and return the path of nodes to this key.
-func (node *Node) Lookup(key, keyShift, imaginaryNode []byte) ([]*vpp2papi.NodeInfo, error) {
+// GetKeyShift returns the keyShift to be used for the first lookup
+// step, along with the imaginary node returned by GetImaginaryNode.
+// Since the imaginary node already contains the topmost bits of
+// the key, only the remaining bits need to be shifted in.
+func (node *Node) GetKeyShift(key []byte) []byte {
+	walker := node.ringPtr.walker
+
+	return walker.ForwardElem(key, walker.Zero(), walker.N()-int(node.ringPtr.Info.Config.NbStep))
+}
+
+// IsSigned returns true if the node has been signed by corresponding host.
+// It does not check if the signature is valid.
+func (node *Node) IsSigned() bool {
+	return vpp2pdat.NodeInfoIsSigned(node.Status.Info)
+}
+
+// CheckSig checks if the node signature is OK, if it's not, returns 0 and an error.
+// If it's OK, returns the number of zeroes in the signature hash.
+func (node *Node) CheckSig() (int, error) {
+	return vpp2pdat.NodeInfoCheckSig(node.Status.Info)
+}
+
+// Lookup performs a lookup for a given key, using Koorde routing. It returns
+// the path of nodes contacted, starting with the node itself, the last one
+// being the node which holds the key if it has been found.
+func (node *Node) Lookup(key, keyShift, imaginaryNode []byte) (bool, []*vpp2papi.NodeInfo, error) {
 	// pseudo code :
 	// procedure m.LOOKUP(k, kshift, i)
 	//   if k is in (m,successor] then return (successor)
@@ -234,78 +374,135 @@ func (node *Node) Lookup(key, keyShift, imaginaryNode []byte) ([]*vpp2papi.NodeI
 	//              i o topBit(kshift)))
 	//   else return (successor.lookup(k,kshift,i))
 	// Note : i can be chosen so that its low bits are top bits of k
+	found, path, hop := node.routeLookup(key, keyShift, imaginaryNode)
+	for hop != nil {
+		upstreamFound, upstreamPath, err := node.remoteLookup(hop.next, key, hop.keyShift, hop.imaginaryNode)
+		if err == nil {
+			return upstreamFound, append(path, upstreamPath...), nil
+		}
+		if hop.fallback == nil {
+			return false, nil, err
+		}
+		vplog.LoggerDebug(node.env.Logger(), "unable to lookup through D, falling back on successor", err)
+		hop = hop.fallback
+	}
 
-		walker := node.ringPtr.walker
+	return found, path, nil
+}
 
-		ret := make([]*vpp2papi.NodeInfo, 1)
-		ret[0] = &(node.Info)
+// lookupHop is the node a lookup is forwarded to, with the key shift
+// and imaginary node to send it, and the hop to fall back on if that
+// node does not answer.
+type lookupHop struct {
+	next          *vpp2papi.NodeInfo
+	keyShift      []byte
+	imaginaryNode []byte
+	fallback      *lookupHop
+}
 
-		if node.Successor == nil || len(node.Successor) == 0 || node.D == nil || len(node.D) == 0 || node.PredecessorInfo == nil {
-			// no successor -> we're alone!
-			return ret, nil
-		}
+// routeLookup does the local part of a lookup step. It returns the
+// path so far, starting with the node itself, and either the next hop,
+// or nil if the lookup is over, in which case found tells whether the
+// node holding the key is the last one in the path. This is shared
+// by Lookup and LookupMany, so that they route keys the same way.
+func (node *Node) routeLookup(key, keyShift, imaginaryNode []byte) (bool, []*vpp2papi.NodeInfo, *lookupHop) {
+	walker := node.ringPtr.walker
 
-		if walker.GtLe(key, node.PredecessorInfo.NodeID, node.Info.NodeID) {
-			// key is local
-			return ret, nil
-		}
+	ret := make([]*vpp2papi.NodeInfo, 1)
+	ret[0] = node.Status.Info
 
-		for i, successor := range node.Successor {
-			var curInfo *vpp2papi.NodeInfo
-			if i == 0 {
-				curInfo = &(node.Info)
-			} else {
-				curInfo = node.Successor[i-1].Info()
-			}
+	found := node.findKeyOnLocalNode(key)
+	if found != nil {
+		if found != node {
+			ret = append(ret, found.Status.Info)
+		}
+		return true, ret, nil
+	}
 
-			successorInfo := successor.Info()
-			if walker.GtLe(key, curInfo.NodeID, successorInfo.NodeID) {
-				// key is handled by successor
-				return append(ret, successorInfo), nil
-			}
+	successors := node.GetSuccessors()
+	if len(successors) == 0 {
+		// no successor -> we're alone, or not synced yet,
+		// either way there's nobody else to ask
+		return false, ret, nil
+	}
 
-			if walker.GtLe(imaginaryNode, curInfo.NodeID, successorInfo.NodeID) {
-				for _, d := range node.D {
-					upstreamPath, err := d.Lookup(key, walker.NextFirst(keyShift), walker.ForwardElem(keyShift, imaginaryNode, 1))
-					if err != nil {
-						vplog.LogDebug("error contacting remote host", err)
-					}
-					return append(ret, upstreamPath...), nil
-				}
-			}
+	curInfo := node.Status.Info
+	for _, successor := range successors {
+		if walker.GtLe(key, curInfo.NodeID, successor.NodeID) {
+			// key is handled by successor
+			return true, append(ret, successor), nil
 		}
+		curInfo = successor
+	}
 
-		// at this stage, key is not local, not handled by any direct host
-		// we know and De Bruijn walking did not return anything interesting.
-		// So we fall back on the default : ask next node...
-		upstreamPath, err := node.Successor[0].Lookup(key, keyShift, imaginaryNode)
-		if err != nil {
-			return nil, err
+	// if key is not local, and not handled by any direct node we know,
+	// the default is to ask the closest successor preceding the
+	// imaginary node. This is also the fallback when De Bruijn
+	// walking through D does not return anything interesting.
+	next := successors[0]
+	for _, successor := range successors[1:] {
+		if walker.GtLe(imaginaryNode, node.Status.Info.NodeID, successor.NodeID) {
+			break
 		}
-		if upstreamPath != nil {
-			return append(ret, upstreamPath...), nil
-		}
-	return nil, fmt.Errorf("unable to Lookup remotes")
-}
-*/
+		next = successor
+	}
+	hop := &lookupHop{next: next, keyShift: keyShift, imaginaryNode: imaginaryNode}
 
-// IsSigned returns true if the node has been signed by corresponding host.
-// It does not check if the signature is valid.
-func (node *Node) IsSigned() bool {
-	return vpp2pdat.NodeInfoIsSigned(node.Status.Info)
+	d := node.GetD()
+	if d != nil && walker.GtLe(imaginaryNode, node.Status.Info.NodeID, successors[0].NodeID) {
+		hop = &lookupHop{next: d, keyShift: walker.NextFirst(keyShift), imaginaryNode: walker.ForwardElem(imaginaryNode, keyShift, 1), fallback: hop}
+	}
+
+	return false, ret, hop
 }
 
-// CheckSig checks if the node signature is OK, if it's not, returns 0 and an error.
-// If it's OK, returns the number of zeroes in the signature hash.
-func (node *Node) CheckSig() (int, error) {
-	return vpp2pdat.NodeInfoCheckSig(node.Status.Info)
+// contextInfo returns the context to be used when calling a remote node.
+func (node *Node) contextInfo(targetNodeID []byte) *vpp2papi.ContextInfo {
+	ret := vpp2papi.NewContextInfo()
+
+	ret.SourceHost = &(node.hostPtr.Info)
+	ret.SourceRing = &(node.ringPtr.Info)
+	ret.SourceNode = node.Status.Info
+	ret.TargetNodeID = targetNodeID
+	ret.Timestamp = node.clock.Now().Unix()
+	// vprand is not safe for concurrent use, and calls are made from
+	// several goroutines, the target refuses requests without a nonce
+	nonce, err := randomBytes(vpp2pdat.NonceNbBytes)
+	if err != nil {
+		vplog.LoggerWarning(node.env.Logger(), "unable to generate nonce", err)
+	}
+	ret.Nonce = nonce
+
+	return ret
 }
 
-// Lookup performs a lookup for a given key
-func (node *Node) Lookup(key, keyShift, imaginaryNode []byte) (bool, []*vpp2papi.NodeInfo, error) {
-	// todo
+// remoteLookup forwards a lookup to another node.
+func (node *Node) remoteLookup(target *vpp2papi.NodeInfo, key, keyShift, imaginaryNode []byte) (bool, []*vpp2papi.NodeInfo, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return false, nil, err
+	}
+
+	request := vpp2papi.NewLookupRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.Key = key
+	request.KeyShift = keyShift
+	request.ImaginaryNode = imaginaryNode
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.LookupRequestSigBytes(request) })
+	if err != nil {
+		return false, nil, err
+	}
+
+	response, err := targetAPI.Lookup(request)
+	if err != nil {
+		return false, nil, err
+	}
+	if response == nil || response.NodesPath == nil {
+		return false, nil, fmt.Errorf("no path returned by remote lookup")
+	}
 
-	return false, nil, nil
+	return response.Found, response.NodesPath, nil
 }
 
 // GetSuccessors returns the successors of a given node
@@ -337,6 +534,25 @@ func (node *Node) GetPredecessor() *vpp2papi.NodeInfo {
 	return node.Status.Predecessor
 }
 
+// setSuccessors sets the successors of a given node
+func (node *Node) setSuccessors(nodeInfos []*vpp2papi.NodeInfo) {
+	defer node.successorsAccess.Unlock()
+	node.successorsAccess.Lock()
+
+	node.Status.Peers.Successors = make([]*vpp2papi.NodeInfo, len(nodeInfos))
+	for i, v := range nodeInfos {
+		node.Status.Peers.Successors[i] = v
+	}
+}
+
+// setD sets the d of a given node
+func (node *Node) setD(nodeInfo *vpp2papi.NodeInfo) {
+	defer node.dAccess.Unlock()
+	node.dAccess.Lock()
+
+	node.Status.Peers.D = nodeInfo
+}
+
 // setPredecessor returns the predecessor of a given node
 func (node *Node) setPredecessor(nodeInfo *vpp2papi.NodeInfo) {
 	defer node.predecessorAccess.Unlock()
@@ -345,6 +561,23 @@ func (node *Node) setPredecessor(nodeInfo *vpp2papi.NodeInfo) {
 	node.Status.Predecessor = nodeInfo
 }
 
+// getPredecessors returns the nodes preceding this one, the closest first.
+func (node *Node) getPredecessors() []*vpp2papi.NodeInfo {
+	defer node.predecessorAccess.RUnlock()
+	node.predecessorAccess.RLock()
+
+	return node.predecessors
+}
+
+// setPredecessors sets the nodes preceding this one, the closest first.
+func (node *Node) setPredecessors(nodeInfos []*vpp2papi.NodeInfo) {
+	defer node.predecessorAccess.Unlock()
+	node.predecessorAccess.Lock()
+
+	node.predecessors = make([]*vpp2papi.NodeInfo, len(nodeInfos))
+	copy(node.predecessors, nodeInfos)
+}
+
 func (node *Node) isKeyOnNode(key []byte) bool {
 	defer node.predecessorAccess.RUnlock()
 	node.predecessorAccess.RLock()
@@ -368,9 +601,17 @@ func (node *Node) isKeyOnNode(key []byte) bool {
 	return false
 }
 
+// hasPredecessor returns true if the node knows its predecessor, that
+// is, the start of the range of keys it owns. This is not the case
+// for a node which has not joined yet, or which has dropped it.
+func (node *Node) hasPredecessor() bool {
+	return !bytes.Equal(node.GetPredecessor().NodeID, node.Status.Info.NodeID)
+}
+
 func (node *Node) findKeyOnLocalNode(key []byte) *Node {
-	// Check if it's on us
-	if node.isKeyOnNode(key) {
+	// Check if it's on us, if we know our predecessor, or if we are
+	// alone on the ring. Else our successors are asked.
+	if (node.hasPredecessor() || len(node.GetSuccessors()) == 0) && node.isKeyOnNode(key) {
 		return node
 	}
 	// Check if it's on other local nodes, while this is not truely in
@@ -379,6 +620,15 @@ func (node *Node) findKeyOnLocalNode(key []byte) *Node {
 	// optimizing the case when there are many virtual nodes for few
 	// physical hosts.
 	for _, localNode := range node.hostPtr.localNodeCatalog.ListPtr() {
+		if localNode == node || !bytes.Equal(localNode.Status.Info.RingID, node.Status.Info.RingID) {
+			// already checked, or not on the same ring, irrelevant
+			continue
+		}
+		if !localNode.Up() || !localNode.hasPredecessor() {
+			// not started, not joined, or out of sync, such a node
+			// would claim keys it does not own
+			continue
+		}
 		if localNode.isKeyOnNode(key) {
 			return localNode
 		}
@@ -393,24 +643,28 @@ func (node *Node) Sync(source *vpp2papi.NodeInfo, key, keyShift, imaginaryNode [
 	var err error
 	var ok bool
 
-	found = node.findKeyOnLocalNode(key)
+	// only the target node may take source as its predecessor, other
+	// local nodes claiming the key could just be out of sync
+	if node.isKeyOnNode(key) || bytes.Equal(node.GetPredecessor().NodeID, source.NodeID) {
+		found = node
+	}
 	if found == nil {
-		vplog.LogDebug("key is not on local node")
+		vplog.LoggerDebug(node.env.Logger(), "key is not on local node")
 	} else {
 		var sourceApi vpp2papi.VpP2pApi
-		sourceApi, err = GlobalNodeCatalog().ConnectToNode(source.NodeID)
+		sourceApi, err = node.env.nodeCatalog.ConnectToNode(source)
 		if err != nil {
-			vplog.LogDebugf("node can't be found in catalog %v", err)
+			vplog.LoggerDebugf(node.env.Logger(), "node can't be found in catalog %v", err)
 		}
 
 		if sourceApi != nil {
 			var status *vpp2papi.HostStatus
 			status, err = sourceApi.Status()
 			if err != nil {
-				vplog.LogDebug("unable to join host requiring Sync")
+				vplog.LoggerDebug(node.env.Logger(), "unable to join host requiring Sync")
 			} else {
 				if status == nil || status.ThisHostInfo == nil {
-					vplog.LogDebug("nil or invalid status")
+					vplog.LoggerDebug(node.env.Logger(), "nil or invalid status")
 				} else {
 					ok, err = vpp2pdat.CheckHostInfo(status.ThisHostInfo)
 					if ok && err == nil {
@@ -22,6 +22,7 @@ package vpp2p
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 	"github.com/ufoot/vapor/go/vpid"
 	"github.com/ufoot/vapor/go/vpp2papi"
 	"github.com/ufoot/vapor/go/vpp2pdat"
@@ -42,13 +43,14 @@ func setupHostRing(t *testing.T, useSig bool) (*Host, *Ring, error) {
 	var host *Host
 	var ring *Ring
 	var err error
+	var env = NewEnv()
 
-	host, err = NewHost(testTitle, testURL, useSig, GlobalHostInfoCatalog())
+	host, err = NewHost(env, testTitle, testURL, useSig)
 	if err != nil {
 		t.Error("unable to create host", t, err)
 		return nil, nil, err
 	}
-	ring, err = NewRing(host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	ring, err = NewRing(env, host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
 	if err != nil {
 		t.Error("unable to create ring", t, err)
 		return nil, nil, err
@@ -65,7 +67,7 @@ func TestNewNode(t *testing.T) {
 	var zeroes, zeroes2 int
 
 	host, ring, err = setupHostRing(t, true)
-	node, err = NewNode(host, ring, nil, GlobalNodeCatalog())
+	node, err = NewNode(host.env, host, ring, nil)
 	if err != nil {
 		t.Error("unable to create node with a valid pubKey", err)
 	}
@@ -91,7 +93,7 @@ func TestNewNode(t *testing.T) {
 	}
 
 	host, ring, err = setupHostRing(t, false)
-	node, err = NewNode(host, ring, nil, GlobalNodeCatalog())
+	node, err = NewNode(host.env, host, ring, nil)
 	if err != nil {
 		t.Error("unable to create node", err)
 	}
@@ -113,11 +115,11 @@ func TestGetImaginaryNode(t *testing.T) {
 	var err error
 
 	host, ring, err = setupHostRing(t, false)
-	node1, err = NewNode(host, ring, nil, GlobalNodeCatalog())
+	node1, err = NewNode(host.env, host, ring, nil)
 	if err != nil {
 		t.Error("unable to create node", err)
 	}
-	node2, err = NewNode(host, ring, nil, GlobalNodeCatalog())
+	node2, err = NewNode(host.env, host, ring, nil)
 	if err != nil {
 		t.Error("unable to create node", err)
 	}
@@ -158,13 +160,13 @@ func TestGetSync(t *testing.T) {
 	var err error
 
 	host, ring, err = setupHostRing(t, false)
-	node1, err = NewNode(host, ring, nil, GlobalNodeCatalog())
+	node1, err = NewNode(host.env, host, ring, nil)
 	if err != nil {
 		t.Error("unable to create node", err)
 	}
-	for ; !(err == nil && node3 != nil && ring.walker.Cmp(node1.Status.Info.NodeID, node3.Status.Info.NodeID) < 0); node3, err = NewNode(host, ring, nil, GlobalNodeCatalog()) {
+	for ; !(err == nil && node3 != nil && ring.walker.Cmp(node1.Status.Info.NodeID, node3.Status.Info.NodeID) < 0); node3, err = NewNode(host.env, host, ring, nil) {
 	}
-	for ; !(err == nil && node2 != nil && ring.walker.GtLe(node2.Status.Info.NodeID, node1.Status.Info.NodeID, node3.Status.Info.NodeID)); node2, err = NewNode(host, ring, nil, GlobalNodeCatalog()) {
+	for ; !(err == nil && node2 != nil && ring.walker.GtLe(node2.Status.Info.NodeID, node1.Status.Info.NodeID, node3.Status.Info.NodeID)); node2, err = NewNode(host.env, host, ring, nil) {
 	}
 
 	t.Logf("node1 = %s", hex.EncodeToString(node1.Status.Info.NodeID))
@@ -209,26 +211,145 @@ func TestGetSync(t *testing.T) {
 	}
 }
 
-/*
+func setupLinkedNodes(t *testing.T, nbNodes int) ([]*Node, error) {
+	var host *Host
+	var ring *Ring
+	var err error
+	var env = NewEnv()
+
+	nodes := make([]*Node, nbNodes)
+	for i := range nodes {
+		host, err = NewHost(env, testTitle, fmt.Sprintf("%s/%d", testURL, i), false)
+		if err != nil {
+			t.Error("unable to create host", err)
+			return nil, err
+		}
+		if ring == nil {
+			ring, err = NewRing(env, host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+			if err != nil {
+				t.Error("unable to create ring", err)
+				return nil, err
+			}
+		}
+		nodes[i], err = NewNode(env, host, ring, nil)
+		if err != nil {
+			t.Error("unable to create node", err)
+			return nil, err
+		}
+		// keep the list sorted, so that nodes[i+1] is the successor of nodes[i]
+		for j := i; j > 0 && bytes.Compare(nodes[j].Status.Info.NodeID, nodes[j-1].Status.Info.NodeID) < 0; j-- {
+			nodes[j], nodes[j-1] = nodes[j-1], nodes[j]
+		}
+	}
+
+	nbSuccessors := int(ring.Info.Config.NbCopy)
+	for i, node := range nodes {
+		successors := make([]*vpp2papi.NodeInfo, nbSuccessors)
+		for j := range successors {
+			successors[j] = nodes[(i+j+1)%nbNodes].Status.Info
+		}
+		node.setSuccessors(successors)
+		node.setPredecessor(nodes[(i+nbNodes-1)%nbNodes].Status.Info)
+		predecessors := make([]*vpp2papi.NodeInfo, nbSuccessors)
+		for j := range predecessors {
+			predecessors[j] = nodes[(i+(j+1)*nbNodes-j-1)%nbNodes].Status.Info
+		}
+		node.setPredecessors(predecessors)
+		dTarget := ring.walker.NextFirst(node.Status.Info.NodeID)
+		for j := range nodes {
+			if ring.walker.GeLt(dTarget, nodes[j].Status.Info.NodeID, nodes[(j+1)%nbNodes].Status.Info.NodeID) {
+				node.setD(nodes[j].Status.Info)
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
 func TestLookup(t *testing.T) {
-	key := vpsum.Checksum256([]byte("toto"))
-	keyShift := vpsum.Checksum256([]byte("toto"))
-	imaginaryNode := vpsum.Checksum256([]byte("titi"))
-	ai := vpapp.CalcID(vpapp.DefaultPackage(), vpapp.DefaultVersion())
-	h, err := NewHost("foo bar", "http://foo.com", true)
+	const nbNodes = 32
+	const nbKeys = 10
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
 	if err != nil {
-		t.Error("error creating host", err)
+		t.Fatal("unable to setup nodes", err)
 	}
-	r, err := NewRing(h, testTitle, testDescription, ai, nil, nil, nil)
-	if err != nil {
-		t.Error("error creating ring", err)
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	walker := nodes[0].ringPtr.walker
+	for i := 0; i < nbKeys; i++ {
+		key := vpsum.Checksum256([]byte(fmt.Sprintf("key %d", i)))
+		node := nodes[i%nbNodes]
+		found, path, err := node.Lookup(key, node.GetKeyShift(key), node.GetImaginaryNode(key))
+		if err != nil {
+			t.Error("lookup failed", err)
+			continue
+		}
+		if !found {
+			t.Errorf("key %s not found", hex.EncodeToString(key))
+			continue
+		}
+		if bytes.Compare(path[0].NodeID, node.Status.Info.NodeID) != 0 {
+			t.Errorf("path does not start with node %s", hex.EncodeToString(node.Status.Info.NodeID))
+		}
+		owner := path[len(path)-1]
+		ownerNode := node.env.nodeCatalog.GetNode(owner.NodeID)
+		if ownerNode == nil || !ownerNode.isKeyOnNode(key) {
+			t.Errorf("key %s not on node %s", hex.EncodeToString(key), hex.EncodeToString(owner.NodeID))
+		}
+		t.Logf("key %s found on node %s in %d hops (pos=%f)", hex.EncodeToString(key), hex.EncodeToString(owner.NodeID), len(path)-1, walker.RingPos(owner.NodeID))
 	}
-	lp, err := NewLocalProxy(vpsum.Checksum256([]byte("tata")), h, r)
+}
+
+func TestLookupUnjoinedLocalNodes(t *testing.T) {
+	const nbNodes = 4
+	const nbKeys = 50
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
 	if err != nil {
-		t.Error("error creating local proxy", err)
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
 	}
+	// nodes started on the same hosts, but which have not joined,
+	// their predecessor is themselves
+	for _, node := range nodes[:2] {
+		unjoined, err := NewNode(node.env, node.hostPtr, node.ringPtr, nil)
+		if err != nil {
+			t.Fatal("unable to create node", err)
+		}
+		defer unjoined.Stop()
+		unjoined.Start()
+	}
+	// a joined node which has lost its predecessor
+	nodes[2].resetPredecessor()
 
-	path, err := lp.Lookup(key, keyShift, imaginaryNode)
-	t.Log("todo...", path, err)
+	for i := 0; i < nbKeys; i++ {
+		key := vpsum.Checksum256([]byte(fmt.Sprintf("key %d", i)))
+		owner := nodes[0]
+		for _, node := range nodes {
+			if bytes.Compare(node.Status.Info.NodeID, key) >= 0 {
+				owner = node
+				break
+			}
+		}
+		node := nodes[i%nbNodes]
+		found, path, err := node.Lookup(key, node.GetKeyShift(key), node.GetImaginaryNode(key))
+		if err != nil || !found {
+			t.Errorf("key %s not found %v", hex.EncodeToString(key), err)
+			continue
+		}
+		if bytes.Compare(path[len(path)-1].NodeID, owner.Status.Info.NodeID) != 0 {
+			t.Errorf("key %s found on node %s instead of %s", hex.EncodeToString(key), hex.EncodeToString(path[len(path)-1].NodeID), hex.EncodeToString(owner.Status.Info.NodeID))
+		}
+	}
 }
-*/
@@ -0,0 +1,263 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"fmt"
+	"github.com/ufoot/vapor/go/vperror"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"time"
+)
+
+const (
+	// MaxPendingChallenges is the maximum number of challenges a node
+	// keeps track of, waiting for them to be used.
+	MaxPendingChallenges = 1000
+	// MaxSeenNonces is the maximum number of request nonces a node
+	// keeps track of for a given source host, until the requests are
+	// too old to be accepted. A source which makes more requests within
+	// that time is refused, other sources are not affected.
+	MaxSeenNonces = 10000
+	// MaxClockSkew is how far the clock of a peer can be from ours.
+	// Requests whose timestamp is further in the past, or in the future,
+	// are refused. It is much longer than the call timeout, since hosts
+	// on the internet can't be expected to have well synchronized clocks.
+	MaxClockSkew = 5 * time.Minute
+)
+
+// newChallenge returns a new challenge, which can be used once, within
+// the ring call timeout, to authenticate a request on this node.
+func (node *Node) newChallenge() ([]byte, error) {
+	challenge, err := randomBytes(vpp2pdat.ChallengeNbBytes)
+	if err != nil {
+		return nil, err
+	}
+	var buf [vpp2pdat.ChallengeNbBytes]byte
+	copy(buf[:], challenge)
+
+	node.challengesAccess.Lock()
+	defer node.challengesAccess.Unlock()
+
+	if len(node.challenges) >= MaxPendingChallenges {
+		node.purgeChallengesLocked()
+		if len(node.challenges) >= MaxPendingChallenges {
+			return nil, fmt.Errorf("too many pending challenges")
+		}
+	}
+	node.challenges[buf] = node.clock.Now().Add(node.ringPtr.callTimeout)
+
+	return challenge, nil
+}
+
+// useChallenge returns true if the challenge has been issued by this node,
+// and is still valid. Once used, a challenge is forgotten.
+func (node *Node) useChallenge(challenge []byte) bool {
+	var buf [vpp2pdat.ChallengeNbBytes]byte
+
+	if challenge == nil || len(challenge) != vpp2pdat.ChallengeNbBytes {
+		return false
+	}
+	copy(buf[:], challenge)
+
+	node.challengesAccess.Lock()
+	defer node.challengesAccess.Unlock()
+
+	expires, ok := node.challenges[buf]
+	if !ok {
+		return false
+	}
+	delete(node.challenges, buf)
+
+	return node.clock.Now().Before(expires)
+}
+
+// useNonce checks that a request is recent, that is, its timestamp is
+// within MaxClockSkew, and that its nonce has not been used yet by the
+// source host. Nonces are remembered until the request is too old anyway.
+func (node *Node) useNonce(context *vpp2papi.ContextInfo) error {
+	var buf [vpp2pdat.NonceNbBytes]byte
+
+	if len(context.Nonce) != vpp2pdat.NonceNbBytes {
+		return fmt.Errorf("bad nonce")
+	}
+	if context.SourceHost == nil {
+		return fmt.Errorf("no source host")
+	}
+	copy(buf[:], context.Nonce)
+	source := vpp2pdat.HostPubKeyToBuf(context.SourceHost.HostPubKey)
+	now := node.clock.Now()
+	timestamp := time.Unix(context.Timestamp, 0)
+	if timestamp.Before(now.Add(-MaxClockSkew)) {
+		return fmt.Errorf("stale request, made at %s", timestamp)
+	}
+	if timestamp.After(now.Add(MaxClockSkew)) {
+		return fmt.Errorf("request from the future, made at %s", timestamp)
+	}
+
+	node.challengesAccess.Lock()
+	defer node.challengesAccess.Unlock()
+
+	nonces := node.nonces[source]
+	if nonces == nil {
+		nonces = make(map[[vpp2pdat.NonceNbBytes]byte]time.Time)
+		node.nonces[source] = nonces
+	}
+	if _, ok := nonces[buf]; ok {
+		return fmt.Errorf("replayed request")
+	}
+	if len(nonces) >= MaxSeenNonces {
+		node.purgeChallengesLocked()
+		if len(nonces) >= MaxSeenNonces {
+			return fmt.Errorf("too many recent requests from source host")
+		}
+		// purge can forget about the source when all its nonces expire
+		node.nonces[source] = nonces
+	}
+	nonces[buf] = timestamp.Add(MaxClockSkew)
+
+	return nil
+}
+
+func (node *Node) purgeChallengesLocked() {
+	now := node.clock.Now()
+	for k, v := range node.challenges {
+		if now.After(v) {
+			delete(node.challenges, k)
+		}
+	}
+	for source, nonces := range node.nonces {
+		for k, v := range nonces {
+			if now.After(v) {
+				delete(nonces, k)
+			}
+		}
+		if len(nonces) == 0 {
+			delete(node.nonces, source)
+		}
+	}
+}
+
+// purgeChallenges removes expired challenges, and the nonces of
+// requests which are too old to be accepted anyway.
+func (node *Node) purgeChallenges() {
+	node.challengesAccess.Lock()
+	defer node.challengesAccess.Unlock()
+
+	node.purgeChallengesLocked()
+}
+
+// checkPeer checks that a peer has done enough proof of work to be
+// part of the ring, that is, the hash of its signature has at least
+// the number of zeroes required by the ring MinNodeZeroes.
+func (node *Node) checkPeer(peer *vpp2papi.NodeInfo) error {
+	minZeroes := int(node.ringPtr.Info.Config.MinNodeZeroes)
+	if minZeroes <= 0 {
+		return nil
+	}
+	if peer == nil {
+		return fmt.Errorf("no peer")
+	}
+	_, err := vpp2pdat.CheckNodeInfoZeroes(peer, minZeroes)
+	if err != nil {
+		return vperror.Chainf(err, "peer %s rejected", vpp2pdat.NodeIDToShortString(peer.NodeID))
+	}
+
+	return nil
+}
+
+// checkAuth checks that a request comes from a node which knows the
+// ring password, and that it is signed by the source host. On rings
+// without a password, only the signature is checked, and unsigned
+// requests are accepted only if neither the ring nor the source host
+// is signed. The source node must also pass checkPeer. Stale or
+// replayed requests are refused, see useNonce.
+func (node *Node) checkAuth(context *vpp2papi.ContextInfo, sigBytes, sig []byte) error {
+	err := node.checkPeer(context.SourceNode)
+	if err != nil {
+		return err
+	}
+	if node.ringPtr.Info.HasPassword {
+		if !node.useChallenge(context.Challenge) {
+			return fmt.Errorf("bad or expired challenge")
+		}
+		_, err := vpp2pdat.CheckRingAuth(node.ringPtr.secret.PasswordHash, sigBytes, context.RingAuth)
+		if err != nil {
+			return err
+		}
+	}
+	err = node.checkSig(context, sigBytes, sig)
+	if err != nil {
+		return err
+	}
+
+	// last, so that only genuine requests use up their nonce
+	return node.useNonce(context)
+}
+
+// checkSig checks the signature of a request.
+func (node *Node) checkSig(context *vpp2papi.ContextInfo, sigBytes, sig []byte) error {
+	ok, err := vpp2pdat.RequestCheckSig(context, sigBytes, sig, node.ringPtr.IsSigned())
+	if err != nil {
+		return vperror.Chain(err, "bad request signature")
+	}
+	if !ok {
+		return fmt.Errorf("bad request signature")
+	}
+
+	return nil
+}
+
+// sign returns the signature of a request by the host, or an empty
+// signature if the host can't sign.
+func (node *Node) sign(sigBytes []byte) ([]byte, error) {
+	if !node.hostPtr.CanSign() {
+		return []byte(""), nil
+	}
+
+	return node.hostPtr.key.Sign(sigBytes)
+}
+
+// authenticate gets a challenge from the target, if the ring has a
+// password, and fills the context with the proof that this node knows it.
+// sigBytes is called once the challenge is set within the context.
+// It returns the signature of the request, to be set in its Sig field.
+func (node *Node) authenticate(targetAPI vpp2papi.VpP2pApi, context *vpp2papi.ContextInfo, sigBytes func() []byte) ([]byte, error) {
+	if node.ringPtr.Info.HasPassword {
+		request := vpp2papi.NewChallengeRequest()
+		request.Context = context
+		sig, err := node.sign(vpp2pdat.ChallengeRequestSigBytes(request))
+		if err != nil {
+			return nil, err
+		}
+		request.Sig = sig
+		response, err := targetAPI.Challenge(request)
+		if err != nil {
+			return nil, err
+		}
+		if response == nil || response.Challenge == nil {
+			return nil, fmt.Errorf("no challenge returned by remote node")
+		}
+		context.Challenge = response.Challenge
+		context.RingAuth = vpp2pdat.RingAuth(node.ringPtr.secret.PasswordHash, sigBytes())
+	}
+
+	return node.sign(sigBytes())
+}
@@ -0,0 +1,296 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+	"time"
+)
+
+func TestRingPassword(t *testing.T) {
+	var hosts [3]*Host
+	var nodes [3]*Node
+	var ring, badRing *Ring
+	var err error
+	var env = NewEnv()
+
+	passwordHash := vpsum.Checksum256([]byte("password"))
+	for i := range hosts {
+		hosts[i], err = NewHost(env, testTitle, testURL+"/password/"+string('a'+rune(i)), false)
+		if err != nil {
+			t.Fatal("unable to create host", err)
+		}
+	}
+	ring, err = NewRing(env, hosts[0], testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, passwordHash)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	badRing, err = RingFromInfo(ring.env, &(ring.Info), vpsum.Checksum256([]byte("wrong")))
+	if err != nil {
+		t.Fatal("unable to create ring from info", err)
+	}
+	for i := range nodes {
+		r := ring
+		if i == 2 {
+			r = badRing
+		}
+		nodes[i], err = NewNode(env, hosts[i], r, nil)
+		if err != nil {
+			t.Fatal("unable to create node", err)
+		}
+		defer nodes[i].Stop()
+		nodes[i].Start()
+	}
+
+	_, err = nodes[0].remoteGetSuccessors(nodes[1].Status.Info)
+	if err != nil {
+		t.Error("unable to call node knowing the password", err)
+	}
+	_, err = nodes[2].remoteGetSuccessors(nodes[1].Status.Info)
+	if err == nil {
+		t.Error("call accepted from node with a wrong password")
+	}
+
+	request := vpp2papi.NewGetSuccessorsRequest()
+	request.Context = nodes[0].contextInfo(nodes[1].Status.Info.NodeID)
+	_, err = hosts[1].GetSuccessors(request)
+	if err == nil {
+		t.Error("call accepted without authentication")
+	}
+	request.Sig, err = nodes[0].authenticate(hosts[1], request.Context, func() []byte { return vpp2pdat.GetSuccessorsRequestSigBytes(request) })
+	if err != nil {
+		t.Fatal("unable to authenticate", err)
+	}
+	_, err = hosts[1].GetSuccessors(request)
+	if err != nil {
+		t.Error("authenticated call rejected", err)
+	}
+	_, err = hosts[1].GetSuccessors(request)
+	if err == nil {
+		t.Error("replayed call accepted")
+	}
+}
+
+func TestRequestSig(t *testing.T) {
+	var hosts [3]*Host
+	var nodes [3]*Node
+	var ring *Ring
+	var err error
+	var env = NewEnv()
+
+	for i := range hosts {
+		hosts[i], err = NewHost(env, testTitle, testURL+"/sig/"+string('a'+rune(i)), i == 0)
+		if err != nil {
+			t.Fatal("unable to create host", err)
+		}
+	}
+	ring, err = NewRing(env, hosts[0], testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	if !ring.IsSigned() {
+		t.Fatal("ring is not signed")
+	}
+	for i := range nodes {
+		nodes[i], err = NewNode(env, hosts[i], ring, nil)
+		if err != nil {
+			t.Fatal("unable to create node", err)
+		}
+		defer nodes[i].Stop()
+		nodes[i].Start()
+	}
+
+	_, err = nodes[0].remoteGetSuccessors(nodes[1].Status.Info)
+	if err != nil {
+		t.Error("unable to call node with a signed request", err)
+	}
+	_, err = nodes[2].remoteGetSuccessors(nodes[1].Status.Info)
+	if err == nil {
+		t.Error("unsigned request accepted on a signed ring")
+	}
+
+	request := vpp2papi.NewGetSuccessorsRequest()
+	request.Context = nodes[0].contextInfo(nodes[1].Status.Info.NodeID)
+	request.Sig, err = nodes[0].authenticate(hosts[1], request.Context, func() []byte { return vpp2pdat.GetSuccessorsRequestSigBytes(request) })
+	if err != nil {
+		t.Fatal("unable to sign request", err)
+	}
+	request.Context.TargetNodeID = nodes[2].Status.Info.NodeID
+	_, err = hosts[2].GetSuccessors(request)
+	if err == nil {
+		t.Error("tampered request accepted")
+	}
+	request.Context.TargetNodeID = nodes[1].Status.Info.NodeID
+	request.Context.SourceNode = nodes[2].Status.Info
+	_, err = hosts[1].GetSuccessors(request)
+	if err == nil {
+		t.Error("request accepted with a source node from another host")
+	}
+}
+
+func TestRequestReplay(t *testing.T) {
+	var hosts [2]*Host
+	var nodes [2]*Node
+	var err error
+	var env = NewEnv()
+
+	for i := range hosts {
+		hosts[i], err = NewHost(env, testTitle, testURL+"/replay/"+string('a'+rune(i)), true)
+		if err != nil {
+			t.Fatal("unable to create host", err)
+		}
+	}
+	ring, err := NewRing(env, hosts[0], testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	for i := range nodes {
+		nodes[i], err = NewNode(env, hosts[i], ring, nil)
+		if err != nil {
+			t.Fatal("unable to create node", err)
+		}
+		defer nodes[i].Stop()
+		nodes[i].Start()
+	}
+
+	request := vpp2papi.NewGetSuccessorsRequest()
+	request.Context = nodes[0].contextInfo(nodes[1].Status.Info.NodeID)
+	request.Sig, err = nodes[0].authenticate(hosts[1], request.Context, func() []byte { return vpp2pdat.GetSuccessorsRequestSigBytes(request) })
+	if err != nil {
+		t.Fatal("unable to sign request", err)
+	}
+	_, err = hosts[1].GetSuccessors(request)
+	if err != nil {
+		t.Error("signed call rejected", err)
+	}
+	_, err = hosts[1].GetSuccessors(request)
+	if err == nil {
+		t.Error("replayed call accepted on a ring without a password")
+	}
+
+	// clocks a bit off are fine, but not too much
+	request.Context = nodes[0].contextInfo(nodes[1].Status.Info.NodeID)
+	request.Context.Timestamp += int64(2 * vpp2pdat.DefaultCallTimeout)
+	request.Sig, err = nodes[0].authenticate(hosts[1], request.Context, func() []byte { return vpp2pdat.GetSuccessorsRequestSigBytes(request) })
+	if err != nil {
+		t.Fatal("unable to sign request", err)
+	}
+	_, err = hosts[1].GetSuccessors(request)
+	if err != nil {
+		t.Error("call from a host with a skewed clock rejected", err)
+	}
+	request.Context = nodes[0].contextInfo(nodes[1].Status.Info.NodeID)
+	request.Context.Timestamp -= int64(2 * MaxClockSkew / time.Second)
+	request.Sig, err = nodes[0].authenticate(hosts[1], request.Context, func() []byte { return vpp2pdat.GetSuccessorsRequestSigBytes(request) })
+	if err != nil {
+		t.Fatal("unable to sign request", err)
+	}
+	_, err = hosts[1].GetSuccessors(request)
+	if err == nil {
+		t.Error("stale call accepted")
+	}
+}
+
+func TestNonceSources(t *testing.T) {
+	nodes, err := setupLinkedNodes(t, 3)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	target := nodes[0]
+
+	// a source filling its nonces table does not prevent others from calling
+	for i := 0; i < MaxSeenNonces; i++ {
+		err = target.useNonce(nodes[1].contextInfo(target.Status.Info.NodeID))
+		if err != nil {
+			t.Fatal("request refused before the limit", i, err)
+		}
+	}
+	err = target.useNonce(nodes[1].contextInfo(target.Status.Info.NodeID))
+	if err == nil {
+		t.Error("request accepted from a source over the limit")
+	}
+	err = target.useNonce(nodes[2].contextInfo(target.Status.Info.NodeID))
+	if err != nil {
+		t.Error("request refused from another source", err)
+	}
+}
+
+func TestMinNodeZeroes(t *testing.T) {
+	var hosts [3]*Host
+	var nodes [3]*Node
+	var err error
+	var env = NewEnv()
+
+	// host 0 can't sign, so it can't produce node IDs with enough zeroes
+	for i := range hosts {
+		hosts[i], err = NewHost(env, testTitle, testURL+"/zeroes/"+string('a'+rune(i)), i > 0)
+		if err != nil {
+			t.Fatal("unable to create host", err)
+		}
+	}
+	config := vpp2pdat.DefaultRingConfig()
+	config.MinNodeZeroes = NodeKeyZeroes
+	ring, err := NewRing(env, hosts[0], testTitle, testDescription, testID, config, nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	_, err = NewNode(env, hosts[0], ring, nil)
+	if err == nil {
+		t.Error("node created on a host which can't sign")
+	}
+
+	// the ring is not signed, so one can change its config, and
+	// use it to create a node which does not meet the requirement
+	laxInfo := ring.Info
+	laxInfo.Config = vpp2pdat.DefaultRingConfig()
+	laxRing, err := RingFromInfo(env, &laxInfo, nil)
+	if err != nil {
+		t.Fatal("unable to create lax ring", err)
+	}
+	nodes[0], err = NewNode(env, hosts[0], laxRing, nil)
+	if err != nil {
+		t.Fatal("unable to create node on lax ring", err)
+	}
+	defer nodes[0].Stop()
+	for i := 1; i < len(nodes); i++ {
+		nodes[i], err = NewNode(env, hosts[i], ring, nil)
+		if err != nil {
+			t.Fatal("unable to create node", err)
+		}
+		defer nodes[i].Stop()
+	}
+
+	nodes[1].Start()
+	err = nodes[2].Join(hosts[1].Info.HostURL)
+	if err != nil {
+		t.Error("node with enough zeroes unable to join", err)
+	}
+	err = nodes[1].checkPeer(nodes[0].Status.Info)
+	if err == nil {
+		t.Error("peer without enough zeroes accepted")
+	}
+	err = nodes[0].Join(hosts[1].Info.HostURL)
+	if err == nil {
+		t.Error("node without enough zeroes joined")
+	}
+}
@@ -20,9 +20,11 @@
 package vpp2p
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/ufoot/vapor/go/vpp2papi"
 	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sort"
 	"sync"
 )
 
@@ -30,33 +32,124 @@ import (
 type NodeCatalog struct {
 	access sync.RWMutex
 	nodes  map[[vpp2pdat.NodeIDBufNbBytes]byte]*Node
+
+	hostInfoCatalog *HostInfoCatalog
+	remoteHostPool  *RemoteHostPool
+
+	transportAccess sync.RWMutex
+	transport       Transport
+}
+
+// nodeList sorts nodes by ID.
+type nodeList []*Node
+
+func (l nodeList) Len() int {
+	return len(l)
 }
 
-var globalNodeCatalog = NewNodeCatalog()
+func (l nodeList) Less(i, j int) bool {
+	return bytes.Compare(l[i].Status.Info.NodeID, l[j].Status.Info.NodeID) < 0
+}
+
+func (l nodeList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+// Transport is used by a catalog to reach the hosts of its local nodes,
+// instead of calling them directly. This is typically used to simulate
+// a network between local hosts.
+type Transport interface {
+	// Connect returns a handler which forwards API calls to host.
+	Connect(host *Host) (vpp2papi.VpP2pApi, error)
+}
 
 // NewNodeCatalog creates a new instance of a local node catalog
 func NewNodeCatalog() *NodeCatalog {
 	return &NodeCatalog{nodes: make(map[[vpp2pdat.NodeIDBufNbBytes]byte]*Node)}
 }
 
-// GlobalNodeCatalog returns a catalog containing all local nodes.
-func GlobalNodeCatalog() *NodeCatalog {
-	return globalNodeCatalog
+// NewNodeCatalogWithRemotes creates a new instance of a node catalog
+// which, when a node is not found locally, connects to its host
+// over the network, the host being searched in hostInfoCatalog.
+func NewNodeCatalogWithRemotes(hostInfoCatalog *HostInfoCatalog, remoteHostPool *RemoteHostPool) *NodeCatalog {
+	ret := NewNodeCatalog()
+
+	ret.hostInfoCatalog = hostInfoCatalog
+	ret.remoteHostPool = remoteHostPool
+
+	return ret
+}
+
+// SetTransport sets the transport used to reach local hosts. Passing
+// nil restores the default, which is to call local hosts directly.
+// It's thread-safe.
+func (c *NodeCatalog) SetTransport(transport Transport) {
+	defer c.transportAccess.Unlock()
+	c.transportAccess.Lock()
+
+	c.transport = transport
+}
+
+func (c *NodeCatalog) connectLocal(host *Host) (vpp2papi.VpP2pApi, error) {
+	c.transportAccess.RLock()
+	transport := c.transport
+	c.transportAccess.RUnlock()
+
+	if transport != nil {
+		return transport.Connect(host)
+	}
+
+	return host, nil
 }
 
 // ConnectToNode returns a handler which makes possible API calls on it.
+// If the node is not local, and the catalog has been created with
+// remotes support, its host is contacted over the network.
 // It's thread-safe.
-func (c *NodeCatalog) ConnectToNode(nodeID []byte) (vpp2papi.VpP2pApi, error) {
-	defer c.access.RUnlock()
+func (c *NodeCatalog) ConnectToNode(nodeInfo *vpp2papi.NodeInfo) (vpp2papi.VpP2pApi, error) {
+	n := c.GetNode(nodeInfo.NodeID)
+	if n != nil {
+		return c.connectLocal(n.hostPtr)
+	}
+	if c.hostInfoCatalog == nil || c.remoteHostPool == nil {
+		return nil, fmt.Errorf("node does not exist")
+	}
+	hostInfo := c.hostInfoCatalog.GetHost(nodeInfo.HostPubKey)
+	if hostInfo == nil {
+		return nil, fmt.Errorf("node does not exist locally, and its host is unknown")
+	}
+	remoteHost, err := c.remoteHostPool.Connect(hostInfo.HostURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return remoteHost, nil
+}
+
+// ConnectToHost returns a handler which makes possible API calls on
+// the host with the given URL. If no node registered within the catalog
+// is on that host, and the catalog has been created with remotes
+// support, the host is contacted over the network.
+// It's thread-safe.
+func (c *NodeCatalog) ConnectToHost(hostURL string) (vpp2papi.VpP2pApi, error) {
 	c.access.RLock()
+	for _, n := range c.nodes {
+		if n.hostPtr.Info.HostURL == hostURL {
+			c.access.RUnlock()
+			return c.connectLocal(n.hostPtr)
+		}
+	}
+	c.access.RUnlock()
 
-	nodeIDBuf := vpp2pdat.NodeIDToBuf(nodeID)
-	n := c.nodes[nodeIDBuf]
-	if n == nil {
-		return nil, fmt.Errorf("node does not exist")
+	if c.remoteHostPool == nil {
+		return nil, fmt.Errorf("host does not exist")
+	}
+	remoteHost, err := c.remoteHostPool.Connect(hostURL)
+	if err != nil {
+		return nil, err
 	}
 
-	return n.hostPtr, nil
+	return remoteHost, nil
 }
 
 // HasNode returns true if the node exists in the catalog.
@@ -102,24 +195,22 @@ func (c *NodeCatalog) UnregisterNode(node *Node) {
 	delete(c.nodes, nodeIDBuf)
 }
 
-// List returns a list of local nodes. It returns static
-// data about the node, not the nodes themselves.
+// List returns a list of local nodes, sorted by node ID. It returns
+// static data about the node, not the nodes themselves.
 func (c *NodeCatalog) List() []*vpp2papi.NodeInfo {
-	defer c.access.RUnlock()
-	c.access.RLock()
+	nodes := c.ListPtr()
 
-	i := 0
-	ret := make([]*vpp2papi.NodeInfo, len(c.nodes))
-	for _, value := range c.nodes {
+	ret := make([]*vpp2papi.NodeInfo, len(nodes))
+	for i, value := range nodes {
 		ret[i] = value.Status.Info
-		i++
 	}
 
 	return ret
 }
 
-// ListPtr returns a list of local nodes. It returns static
-// a pointer on nodes themselves.
+// ListPtr returns a list of local nodes, sorted by node ID. It returns
+// a pointer on nodes themselves. Sorting them makes things reproducible,
+// as maps are iterated in random order.
 func (c *NodeCatalog) ListPtr() []*Node {
 	defer c.access.RUnlock()
 	c.access.RLock()
@@ -130,6 +221,7 @@ func (c *NodeCatalog) ListPtr() []*Node {
 		ret[i] = value
 		i++
 	}
+	sort.Sort(nodeList(ret))
 
 	return ret
 }
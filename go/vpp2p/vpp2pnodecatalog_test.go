@@ -24,70 +24,71 @@ import (
 	"testing"
 )
 
-func TestGlobalNodeCatalog(t *testing.T) {
+func TestNodeCatalog(t *testing.T) {
 	var host *Host
 	var node1, node2 *Node
 	var ring *Ring
 	var err error
+	var env = NewEnv()
 
-	host, err = NewHost(testTitle, testURL, false, GlobalHostInfoCatalog())
+	host, err = NewHost(env, testTitle, testURL, false)
 	if err != nil {
 		t.Error("unable to create host", err)
 	}
-	ring, err = NewRing(host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	ring, err = NewRing(env, host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
 	if err != nil {
 		t.Error("unable to create ring", err)
 	}
-	node1, err = NewNode(host, ring, nil, GlobalNodeCatalog())
+	node1, err = NewNode(env, host, ring, nil)
 	if err != nil {
 		t.Error("unable to create node1", err)
 	}
-	node2, err = NewNode(host, ring, nil, GlobalNodeCatalog())
+	node2, err = NewNode(env, host, ring, nil)
 	if err != nil {
 		t.Error("unable to create node2", err)
 	}
 
-	if GlobalNodeCatalog().HasNode(node1.Status.Info.NodeID) {
-		t.Error("global catalog has node1, but it's not started yet")
+	if env.NodeCatalog().HasNode(node1.Status.Info.NodeID) {
+		t.Error("env catalog has node1, but it's not started yet")
 	}
 	if host.localNodeCatalog.HasNode(node1.Status.Info.NodeID) {
 		t.Error("host local catalog has node1, but it's not started yet")
 	}
-	if GlobalNodeCatalog().HasNode(node2.Status.Info.NodeID) {
-		t.Error("global catalog has node2, but it's not started yet")
+	if env.NodeCatalog().HasNode(node2.Status.Info.NodeID) {
+		t.Error("env catalog has node2, but it's not started yet")
 	}
 	if host.localNodeCatalog.HasNode(node2.Status.Info.NodeID) {
 		t.Error("host local catalog has node2, but it's not started yet")
 	}
 	node1.Start()
 	node2.Start()
-	if !GlobalNodeCatalog().HasNode(node1.Status.Info.NodeID) {
-		t.Error("global catalog does not have node1, but it has been started")
+	if !env.NodeCatalog().HasNode(node1.Status.Info.NodeID) {
+		t.Error("env catalog does not have node1, but it has been started")
 	}
 	if !host.localNodeCatalog.HasNode(node1.Status.Info.NodeID) {
 		t.Error("host local catalog does not have node1, but it has been started")
 	}
-	if !GlobalNodeCatalog().HasNode(node2.Status.Info.NodeID) {
-		t.Error("global catalog does not have node2, but it has been started")
+	if !env.NodeCatalog().HasNode(node2.Status.Info.NodeID) {
+		t.Error("env catalog does not have node2, but it has been started")
 	}
 	if !host.localNodeCatalog.HasNode(node2.Status.Info.NodeID) {
 		t.Error("host local catalog does not have node2, but it has been started")
 	}
-	if len(GlobalNodeCatalog().List()) != 2 {
-		t.Error("global catalog length should be 2")
+	if len(env.NodeCatalog().List()) != 2 {
+		t.Error("env catalog length should be 2")
 	}
 	if len(host.localNodeCatalog.List()) != 2 {
 		t.Error("host local catalog length should be 2")
 	}
 	node1.Stop()
-	if GlobalNodeCatalog().HasNode(node1.Status.Info.NodeID) {
-		t.Error("global catalog has node1, but it has been stopped")
+	if env.NodeCatalog().HasNode(node1.Status.Info.NodeID) {
+		t.Error("env catalog has node1, but it has been stopped")
 	}
 	if host.localNodeCatalog.HasNode(node1.Status.Info.NodeID) {
 		t.Error("host local catalog has node1, but has been stopped")
 	}
-	if !GlobalNodeCatalog().HasNode(node2.Status.Info.NodeID) {
-		t.Error("global catalog does not have node2, but it should still be started")
+	if !env.NodeCatalog().HasNode(node2.Status.Info.NodeID) {
+		t.Error("env catalog does not have node2, but it should still be started")
 	}
 	if !host.localNodeCatalog.HasNode(node2.Status.Info.NodeID) {
 		t.Error("host local catalog does not have node2, but it shoulto still be started")
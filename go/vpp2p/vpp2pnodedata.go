@@ -0,0 +1,279 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+)
+
+// lookupOwner finds the node holding a key, returns the path to it,
+// the last element being the owner.
+func (node *Node) lookupOwner(key []byte) ([]*vpp2papi.NodeInfo, error) {
+	found, path, err := node.Lookup(key, node.GetKeyShift(key), node.GetImaginaryNode(key))
+	if err != nil {
+		return nil, err
+	}
+	if !found || len(path) == 0 {
+		return path, fmt.Errorf("unable to find node holding key")
+	}
+
+	return path, nil
+}
+
+// replicas returns the nodes which should hold copies of keys
+// owned by this node, that is, the NbCopy-1 first successors.
+func (node *Node) replicas() []*vpp2papi.NodeInfo {
+	successors := node.GetSuccessors()
+	nbReplicas := int(node.ringPtr.Info.Config.NbCopy) - 1
+	if nbReplicas > len(successors) {
+		nbReplicas = len(successors)
+	}
+	if nbReplicas < 0 {
+		nbReplicas = 0
+	}
+
+	return successors[0:nbReplicas]
+}
+
+// checkKeyOwner checks that source can push a copy of key on this node,
+// that is, source owns key and this node is one of its NbCopy-1 replicas.
+// If fromReplica is true, source can also be one of the replicas of the
+// owner, preceding this node. The owner is searched within the nodes
+// preceding this node, as recorded by refreshPredecessors, so that a node
+// can't push keys it does not own, or push them anywhere on the ring.
+func (node *Node) checkKeyOwner(source *vpp2papi.NodeInfo, key []byte, fromReplica bool) error {
+	walker := node.ringPtr.walker
+
+	if source == nil {
+		return fmt.Errorf("no source node")
+	}
+	predecessors := node.getPredecessors()
+	nbReplicas := int(node.ringPtr.Info.Config.NbCopy) - 1
+	for i := 0; i < nbReplicas && i+1 < len(predecessors); i++ {
+		if !walker.GtLe(key, predecessors[i+1].NodeID, predecessors[i].NodeID) {
+			continue
+		}
+		// predecessors[i] owns key, source must be the owner itself,
+		// or one of its replicas between the owner and this node
+		for j := 0; j <= i; j++ {
+			if bytes.Equal(source.NodeID, predecessors[j].NodeID) && (j == i || fromReplica) {
+				return nil
+			}
+		}
+		return fmt.Errorf("key is not owned by source node")
+	}
+
+	return fmt.Errorf("key is not owned by any of the %d nodes preceding this one", nbReplicas)
+}
+
+// Put stores a value on the ring. If replica is true, the value is stored
+// on this node only. Otherwise it is stored on the node holding the key,
+// and on its NbCopy-1 successors. Returns the number of copies stored.
+func (node *Node) Put(key, value []byte, replica bool) (int, []*vpp2papi.NodeInfo, error) {
+	if replica {
+		node.store.put(key, value, node.ringPtr.dataLifetime)
+		return 1, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+	}
+
+	path, err := node.lookupOwner(key)
+	if err != nil {
+		return 0, path, err
+	}
+	owner := path[len(path)-1]
+	if !bytes.Equal(owner.NodeID, node.Status.Info.NodeID) {
+		nbCopy, err := node.remotePut(owner, key, value, false)
+		return nbCopy, path, err
+	}
+
+	node.store.put(key, value, node.ringPtr.dataLifetime)
+	nbCopy := 1
+	for _, replica := range node.replicas() {
+		_, err = node.remotePut(replica, key, value, true)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to put replica", err)
+			continue
+		}
+		nbCopy++
+	}
+
+	return nbCopy, path, nil
+}
+
+// Get retrieves a value from the ring. If replica is true, only the
+// local store is searched. Otherwise the request is sent to the node
+// holding the key, which falls back on its successors if needed.
+func (node *Node) Get(key []byte, replica bool) (bool, []byte, []*vpp2papi.NodeInfo, error) {
+	if replica {
+		value, ok := node.store.get(key)
+		return ok, value, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+	}
+
+	path, err := node.lookupOwner(key)
+	if err != nil {
+		return false, nil, path, err
+	}
+	owner := path[len(path)-1]
+	if !bytes.Equal(owner.NodeID, node.Status.Info.NodeID) {
+		found, value, err := node.remoteGet(owner, key, false)
+		return found, value, path, err
+	}
+
+	value, ok := node.store.get(key)
+	if ok {
+		return true, value, path, nil
+	}
+	// the key might have been stored before we joined,
+	// so the copies could be on successors only
+	for _, replica := range node.replicas() {
+		found, value, err := node.remoteGet(replica, key, true)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to get replica", err)
+			continue
+		}
+		if found {
+			return true, value, append(path, replica), nil
+		}
+	}
+
+	return false, nil, path, nil
+}
+
+// Delete removes a value from the ring. If replica is true, the value is
+// removed from this node only. Otherwise it is removed from the node holding
+// the key, and from its NbCopy-1 successors. Returns the number of copies removed.
+func (node *Node) Delete(key []byte, replica bool) (int, []*vpp2papi.NodeInfo, error) {
+	if replica {
+		if node.store.delete(key) {
+			return 1, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+		}
+		return 0, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+	}
+
+	path, err := node.lookupOwner(key)
+	if err != nil {
+		return 0, path, err
+	}
+	owner := path[len(path)-1]
+	if !bytes.Equal(owner.NodeID, node.Status.Info.NodeID) {
+		nbCopy, err := node.remoteDelete(owner, key, false)
+		return nbCopy, path, err
+	}
+
+	nbCopy := 0
+	if node.store.delete(key) {
+		nbCopy++
+	}
+	for _, replica := range node.replicas() {
+		n, err := node.remoteDelete(replica, key, true)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to delete replica", err)
+			continue
+		}
+		nbCopy += n
+	}
+
+	return nbCopy, path, nil
+}
+
+func (node *Node) remotePut(target *vpp2papi.NodeInfo, key, value []byte, replica bool) (int, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return 0, err
+	}
+
+	request := vpp2papi.NewPutRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.Key = key
+	request.Value = value
+	request.Replica = replica
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.PutRequestSigBytes(request) })
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := targetAPI.Put(request)
+	if err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, fmt.Errorf("no response to remote put")
+	}
+
+	return int(response.NbCopy), nil
+}
+
+func (node *Node) remoteGet(target *vpp2papi.NodeInfo, key []byte, replica bool) (bool, []byte, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return false, nil, err
+	}
+
+	request := vpp2papi.NewGetRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.Key = key
+	request.Replica = replica
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.GetRequestSigBytes(request) })
+	if err != nil {
+		return false, nil, err
+	}
+
+	response, err := targetAPI.Get(request)
+	if err != nil {
+		return false, nil, err
+	}
+	if response == nil {
+		return false, nil, fmt.Errorf("no response to remote get")
+	}
+
+	return response.Found, response.Value, nil
+}
+
+func (node *Node) remoteDelete(target *vpp2papi.NodeInfo, key []byte, replica bool) (int, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return 0, err
+	}
+
+	request := vpp2papi.NewDeleteRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.Key = key
+	request.Replica = replica
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.DeleteRequestSigBytes(request) })
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := targetAPI.Delete(request)
+	if err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, fmt.Errorf("no response to remote delete")
+	}
+
+	return int(response.NbCopy), nil
+}
@@ -0,0 +1,156 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+	"time"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	const nbNodes = 16
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	key := vpsum.Checksum256([]byte("level"))
+	value := []byte("this is a level")
+	nbCopy := int(nodes[0].ringPtr.Info.Config.NbCopy)
+
+	n, path, err := nodes[0].Put(key, value, false)
+	if err != nil {
+		t.Fatal("unable to put value", err)
+	}
+	if n != nbCopy {
+		t.Errorf("bad number of copies %d!=%d", n, nbCopy)
+	}
+	stored := 0
+	for _, node := range nodes {
+		if _, ok := node.store.get(key); ok {
+			stored++
+		}
+	}
+	if stored != nbCopy {
+		t.Errorf("bad number of stored copies %d!=%d", stored, nbCopy)
+	}
+
+	owner := nodes[0].env.nodeCatalog.GetNode(path[len(path)-1].NodeID)
+	if owner == nil || !owner.isKeyOnNode(key) {
+		t.Fatal("value not put on owner")
+	}
+	for _, node := range nodes {
+		found, got, _, err := node.Get(key, false)
+		if err != nil || !found || bytes.Compare(got, value) != 0 {
+			t.Error("unable to get value", err)
+		}
+	}
+
+	// owner losing its copy should not prevent from getting the value
+	owner.store.delete(key)
+	found, got, _, err := nodes[nbNodes/2].Get(key, false)
+	if err != nil || !found || bytes.Compare(got, value) != 0 {
+		t.Error("unable to get value from replicas", err)
+	}
+
+	n, _, err = nodes[nbNodes-1].Delete(key, false)
+	if err != nil {
+		t.Error("unable to delete value", err)
+	}
+	if n != nbCopy-1 {
+		t.Errorf("bad number of deleted copies %d!=%d", n, nbCopy-1)
+	}
+	found, _, _, err = nodes[0].Get(key, false)
+	if err != nil || found {
+		t.Error("value still there after delete", err)
+	}
+
+	// values expire after DataLifetime
+	owner.ringPtr.dataLifetime = time.Duration(0)
+	_, _, err = nodes[0].Put(key, value, false)
+	if err != nil {
+		t.Error("unable to put value", err)
+	}
+	time.Sleep(time.Millisecond)
+	found, _, _, err = nodes[0].Get(key, false)
+	if err != nil || found {
+		t.Error("value still there after expiration", err)
+	}
+}
+
+func TestPutReplicaChecks(t *testing.T) {
+	const nbNodes = 8
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	// a key owned by nodes[0], whose replicas are nodes[1] and nodes[2]
+	key := nodes[0].Status.Info.NodeID
+	value := []byte("this is a level")
+	owner := nodes[0]
+	replica := nodes[1]
+	other := nodes[nbNodes/2]
+	far := nodes[int(owner.ringPtr.Info.Config.NbCopy)]
+
+	_, err = other.remotePut(replica.Status.Info, key, value, true)
+	if err == nil {
+		t.Error("replica put by a node which does not own the key")
+	}
+	_, err = owner.remotePut(far.Status.Info, key, value, true)
+	if err == nil {
+		t.Error("replica put on a node which is not a replica of the owner")
+	}
+	if _, ok := far.store.get(key); ok {
+		t.Error("value stored outside the replicas")
+	}
+
+	n, err := owner.remotePut(replica.Status.Info, key, value, true)
+	if err != nil || n != 1 {
+		t.Error("replica refused from the owner", err)
+	}
+	_, err = other.remoteDelete(replica.Status.Info, key, true)
+	if err == nil {
+		t.Error("replica deleted by a node which does not own the key")
+	}
+	if _, ok := replica.store.get(key); !ok {
+		t.Error("replica removed by a node which does not own the key")
+	}
+	n, err = owner.remoteDelete(replica.Status.Info, key, true)
+	if err != nil || n != 1 {
+		t.Error("replica delete refused from the owner", err)
+	}
+}
@@ -0,0 +1,186 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"time"
+)
+
+// checkAnnouncedRing checks a ring can be announced in a directory,
+// it must be valid and signed.
+func checkAnnouncedRing(ringInfo *vpp2papi.RingInfo) error {
+	if ringInfo == nil || ringInfo.Config == nil {
+		return fmt.Errorf("no ring info")
+	}
+	if !vpp2pdat.RingInfoIsSigned(ringInfo) {
+		return fmt.Errorf("only signed rings can be announced")
+	}
+	_, err := vpp2pdat.CheckRingInfo(ringInfo)
+
+	return err
+}
+
+// AnnounceRing publishes a ring in the ring directory, which is held by the
+// node owning vpp2pdat.RingDirectoryKey(), and replicated on its successors.
+// Announcements expire after vpp2pdat.RingAnnounceLifetime seconds, so rings
+// should be announced again periodically. While any ring can hold
+// a directory, the one everybody knows about is BuiltinRing0.
+// If replica is true, the ring is stored in this node directory only.
+// Returns the number of copies stored.
+func (node *Node) AnnounceRing(ringInfo *vpp2papi.RingInfo, replica bool) (int, []*vpp2papi.NodeInfo, error) {
+	lifetime := time.Second * time.Duration(vpp2pdat.RingAnnounceLifetime)
+
+	err := checkAnnouncedRing(ringInfo)
+	if err != nil {
+		return 0, nil, err
+	}
+	if replica {
+		err = node.directory.announce(ringInfo, lifetime)
+		if err != nil {
+			return 0, []*vpp2papi.NodeInfo{node.Status.Info}, err
+		}
+		return 1, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+	}
+
+	path, err := node.lookupOwner(vpp2pdat.RingDirectoryKey())
+	if err != nil {
+		return 0, path, err
+	}
+	owner := path[len(path)-1]
+	if !bytes.Equal(owner.NodeID, node.Status.Info.NodeID) {
+		nbCopy, err := node.remoteAnnounceRing(owner, ringInfo, false)
+		return nbCopy, path, err
+	}
+
+	err = node.directory.announce(ringInfo, lifetime)
+	if err != nil {
+		return 0, path, err
+	}
+	nbCopy := 1
+	for _, replica := range node.replicas() {
+		_, err = node.remoteAnnounceRing(replica, ringInfo, true)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to announce ring on replica", err)
+			continue
+		}
+		nbCopy++
+	}
+
+	return nbCopy, path, nil
+}
+
+// ListRings searches the ring directory for rings matching appID, if not
+// empty, and whose title starts with titlePrefix. If replica is true, only
+// this node directory is searched. Otherwise the request is sent to the node
+// holding the directory, which falls back on its successors if needed.
+func (node *Node) ListRings(appID []byte, titlePrefix string, replica bool) ([]*vpp2papi.RingInfo, []*vpp2papi.NodeInfo, error) {
+	if replica {
+		return node.directory.list(appID, titlePrefix), []*vpp2papi.NodeInfo{node.Status.Info}, nil
+	}
+
+	path, err := node.lookupOwner(vpp2pdat.RingDirectoryKey())
+	if err != nil {
+		return nil, path, err
+	}
+	owner := path[len(path)-1]
+	if !bytes.Equal(owner.NodeID, node.Status.Info.NodeID) {
+		rings, err := node.remoteListRings(owner, appID, titlePrefix, false)
+		return rings, path, err
+	}
+
+	rings := node.directory.list(appID, titlePrefix)
+	if len(rings) > 0 {
+		return rings, path, nil
+	}
+	// the directory might have been filled before we joined,
+	// so the copies could be on successors only
+	for _, replica := range node.replicas() {
+		rings, err = node.remoteListRings(replica, appID, titlePrefix, true)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to list rings on replica", err)
+			continue
+		}
+		if len(rings) > 0 {
+			return rings, append(path, replica), nil
+		}
+	}
+
+	return rings, path, nil
+}
+
+func (node *Node) remoteAnnounceRing(target *vpp2papi.NodeInfo, ringInfo *vpp2papi.RingInfo, replica bool) (int, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return 0, err
+	}
+
+	request := vpp2papi.NewAnnounceRingRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.Ring = ringInfo
+	request.Replica = replica
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.AnnounceRingRequestSigBytes(request) })
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := targetAPI.AnnounceRing(request)
+	if err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, fmt.Errorf("no response to remote ring announce")
+	}
+
+	return int(response.NbCopy), nil
+}
+
+func (node *Node) remoteListRings(target *vpp2papi.NodeInfo, appID []byte, titlePrefix string, replica bool) ([]*vpp2papi.RingInfo, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return nil, err
+	}
+
+	request := vpp2papi.NewListRingsRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.AppID = appID
+	request.TitlePrefix = titlePrefix
+	request.Replica = replica
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.ListRingsRequestSigBytes(request) })
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := targetAPI.ListRings(request)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, fmt.Errorf("no response to remote rings list")
+	}
+
+	return response.Rings, nil
+}
@@ -0,0 +1,112 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+	"time"
+)
+
+func TestAnnounceListRings(t *testing.T) {
+	const nbNodes = 8
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	signingHost, err := NewHost(nodes[0].env, testTitle, testURL+"/directory", true)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	appID := vpsum.Checksum256([]byte("directory app"))
+	var announced []*Ring
+	for _, title := range []string{"Directory A", "Directory B"} {
+		ring, err := NewRing(signingHost.env, signingHost, title, testDescription, appID, vpp2pdat.DefaultRingConfig(), nil, nil)
+		if err != nil {
+			t.Fatal("unable to create ring", err)
+		}
+		announced = append(announced, ring)
+	}
+	nbCopy := int(nodes[0].ringPtr.Info.Config.NbCopy)
+
+	for i, ring := range announced {
+		n, _, err := nodes[i].AnnounceRing(&ring.Info, false)
+		if err != nil {
+			t.Fatal("unable to announce ring", err)
+		}
+		if n != nbCopy {
+			t.Errorf("bad number of copies %d!=%d", n, nbCopy)
+		}
+	}
+	_, _, err = nodes[0].AnnounceRing(&(nodes[0].ringPtr.Info), false)
+	if err == nil {
+		t.Error("unsigned ring announced")
+	}
+
+	for _, node := range nodes {
+		rings, _, err := node.ListRings(appID, "", false)
+		if err != nil || len(rings) != len(announced) {
+			t.Error("unable to list rings by app ID", err)
+		}
+	}
+	rings, _, err := nodes[nbNodes-1].ListRings(nil, "Directory B", false)
+	if err != nil || len(rings) != 1 || rings[0].RingTitle != "Directory B" {
+		t.Error("unable to list rings by title prefix", err)
+	}
+	rings, _, err = nodes[nbNodes/2].ListRings(testID, "", false)
+	if err != nil || len(rings) != 0 {
+		t.Error("listed rings from another app", err)
+	}
+
+	// owner losing its directory should not prevent from listing rings
+	path, err := nodes[0].lookupOwner(vpp2pdat.RingDirectoryKey())
+	if err != nil {
+		t.Fatal("unable to find directory owner", err)
+	}
+	owner := nodes[0].env.nodeCatalog.GetNode(path[len(path)-1].NodeID)
+	if owner == nil {
+		t.Fatal("directory owner not found locally")
+	}
+	owner.directory = newRingDirectory(SystemClock())
+	rings, _, err = nodes[1].ListRings(appID, "", false)
+	if err != nil || len(rings) != len(announced) {
+		t.Error("unable to list rings from replicas", err)
+	}
+
+	// announces expire unless refreshed
+	var expired []*vpp2papi.RingInfo
+	for _, node := range nodes {
+		node.directory.announce(&(announced[0].Info), -time.Second)
+		expired = append(expired, node.directory.list(appID, "Directory A")...)
+	}
+	if len(expired) != 0 {
+		t.Error("expired ring still listed")
+	}
+}
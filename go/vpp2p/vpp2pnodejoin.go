@@ -0,0 +1,136 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+)
+
+// JoinHost makes the node enter an existing ring, using the given
+// host as a bootstrap. The host must have at least one node on the ring.
+func (node *Node) JoinHost(hostInfo *vpp2papi.HostInfo) error {
+	_, err := vpp2pdat.CheckHostInfo(hostInfo)
+	if err != nil {
+		return err
+	}
+
+	return node.Join(hostInfo.HostURL)
+}
+
+// Join makes the node enter an existing ring, using the host at the
+// given URL as a bootstrap, for instance vpp2pdat.Host0URL for ring0.
+// It looks up the node ID to find its position on the ring, fetches
+// the successors and the predecessor, and computes an initial D. Only
+// then is the node started, that is, registered and made available.
+func (node *Node) Join(hostURL string) error {
+	var bootstrapAPI vpp2papi.VpP2pApi
+	var status *vpp2papi.HostStatus
+	var bootstrap *vpp2papi.NodeInfo
+	var err error
+
+	if node.Up() {
+		return fmt.Errorf("node is already started")
+	}
+
+	bootstrapAPI, err = node.env.nodeCatalog.ConnectToHost(hostURL)
+	if err != nil {
+		return err
+	}
+	status, err = bootstrapAPI.Status()
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		return fmt.Errorf("no status returned by bootstrap host")
+	}
+	for _, v := range status.LocalNodeStatus {
+		if v != nil && v.Info != nil && bytes.Equal(v.Info.RingID, node.Status.Info.RingID) && !bytes.Equal(v.Info.NodeID, node.Status.Info.NodeID) {
+			bootstrap = v.Info
+			break
+		}
+	}
+	if bootstrap == nil {
+		return fmt.Errorf("no node on ring %s for bootstrap host %s", vpp2pdat.RingIDToShortString(node.Status.Info.RingID), hostURL)
+	}
+
+	// the node which currently holds our ID is going to be our successor
+	nodeID := node.Status.Info.NodeID
+	request := vpp2papi.NewLookupRequest()
+	request.Context = node.contextInfo(bootstrap.NodeID)
+	request.Key = nodeID
+	request.KeyShift = node.GetKeyShift(nodeID)
+	request.ImaginaryNode = node.imaginaryNodeFrom(bootstrap.NodeID, nodeID)
+	request.Sig, err = node.authenticate(bootstrapAPI, request.Context, func() []byte { return vpp2pdat.LookupRequestSigBytes(request) })
+	if err != nil {
+		return err
+	}
+	response, err := bootstrapAPI.Lookup(request)
+	if err != nil {
+		return err
+	}
+	if response == nil || !response.Found || len(response.NodesPath) == 0 {
+		return fmt.Errorf("unable to find position of node %s on ring", vpp2pdat.NodeIDToShortString(nodeID))
+	}
+	successor := response.NodesPath[len(response.NodesPath)-1]
+	if bytes.Equal(successor.NodeID, nodeID) {
+		return fmt.Errorf("node %s already exists on ring", vpp2pdat.NodeIDToShortString(nodeID))
+	}
+	err = node.checkPeer(successor)
+	if err != nil {
+		return err
+	}
+
+	predecessor, err := node.remoteGetPredecessor(successor)
+	if err != nil {
+		return err
+	}
+	if predecessor == nil {
+		return fmt.Errorf("no predecessor returned by successor")
+	}
+	err = node.checkPeer(predecessor)
+	if err != nil {
+		return err
+	}
+	successorSuccessors, err := node.remoteGetSuccessors(successor)
+	if err != nil {
+		return err
+	}
+
+	node.setSuccessors(node.buildSuccessors(successor, successorSuccessors))
+	node.setPredecessor(predecessor)
+	node.peerSeen(successor.NodeID)
+	node.peerSeen(predecessor.NodeID)
+	node.stabilizeD()
+
+	node.Start()
+
+	// tell our successor about us right away, predecessor
+	// will learn it on its next stabilization round
+	err = node.remoteSync(successor)
+	if err != nil {
+		vplog.LoggerDebug(node.env.Logger(), "unable to sync with successor after join", err)
+	}
+
+	return nil
+}
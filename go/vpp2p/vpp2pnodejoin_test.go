@@ -0,0 +1,94 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	const nbNodes = 16
+	const nbRounds = 5
+	var nodes []*Node
+	var host *Host
+	var node *Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, v := range nodes {
+		defer v.Stop()
+		v.Start()
+	}
+
+	host, err = NewHost(nodes[0].env, testTitle, testURL+"/join", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	node, err = NewNode(host.env, host, nodes[0].ringPtr, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	defer node.Stop()
+
+	err = node.Join(testURL + "/nobody")
+	if err == nil {
+		t.Error("joined through a host which does not exist")
+	}
+	err = node.JoinHost(&(nodes[0].hostPtr.Info))
+	if err != nil {
+		t.Fatal("unable to join", err)
+	}
+	if !node.Up() {
+		t.Error("node not started after join")
+	}
+
+	var expectedPredecessor, expectedSuccessor *Node
+	for i, v := range nodes {
+		if node.ringPtr.walker.GtLe(node.Status.Info.NodeID, v.Status.Info.NodeID, nodes[(i+1)%nbNodes].Status.Info.NodeID) {
+			expectedPredecessor = v
+			expectedSuccessor = nodes[(i+1)%nbNodes]
+		}
+	}
+	if len(node.GetSuccessors()) == 0 || bytes.Compare(node.GetSuccessors()[0].NodeID, expectedSuccessor.Status.Info.NodeID) != 0 {
+		t.Error("bad successor after join")
+	}
+	if bytes.Compare(node.GetPredecessor().NodeID, expectedPredecessor.Status.Info.NodeID) != 0 {
+		t.Error("bad predecessor after join")
+	}
+	if node.GetD() == nil {
+		t.Error("no D after join")
+	}
+	if bytes.Compare(expectedSuccessor.GetPredecessor().NodeID, node.Status.Info.NodeID) != 0 {
+		t.Error("successor not aware of joined node")
+	}
+
+	for i := 0; i < nbRounds; i++ {
+		for _, v := range nodes {
+			v.Stabilize()
+		}
+	}
+	if bytes.Compare(expectedPredecessor.GetSuccessors()[0].NodeID, node.Status.Info.NodeID) != 0 {
+		t.Error("predecessor not aware of joined node")
+	}
+}
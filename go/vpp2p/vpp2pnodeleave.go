@@ -0,0 +1,160 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+)
+
+// Leave gracefully removes the node from the ring. The keys owned by
+// the node are handed over to its successors, then its predecessor and
+// successor are told to splice the ring, and finally the node is stopped.
+// The node is stopped even if its neighbours could not be contacted,
+// in which case they'll find out by themselves, on stabilization.
+func (node *Node) Leave() error {
+	var ret error
+
+	if !node.Up() {
+		return fmt.Errorf("node is not up")
+	}
+
+	nodeID := node.Status.Info.NodeID
+	successors := node.GetSuccessors()
+	predecessor := node.GetPredecessor()
+
+	node.handOver(successors)
+
+	notified := make(map[[vpp2pdat.NodeIDBufNbBytes]byte]bool)
+	neighbours := make([]*vpp2papi.NodeInfo, 0, 2)
+	neighbours = append(neighbours, predecessor)
+	if len(successors) > 0 {
+		neighbours = append(neighbours, successors[0])
+	}
+	for _, v := range neighbours {
+		nodeIDBuf := vpp2pdat.NodeIDToBuf(v.NodeID)
+		if bytes.Equal(v.NodeID, nodeID) || notified[nodeIDBuf] {
+			continue
+		}
+		notified[nodeIDBuf] = true
+		err := node.remoteLeave(v, successors, predecessor)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to tell neighbour about leave", err)
+			if ret == nil {
+				ret = err
+			}
+		}
+	}
+
+	node.Stop()
+
+	return ret
+}
+
+// handOver copies the keys owned by the node to its successors,
+// which are going to hold them once the node has left.
+func (node *Node) handOver(successors []*vpp2papi.NodeInfo) {
+	keys, values := node.store.list()
+	for i, key := range keys {
+		if !node.isKeyOnNode(key) {
+			// a replica of some other node's key
+			continue
+		}
+		for _, successor := range successors {
+			_, err := node.remotePut(successor, key, values[i], true)
+			if err != nil {
+				vplog.LoggerDebug(node.env.Logger(), "unable to hand over key", err)
+			}
+		}
+	}
+}
+
+// peerLeft is called when a peer tells it is leaving the ring, along
+// with its successors and predecessor, so that the ring can be spliced.
+func (node *Node) peerLeft(leaving *vpp2papi.NodeInfo, leavingSuccessors []*vpp2papi.NodeInfo, leavingPredecessor *vpp2papi.NodeInfo) {
+	nodeID := node.Status.Info.NodeID
+
+	successors := node.GetSuccessors()
+	for i, v := range successors {
+		if !bytes.Equal(v.NodeID, leaving.NodeID) {
+			continue
+		}
+		candidates := make([]*vpp2papi.NodeInfo, 0, len(successors)+len(leavingSuccessors))
+		candidates = append(candidates, successors[:i]...)
+		for _, w := range leavingSuccessors {
+			if w != nil && !bytes.Equal(w.NodeID, leaving.NodeID) && node.checkPeer(w) == nil {
+				candidates = append(candidates, w)
+			}
+		}
+		if len(candidates) == 0 || bytes.Equal(candidates[0].NodeID, nodeID) {
+			node.resetSuccessors()
+		} else {
+			node.setSuccessors(node.buildSuccessors(candidates[0], candidates[1:]))
+		}
+		break
+	}
+
+	if bytes.Equal(node.GetPredecessor().NodeID, leaving.NodeID) {
+		if leavingPredecessor == nil || bytes.Equal(leavingPredecessor.NodeID, leaving.NodeID) || node.checkPeer(leavingPredecessor) != nil {
+			node.resetPredecessor()
+		} else {
+			node.setPredecessor(leavingPredecessor)
+		}
+	}
+
+	if d := node.GetD(); d != nil && bytes.Equal(d.NodeID, leaving.NodeID) {
+		node.resetD()
+	}
+
+	defer node.peersAccess.Unlock()
+	node.peersAccess.Lock()
+
+	delete(node.lastSeen, vpp2pdat.NodeIDToBuf(leaving.NodeID))
+}
+
+func (node *Node) remoteLeave(target *vpp2papi.NodeInfo, successors []*vpp2papi.NodeInfo, predecessor *vpp2papi.NodeInfo) error {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return err
+	}
+
+	request := vpp2papi.NewLeaveRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.SuccessorNodes = successors
+	request.PredecessorNode = predecessor
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.LeaveRequestSigBytes(request) })
+	if err != nil {
+		return err
+	}
+
+	response, err := targetAPI.Leave(request)
+	if err != nil {
+		return err
+	}
+	if response == nil || !response.Done {
+		return fmt.Errorf("leave not acknowledged by remote node")
+	}
+
+	return nil
+}
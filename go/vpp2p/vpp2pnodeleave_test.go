@@ -0,0 +1,104 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+)
+
+func TestLeave(t *testing.T) {
+	const nbNodes = 8
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	key := vpsum.Checksum256([]byte("leave"))
+	value := []byte("this is a value")
+	_, path, err := nodes[0].Put(key, value, false)
+	if err != nil {
+		t.Fatal("unable to put value", err)
+	}
+	owner := nodes[0].env.nodeCatalog.GetNode(path[len(path)-1].NodeID)
+	if owner == nil {
+		t.Fatal("unable to find owner")
+	}
+	predecessor := nodes[0].env.nodeCatalog.GetNode(owner.GetPredecessor().NodeID)
+	successor := nodes[0].env.nodeCatalog.GetNode(owner.GetSuccessors()[0].NodeID)
+	if predecessor == nil || successor == nil {
+		t.Fatal("unable to find owner neighbours")
+	}
+	for _, node := range nodes {
+		if node != owner {
+			// make sure the key can only come from the owner
+			node.store.delete(key)
+		}
+	}
+
+	err = owner.Leave()
+	if err != nil {
+		t.Error("unable to leave", err)
+	}
+	if owner.Up() {
+		t.Error("node still up after leave")
+	}
+	err = owner.Leave()
+	if err == nil {
+		t.Error("node left twice")
+	}
+
+	if bytes.Compare(predecessor.GetSuccessors()[0].NodeID, successor.Status.Info.NodeID) != 0 {
+		t.Error("predecessor not linked to successor")
+	}
+	for _, v := range predecessor.GetSuccessors() {
+		if bytes.Compare(v.NodeID, owner.Status.Info.NodeID) == 0 {
+			t.Error("left node still in predecessor successors")
+		}
+	}
+	if bytes.Compare(successor.GetPredecessor().NodeID, predecessor.Status.Info.NodeID) != 0 {
+		t.Error("successor not linked to predecessor")
+	}
+	if got, ok := successor.store.get(key); !ok || bytes.Compare(got, value) != 0 {
+		t.Error("key not handed over to successor")
+	}
+	// other nodes may still refer to the node which left, they find
+	// out about it on stabilization, walking the ring backwards, twice
+	// since the left node might be close to the end of the ring
+	for round := 0; round < 2; round++ {
+		for i := nbNodes - 1; i >= 0; i-- {
+			if nodes[i] != owner {
+				nodes[i].Stabilize()
+			}
+		}
+	}
+	found, got, _, err := nodes[0].Get(key, false)
+	if err != nil || !found || bytes.Compare(got, value) != 0 {
+		t.Error("unable to get value after leave", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sync"
+)
+
+// lookupRoute is an entry being looked up, and the next hop it is sent to.
+type lookupRoute struct {
+	key   string
+	entry *vpp2papi.LookupEntry
+	hop   *lookupHop
+}
+
+// LookupMany looks up several keys at once. Each key is looked up
+// as with Lookup, but keys which are forwarded to the same node are
+// sent together, in a single call, and calls to different nodes are
+// made in parallel. This saves many round trips when loading a lot of
+// keys. Results are indexed by vpp2pdat.KeyToString. Keys for which
+// a node on the path could not be reached are reported as not found.
+func (node *Node) LookupMany(keys [][]byte) (map[string]*vpp2papi.LookupResult, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys to look up")
+	}
+	entries := make([]*vpp2papi.LookupEntry, len(keys))
+	for i, key := range keys {
+		_, err := vpp2pdat.CheckKey(key)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = &vpp2papi.LookupEntry{Key: key, KeyShift: node.GetKeyShift(key), ImaginaryNode: node.GetImaginaryNode(key)}
+	}
+
+	return node.lookupMany(entries), nil
+}
+
+// lookupMany looks up entries, forwarding them, grouped by next hop,
+// to other nodes. If a hop fails, its entries are sent to their
+// fallback hop, if any, as Lookup does when D does not answer.
+func (node *Node) lookupMany(entries []*vpp2papi.LookupEntry) map[string]*vpp2papi.LookupResult {
+	self := node.Status.Info
+	ret := make(map[string]*vpp2papi.LookupResult)
+	routes := make([]*lookupRoute, 0)
+	seen := make(map[string]bool)
+
+	for _, entry := range entries {
+		key := vpp2pdat.KeyToString(entry.Key)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		found, path, hop := node.routeLookup(entry.Key, entry.KeyShift, entry.ImaginaryNode)
+		if hop == nil {
+			ret[key] = &vpp2papi.LookupResult{Found: found, NodesPath: path}
+		} else {
+			routes = append(routes, &lookupRoute{key: key, entry: entry, hop: hop})
+		}
+	}
+
+	for len(routes) > 0 {
+		var wg sync.WaitGroup
+		var access sync.Mutex
+		failed := make([]*lookupRoute, 0)
+
+		groups := make(map[[vpp2pdat.NodeIDBufNbBytes]byte][]*lookupRoute)
+		for _, route := range routes {
+			nextBuf := vpp2pdat.NodeIDToBuf(route.hop.next.NodeID)
+			groups[nextBuf] = append(groups[nextBuf], route)
+		}
+		for _, group := range groups {
+			wg.Add(1)
+			go func(group []*lookupRoute) {
+				defer wg.Done()
+
+				groupEntries := make([]*vpp2papi.LookupEntry, len(group))
+				for i, route := range group {
+					groupEntries[i] = &vpp2papi.LookupEntry{Key: route.entry.Key, KeyShift: route.hop.keyShift, ImaginaryNode: route.hop.imaginaryNode}
+				}
+				results, err := node.remoteLookupMany(group[0].hop.next, groupEntries)
+				if err != nil {
+					vplog.LoggerDebug(node.env.Logger(), "unable to forward lookups", err)
+				}
+
+				defer access.Unlock()
+				access.Lock()
+
+				for _, route := range group {
+					result := results[route.key]
+					if err != nil || result == nil {
+						failed = append(failed, route)
+						continue
+					}
+					ret[route.key] = &vpp2papi.LookupResult{Found: result.Found, NodesPath: append([]*vpp2papi.NodeInfo{self}, result.NodesPath...)}
+				}
+			}(group)
+		}
+		wg.Wait()
+
+		routes = make([]*lookupRoute, 0)
+		for _, route := range failed {
+			if route.hop.fallback != nil {
+				route.hop = route.hop.fallback
+				routes = append(routes, route)
+				continue
+			}
+			ret[route.key] = &vpp2papi.LookupResult{Found: false, NodesPath: []*vpp2papi.NodeInfo{self}}
+		}
+	}
+
+	return ret
+}
+
+// remoteLookupMany forwards lookups to another node, in as many calls
+// as needed to keep each call within MaxLookupEntries.
+func (node *Node) remoteLookupMany(target *vpp2papi.NodeInfo, entries []*vpp2papi.LookupEntry) (map[string]*vpp2papi.LookupResult, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]*vpp2papi.LookupResult)
+	for begin := 0; begin < len(entries); begin += vpp2pdat.MaxLookupEntries {
+		end := begin + vpp2pdat.MaxLookupEntries
+		if end > len(entries) {
+			end = len(entries)
+		}
+
+		request := vpp2papi.NewLookupManyRequest()
+		request.Context = node.contextInfo(target.NodeID)
+		request.Entries = entries[begin:end]
+
+		request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.LookupManyRequestSigBytes(request) })
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := targetAPI.LookupMany(request)
+		if err != nil {
+			return nil, err
+		}
+		if response == nil || response.Results == nil {
+			return nil, fmt.Errorf("no results returned by remote lookup")
+		}
+		for k, v := range response.Results {
+			if v != nil && v.NodesPath != nil {
+				ret[k] = v
+			}
+		}
+	}
+
+	return ret, nil
+}
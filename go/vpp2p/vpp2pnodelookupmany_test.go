@@ -0,0 +1,119 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"fmt"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"github.com/ufoot/vapor/go/vpsum"
+	"sync"
+	"testing"
+)
+
+// testCountTransport counts lookup calls between hosts.
+type testCountTransport struct {
+	access       sync.Mutex
+	nbLookup     int
+	nbLookupMany int
+}
+
+type testCountLink struct {
+	*Host
+	transport *testCountTransport
+}
+
+func (t *testCountTransport) Connect(host *Host) (vpp2papi.VpP2pApi, error) {
+	return &testCountLink{Host: host, transport: t}, nil
+}
+
+func (l *testCountLink) Lookup(request *vpp2papi.LookupRequest) (*vpp2papi.LookupResponse, error) {
+	l.transport.access.Lock()
+	l.transport.nbLookup++
+	l.transport.access.Unlock()
+
+	return l.Host.Lookup(request)
+}
+
+func (l *testCountLink) LookupMany(request *vpp2papi.LookupManyRequest) (*vpp2papi.LookupManyResponse, error) {
+	l.transport.access.Lock()
+	l.transport.nbLookupMany++
+	l.transport.access.Unlock()
+
+	return l.Host.LookupMany(request)
+}
+
+func TestLookupMany(t *testing.T) {
+	const nbNodes = 32
+	const nbKeys = 200
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+	transport := &testCountTransport{}
+	nodes[0].env.SetTransport(transport)
+
+	keys := make([][]byte, nbKeys)
+	for i := range keys {
+		keys[i] = vpsum.Checksum256([]byte(fmt.Sprintf("level item %d", i)))
+	}
+	results, err := nodes[0].LookupMany(keys)
+	if err != nil {
+		t.Fatal("unable to lookup keys", err)
+	}
+	if len(results) != nbKeys {
+		t.Errorf("bad number of results %d!=%d", len(results), nbKeys)
+	}
+	for _, key := range keys {
+		result := results[vpp2pdat.KeyToString(key)]
+		if result == nil || !result.Found {
+			t.Errorf("key %s not found", vpp2pdat.KeyToString(key))
+			continue
+		}
+		owner := nodes[0].env.nodeCatalog.GetNode(result.NodesPath[len(result.NodesPath)-1].NodeID)
+		if owner == nil || !owner.isKeyOnNode(key) {
+			t.Errorf("key %s not on returned owner", vpp2pdat.KeyToString(key))
+		}
+	}
+	nbLookupMany := transport.nbLookupMany
+
+	for _, key := range keys {
+		_, err = nodes[0].lookupOwner(key)
+		if err != nil {
+			t.Error("unable to lookup key", err)
+		}
+	}
+	t.Logf("%d keys looked up with %d calls, instead of %d", nbKeys, nbLookupMany, transport.nbLookup)
+	if nbLookupMany >= transport.nbLookup {
+		t.Errorf("batch lookup does not save calls, %d>=%d", nbLookupMany, transport.nbLookup)
+	}
+
+	_, err = nodes[0].LookupMany(nil)
+	if err == nil {
+		t.Error("no error when looking up no keys")
+	}
+}
@@ -0,0 +1,376 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCapacity is the capacity of a typical host.
+	DefaultCapacity = 1.0
+	// NodesPerCapacity is the number of nodes a host runs on a ring
+	// for each capacity unit, before any rebalancing.
+	NodesPerCapacity = 4
+	// MaxManagedNodes is the maximum number of nodes a manager runs
+	// on a given ring.
+	MaxManagedNodes = 256
+	// ShareTolerance is how far, relatively, the share of a host can
+	// be from its target before nodes are added or removed.
+	ShareTolerance = 0.25
+	// RebalanceSyncSteps is the number of ring SyncDelay periods between
+	// two automatic calls to Rebalance, so that the ring has time to
+	// stabilize after a node has been added or removed.
+	RebalanceSyncSteps = 10
+)
+
+// NodeFactory creates a node for a host on a ring. Node managers use
+// it when they need more nodes, the default is to call NewNode.
+type NodeFactory func(host *Host, ring *Ring) (*Node, error)
+
+// NodeManager runs the nodes of a host on a ring. The number of nodes
+// depends on the declared capacity of the host, so that a dedicated
+// server handles a bigger part of the key space than a laptop. Since
+// node IDs are random, the share of the key space actually held can
+// differ from what the capacity says, so Rebalance adds or removes
+// nodes, one at a time, to keep it close to the target. Once started,
+// the manager calls it periodically, see SetRebalanceDelay.
+type NodeManager struct {
+	access         sync.Mutex
+	hostPtr        *Host
+	ringPtr        *Ring
+	factory        NodeFactory
+	nodes          []*Node
+	rebalanceDelay time.Duration
+	rebalanceStop  chan bool
+}
+
+func defaultNodeFactory(host *Host, ring *Ring) (*Node, error) {
+	return NewNode(host.env, host, ring, nil)
+}
+
+// NewNodeManager creates a node manager for host on ring. No node is
+// created until Start is called.
+func NewNodeManager(host *Host, ring *Ring) (*NodeManager, error) {
+	if host.env != ring.env {
+		return nil, fmt.Errorf("host and ring do not live within the same env")
+	}
+
+	return &NodeManager{hostPtr: host, ringPtr: ring, factory: defaultNodeFactory, nodes: make([]*Node, 0), rebalanceDelay: RebalanceSyncSteps * ring.syncDelay}, nil
+}
+
+// SetRebalanceDelay sets the delay between two automatic calls to
+// Rebalance, the default is RebalanceSyncSteps times the ring SyncDelay.
+// Passing 0 disables them, Rebalance must then be called explicitly.
+// Must be called before Start.
+// It's thread-safe.
+func (m *NodeManager) SetRebalanceDelay(delay time.Duration) {
+	defer m.access.Unlock()
+	m.access.Lock()
+
+	m.rebalanceDelay = delay
+}
+
+// SetNodeFactory sets the function used to create nodes, passing nil
+// restores the default. Simulations use this to control node IDs.
+// It's thread-safe.
+func (m *NodeManager) SetNodeFactory(factory NodeFactory) {
+	defer m.access.Unlock()
+	m.access.Lock()
+
+	if factory == nil {
+		factory = defaultNodeFactory
+	}
+	m.factory = factory
+}
+
+// Nodes returns the nodes currently run by the manager.
+// It's thread-safe.
+func (m *NodeManager) Nodes() []*Node {
+	defer m.access.Unlock()
+	m.access.Lock()
+
+	ret := make([]*Node, len(m.nodes))
+	copy(ret, m.nodes)
+
+	return ret
+}
+
+// BaseNbNodes returns the number of nodes the host should run on the
+// ring according to its capacity, before any rebalancing.
+func (m *NodeManager) BaseNbNodes() int {
+	ret := int(math.Floor(m.hostPtr.Capacity()*NodesPerCapacity + 0.5))
+	if ret < 1 {
+		ret = 1
+	}
+	if ret > MaxManagedNodes {
+		ret = MaxManagedNodes
+	}
+
+	return ret
+}
+
+// nbNodesRange returns the minimum and maximum number of nodes
+// Rebalance can lead to, this is half and twice the base number
+// of nodes, so that a bad estimation of the ring size can't make
+// the host go too far from what its capacity says.
+func (m *NodeManager) nbNodesRange() (int, int) {
+	base := m.BaseNbNodes()
+	minNbNodes := (base + 1) / 2
+	maxNbNodes := base * 2
+	if maxNbNodes > MaxManagedNodes {
+		maxNbNodes = MaxManagedNodes
+	}
+
+	return minNbNodes, maxNbNodes
+}
+
+// wrapRange returns the range from x to y, going forward on the ring.
+func (m *NodeManager) wrapRange(x, y []byte) float64 {
+	ret := m.ringPtr.walker.RingRange(x, y)
+	if ret <= 0 {
+		// range wraps around zero
+		ret += 1.0
+	}
+
+	return ret
+}
+
+// nodeShare returns the part of the key space held by a node,
+// that is, the range between its predecessor and itself.
+func (m *NodeManager) nodeShare(node *Node) float64 {
+	return m.wrapRange(node.GetPredecessor().NodeID, node.Status.Info.NodeID)
+}
+
+// Share returns the part of the key space, between 0 and 1, held
+// by the nodes of the manager.
+// It's thread-safe.
+func (m *NodeManager) Share() float64 {
+	defer m.access.Unlock()
+	m.access.Lock()
+
+	return m.share()
+}
+
+func (m *NodeManager) share() float64 {
+	ret := 0.0
+	for _, node := range m.nodes {
+		ret += m.nodeShare(node)
+	}
+	if ret > 1.0 {
+		ret = 1.0
+	}
+
+	return ret
+}
+
+// estimateNbNodes estimates how many nodes there are on the ring,
+// using the distance between each node and its last successor. This
+// does not depend on the ranges held by the nodes themselves, which
+// are precisely what we're trying to balance.
+func (m *NodeManager) estimateNbNodes() float64 {
+	nbSuccessors := 0
+	sumRanges := 0.0
+	for _, node := range m.nodes {
+		successors := node.GetSuccessors()
+		if len(successors) == 0 {
+			continue
+		}
+		last := successors[len(successors)-1]
+		if last.NodeID == nil || m.ringPtr.walker.Cmp(last.NodeID, node.Status.Info.NodeID) == 0 {
+			continue
+		}
+		nbSuccessors += len(successors)
+		sumRanges += m.wrapRange(node.Status.Info.NodeID, last.NodeID)
+	}
+	if nbSuccessors == 0 {
+		return float64(len(m.nodes))
+	}
+
+	return math.Max(float64(nbSuccessors)/sumRanges, float64(len(m.nodes)))
+}
+
+// TargetShare returns the part of the key space the host should hold.
+// Other hosts are assumed to run NodesPerCapacity nodes per capacity
+// unit, so the target is the ratio between the base number of nodes
+// of the host and the number of nodes on the ring, had the host run
+// exactly that base number of nodes.
+// It's thread-safe.
+func (m *NodeManager) TargetShare() float64 {
+	defer m.access.Unlock()
+	m.access.Lock()
+
+	return m.targetShare()
+}
+
+func (m *NodeManager) targetShare() float64 {
+	if len(m.nodes) == 0 {
+		return 0.0
+	}
+	base := float64(m.BaseNbNodes())
+	others := m.estimateNbNodes() - float64(len(m.nodes))
+
+	return base / (base + others)
+}
+
+// addNode creates a new node, and starts it, making it join the ring
+// through bootstrapURL, or through the host itself, if it already
+// runs nodes on the ring.
+func (m *NodeManager) addNode(bootstrapURL string) error {
+	node, err := m.factory(m.hostPtr, m.ringPtr)
+	if err != nil {
+		return err
+	}
+	if len(m.nodes) > 0 {
+		bootstrapURL = m.hostPtr.Info.HostURL
+	}
+	if bootstrapURL == "" {
+		node.Start()
+	} else {
+		err = node.Join(bootstrapURL)
+		if err != nil {
+			return err
+		}
+	}
+	m.nodes = append(m.nodes, node)
+
+	return nil
+}
+
+// Start creates and starts BaseNbNodes nodes. If bootstrapURL is empty,
+// the first node starts a new ring on its own, else it joins the ring
+// through the given host. Rebalance is then called periodically,
+// until Stop is called.
+// It's thread-safe.
+func (m *NodeManager) Start(bootstrapURL string) error {
+	defer m.access.Unlock()
+	m.access.Lock()
+
+	if len(m.nodes) > 0 {
+		return fmt.Errorf("node manager already started")
+	}
+	nbNodes := m.BaseNbNodes()
+	for i := 0; i < nbNodes; i++ {
+		err := m.addNode(bootstrapURL)
+		if err != nil {
+			return err
+		}
+	}
+	if m.rebalanceDelay > 0 && m.rebalanceStop == nil {
+		m.rebalanceStop = make(chan bool)
+		go m.rebalanceLoop(m.rebalanceDelay, m.rebalanceStop)
+	}
+
+	return nil
+}
+
+// rebalanceLoop calls Rebalance every delay, until stop is closed.
+func (m *NodeManager) rebalanceLoop(delay time.Duration, stop chan bool) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_, err := m.Rebalance()
+			if err != nil {
+				vplog.LoggerDebug(m.hostPtr.env.Logger(), "unable to rebalance", err)
+			}
+		}
+	}
+}
+
+// Rebalance compares the share of the key space held by the host with
+// its target, and adds a node if it's too low, or removes the node which
+// brings the share closest to the target if it's too high. It does at
+// most one change per call, so that the ring has a chance to stabilize
+// between two calls, and keeps the number of nodes between half and
+// twice BaseNbNodes. Returns the change in the number of nodes.
+// It's thread-safe.
+func (m *NodeManager) Rebalance() (int, error) {
+	defer m.access.Unlock()
+	m.access.Lock()
+
+	if len(m.nodes) == 0 {
+		return 0, fmt.Errorf("node manager not started")
+	}
+
+	share := m.share()
+	target := m.targetShare()
+	minNbNodes, maxNbNodes := m.nbNodesRange()
+	if share < target*(1.0-ShareTolerance) && len(m.nodes) < maxNbNodes {
+		err := m.addNode("")
+		if err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	if share > target*(1.0+ShareTolerance) && len(m.nodes) > minNbNodes {
+		best := -1
+		bestDiff := math.Abs(share - target)
+		for i, node := range m.nodes {
+			diff := math.Abs(share - m.nodeShare(node) - target)
+			if diff < bestDiff {
+				best = i
+				bestDiff = diff
+			}
+		}
+		if best < 0 {
+			// removing any node would make things worse
+			return 0, nil
+		}
+		node := m.nodes[best]
+		m.nodes = append(m.nodes[:best], m.nodes[best+1:]...)
+		err := node.Leave()
+		if err != nil {
+			vplog.LoggerDebug(m.hostPtr.env.Logger(), "unable to leave ring cleanly", err)
+		}
+		return -1, nil
+	}
+
+	return 0, nil
+}
+
+// Stop makes all the nodes of the manager leave the ring, and stops
+// calling Rebalance.
+// It's thread-safe.
+func (m *NodeManager) Stop() {
+	defer m.access.Unlock()
+	m.access.Lock()
+
+	if m.rebalanceStop != nil {
+		close(m.rebalanceStop)
+		m.rebalanceStop = nil
+	}
+
+	for _, node := range m.nodes {
+		err := node.Leave()
+		if err != nil {
+			vplog.LoggerDebug(m.hostPtr.env.Logger(), "unable to leave ring cleanly", err)
+		}
+	}
+	m.nodes = make([]*Node, 0)
+}
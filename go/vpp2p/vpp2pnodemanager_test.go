@@ -0,0 +1,214 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"math"
+	"testing"
+	"time"
+)
+
+func testStabilizeManagers(managers []*NodeManager, nbRounds int) {
+	for i := 0; i < nbRounds; i++ {
+		for _, m := range managers {
+			for _, node := range m.Nodes() {
+				node.Stabilize()
+			}
+		}
+	}
+}
+
+func TestNodeManager(t *testing.T) {
+	const nbRebalances = 30
+	var env = NewEnv()
+
+	server, err := NewHost(env, testTitle, testURL+"/manager/server", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	laptop, err := NewHost(env, testTitle, testURL+"/manager/laptop", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	err = server.SetCapacity(4)
+	if err != nil {
+		t.Fatal("unable to set capacity", err)
+	}
+	if laptop.SetCapacity(0) == nil {
+		t.Error("null capacity accepted")
+	}
+	// both hosts live in the same env and limit each other's calls,
+	// stabilizing in a tight loop would exceed the default rate
+	for _, host := range []*Host{server, laptop} {
+		err = host.Limiter().SetConfig(&LimiterConfig{Rate: 1e6, Burst: 1e6, MaxConcurrentCalls: DefaultMaxConcurrentCalls, MaxSources: DefaultMaxLimitedSources})
+		if err != nil {
+			t.Fatal("unable to set limits", err)
+		}
+	}
+	ring, err := NewRing(env, server, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+
+	serverManager, err := NewNodeManager(server, ring)
+	if err != nil {
+		t.Fatal("unable to create node manager", err)
+	}
+	defer serverManager.Stop()
+	laptopManager, err := NewNodeManager(laptop, ring)
+	if err != nil {
+		t.Fatal("unable to create node manager", err)
+	}
+	defer laptopManager.Stop()
+	managers := []*NodeManager{serverManager, laptopManager}
+
+	if serverManager.BaseNbNodes() != 4*NodesPerCapacity || laptopManager.BaseNbNodes() != NodesPerCapacity {
+		t.Errorf("bad base number of nodes %d/%d", serverManager.BaseNbNodes(), laptopManager.BaseNbNodes())
+	}
+	err = serverManager.Start("")
+	if err != nil {
+		t.Fatal("unable to start server nodes", err)
+	}
+	testStabilizeManagers(managers, 3)
+	err = laptopManager.Start(server.Info.HostURL)
+	if err != nil {
+		t.Fatal("unable to start laptop nodes", err)
+	}
+	testStabilizeManagers(managers, 5)
+
+	for i := 0; i < nbRebalances; i++ {
+		for _, m := range managers {
+			_, err = m.Rebalance()
+			if err != nil {
+				t.Error("unable to rebalance", err)
+			}
+		}
+		testStabilizeManagers(managers, 3)
+	}
+	testStabilizeManagers(managers, 5)
+
+	serverShare := serverManager.Share()
+	laptopShare := laptopManager.Share()
+	t.Logf("server has %d nodes, share=%f target=%f", len(serverManager.Nodes()), serverShare, serverManager.TargetShare())
+	t.Logf("laptop has %d nodes, share=%f target=%f", len(laptopManager.Nodes()), laptopShare, laptopManager.TargetShare())
+	if math.Abs(serverShare+laptopShare-1.0) > 1e-6 {
+		t.Errorf("shares do not cover the ring %f+%f", serverShare, laptopShare)
+	}
+	if serverShare <= laptopShare {
+		t.Errorf("server share %f not greater than laptop share %f", serverShare, laptopShare)
+	}
+	for _, m := range managers {
+		nbNodes := len(m.Nodes())
+		minNbNodes, maxNbNodes := m.nbNodesRange()
+		if nbNodes < minNbNodes || nbNodes > maxNbNodes {
+			t.Errorf("bad number of nodes %d, range is [%d,%d]", nbNodes, minNbNodes, maxNbNodes)
+		}
+		if nbNodes == minNbNodes || nbNodes == maxNbNodes {
+			// the manager can't do better, target is out of reach
+			continue
+		}
+		share := m.Share()
+		target := m.TargetShare()
+		if share < target*(1.0-ShareTolerance) || share > target*(1.0+ShareTolerance) {
+			t.Errorf("share %f too far from target %f", share, target)
+		}
+	}
+}
+
+func TestNodeManagerAutoRebalance(t *testing.T) {
+	const rebalanceDelay = 10 * time.Millisecond
+	var env = NewEnv()
+
+	server, err := NewHost(env, testTitle, testURL+"/autorebalance/server", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	laptop, err := NewHost(env, testTitle, testURL+"/autorebalance/laptop", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	for _, host := range []*Host{server, laptop} {
+		err = host.Limiter().SetConfig(&LimiterConfig{Rate: 1e6, Burst: 1e6, MaxConcurrentCalls: DefaultMaxConcurrentCalls, MaxSources: DefaultMaxLimitedSources})
+		if err != nil {
+			t.Fatal("unable to set limits", err)
+		}
+	}
+	err = server.SetCapacity(4)
+	if err != nil {
+		t.Fatal("unable to set capacity", err)
+	}
+	err = laptop.SetCapacity(0.25)
+	if err != nil {
+		t.Fatal("unable to set capacity", err)
+	}
+	ring, err := NewRing(env, server, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+
+	serverManager, err := NewNodeManager(server, ring)
+	if err != nil {
+		t.Fatal("unable to create node manager", err)
+	}
+	defer serverManager.Stop()
+	laptopManager, err := NewNodeManager(laptop, ring)
+	if err != nil {
+		t.Fatal("unable to create node manager", err)
+	}
+	defer laptopManager.Stop()
+	managers := []*NodeManager{serverManager, laptopManager}
+	serverManager.SetRebalanceDelay(0)
+	laptopManager.SetRebalanceDelay(rebalanceDelay)
+
+	err = serverManager.Start("")
+	if err != nil {
+		t.Fatal("unable to start server nodes", err)
+	}
+	err = laptopManager.Start(server.Info.HostURL)
+	if err != nil {
+		t.Fatal("unable to start laptop nodes", err)
+	}
+	testStabilizeManagers(managers, 5)
+
+	// the laptop gets a bigger capacity, its share drifts below
+	// target, and nodes should be added without calling Rebalance
+	nbNodes := len(laptopManager.Nodes())
+	err = laptop.SetCapacity(16)
+	if err != nil {
+		t.Fatal("unable to set capacity", err)
+	}
+	for i := 0; i < 100 && len(laptopManager.Nodes()) <= nbNodes; i++ {
+		testStabilizeManagers(managers, 1)
+		time.Sleep(rebalanceDelay)
+	}
+	if len(laptopManager.Nodes()) <= nbNodes {
+		t.Errorf("no node added on drift, %d nodes", len(laptopManager.Nodes()))
+	}
+	if len(serverManager.Nodes()) != serverManager.BaseNbNodes() {
+		t.Errorf("nodes changed on a manager with rebalancing disabled, %d nodes", len(serverManager.Nodes()))
+	}
+
+	laptopManager.Stop()
+	time.Sleep(5 * rebalanceDelay)
+	if len(laptopManager.Nodes()) != 0 {
+		t.Errorf("nodes added after stop, %d nodes", len(laptopManager.Nodes()))
+	}
+}
@@ -0,0 +1,287 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"time"
+)
+
+// RepairReplicas compares the entries this node holds, within the
+// range it owns, with the copies held by its NbCopy-1 successors, and
+// transfers only the entries which differ. Both sides build a Merkle
+// tree of the range, trees are compared level by level, so a replica
+// which is in sync costs a single call. Missing entries are copied both
+// ways, keeping the time they have left before they expire, and when
+// values differ, the value held by this node wins. Since deletions
+// leave no trace, a key deleted while a replica was unreachable can
+// come back, until it expires. This is called by SyncStep, every SyncDelay
+// seconds once the node is started. Returns the number of entries transferred.
+func (node *Node) RepairReplicas() (int, error) {
+	nodeID := node.Status.Info.NodeID
+	predecessor := node.GetPredecessor()
+	if bytes.Equal(predecessor.NodeID, nodeID) {
+		// range unknown, or alone on the ring, nothing to do
+		return 0, nil
+	}
+
+	nbTransferred := 0
+	var lastErr error
+	for _, replica := range node.replicas() {
+		n, err := node.repairReplica(replica, predecessor.NodeID, nodeID)
+		nbTransferred += n
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to repair replica", err)
+			lastErr = err
+		}
+	}
+
+	return nbTransferred, lastErr
+}
+
+// repairReplica makes a replica hold the same entries than this
+// node, within (rangeStart,rangeEnd].
+func (node *Node) repairReplica(replica *vpp2papi.NodeInfo, rangeStart, rangeEnd []byte) (int, error) {
+	tree := node.merkleTree(rangeStart, rangeEnd)
+
+	var pushKeys, wantedKeys [][]byte
+	prefixes := [][]byte{{}}
+	for len(prefixes) > 0 {
+		remoteNodes, _, err := node.remoteMerkle(replica, rangeStart, rangeEnd, prefixes, nil, nil)
+		if err != nil {
+			return 0, err
+		}
+		if len(remoteNodes) != len(prefixes) {
+			return 0, fmt.Errorf("bad number of Merkle nodes %d!=%d", len(remoteNodes), len(prefixes))
+		}
+
+		var next [][]byte
+		for i, prefix := range prefixes {
+			remote := remoteNodes[i]
+			if remote == nil || bytes.Equal(remote.Hash, tree.hash(prefix)) {
+				continue
+			}
+			if len(prefix) < vpp2pdat.MerkleDepth {
+				children := tree.children(prefix)
+				if len(remote.Children) != len(children) {
+					return 0, fmt.Errorf("bad number of Merkle children %d!=%d", len(remote.Children), len(children))
+				}
+				for j, v := range remote.Children {
+					if !bytes.Equal(v, children[j]) {
+						next = append(next, merkleChild(prefix, j))
+					}
+				}
+				continue
+			}
+			digests := tree.digests(prefix)
+			for _, v := range remote.Digests {
+				if v == nil {
+					continue
+				}
+				hash, ok := digests[string(v.Key)]
+				if !ok {
+					wantedKeys = append(wantedKeys, v.Key)
+					continue
+				}
+				if !bytes.Equal(hash, v.Hash) {
+					pushKeys = append(pushKeys, v.Key)
+				}
+				delete(digests, string(v.Key))
+			}
+			for k := range digests {
+				pushKeys = append(pushKeys, []byte(k))
+			}
+		}
+		prefixes = next
+	}
+
+	entries := node.dataEntries(pushKeys)
+	nbTransferred := 0
+	for len(entries) > 0 || len(wantedKeys) > 0 {
+		nbEntries := len(entries)
+		if nbEntries > vpp2pdat.MaxMerkleEntries {
+			nbEntries = vpp2pdat.MaxMerkleEntries
+		}
+		nbWanted := len(wantedKeys)
+		if nbWanted > vpp2pdat.MaxMerkleEntries {
+			nbWanted = vpp2pdat.MaxMerkleEntries
+		}
+
+		_, received, err := node.remoteMerkle(replica, rangeStart, rangeEnd, nil, entries[:nbEntries], wantedKeys[:nbWanted])
+		if err != nil {
+			return nbTransferred, err
+		}
+		nbTransferred += nbEntries
+		_, err = vpp2pdat.CheckDataEntries(received)
+		if err != nil {
+			return nbTransferred, err
+		}
+		nbTransferred += node.storeEntries(node.entriesInRange(received, rangeStart, rangeEnd))
+
+		entries = entries[nbEntries:]
+		wantedKeys = wantedKeys[nbWanted:]
+	}
+
+	return nbTransferred, nil
+}
+
+// checkRangeOwner checks that source owns (rangeStart,rangeEnd], as
+// claimed in a Merkle request. The range must end on the source node,
+// and none of the nodes this node knows about, that is, itself and its
+// predecessor, can lie within the range, else the source would not
+// own it all. This way a node can't push keys it does not own.
+func (node *Node) checkRangeOwner(source *vpp2papi.NodeInfo, rangeStart, rangeEnd []byte) error {
+	walker := node.ringPtr.walker
+	nodeID := node.Status.Info.NodeID
+
+	if source == nil || !bytes.Equal(source.NodeID, rangeEnd) {
+		return fmt.Errorf("range does not end on source node")
+	}
+	if walker.Cmp(rangeStart, rangeEnd) == 0 || walker.GtLe(nodeID, rangeStart, rangeEnd) {
+		return fmt.Errorf("range contains target node")
+	}
+	predecessor := node.GetPredecessor()
+	if !bytes.Equal(predecessor.NodeID, nodeID) && walker.GtLe(predecessor.NodeID, rangeStart, rangeEnd) && walker.Cmp(predecessor.NodeID, rangeEnd) != 0 {
+		return fmt.Errorf("range is not owned by source node, predecessor %s lies within", vpp2pdat.NodeIDToShortString(predecessor.NodeID))
+	}
+
+	return nil
+}
+
+// merkle serves a Merkle request. It stores entries, as replicas, then
+// describes the nodes at prefixes of the Merkle tree of the entries held
+// within (rangeStart,rangeEnd], and returns the entries for wantedKeys.
+// Entries and wanted keys which are out of the range are ignored.
+func (node *Node) merkle(rangeStart, rangeEnd []byte, prefixes [][]byte, entries []*vpp2papi.DataEntry, wantedKeys [][]byte) ([]*vpp2papi.MerkleNode, []*vpp2papi.DataEntry) {
+	walker := node.ringPtr.walker
+
+	node.storeEntries(node.entriesInRange(entries, rangeStart, rangeEnd))
+	wantedInRange := make([][]byte, 0, len(wantedKeys))
+	for _, v := range wantedKeys {
+		if walker.GtLe(v, rangeStart, rangeEnd) {
+			wantedInRange = append(wantedInRange, v)
+		}
+	}
+
+	nodes := make([]*vpp2papi.MerkleNode, 0, len(prefixes))
+	if len(prefixes) > 0 {
+		tree := node.merkleTree(rangeStart, rangeEnd)
+		for _, prefix := range prefixes {
+			nodes = append(nodes, tree.node(prefix))
+		}
+	}
+
+	return nodes, node.dataEntries(wantedInRange)
+}
+
+// merkleTree builds the Merkle tree of the entries held within
+// (rangeStart,rangeEnd].
+func (node *Node) merkleTree(rangeStart, rangeEnd []byte) *merkleTree {
+	walker := node.ringPtr.walker
+
+	keys, values := node.store.list()
+	n := 0
+	for i, key := range keys {
+		if walker.GtLe(key, rangeStart, rangeEnd) {
+			keys[n], values[n] = key, values[i]
+			n++
+		}
+	}
+
+	return newMerkleTree(keys[:n], values[:n])
+}
+
+// entriesInRange returns the entries whose keys are within (rangeStart,rangeEnd].
+func (node *Node) entriesInRange(entries []*vpp2papi.DataEntry, rangeStart, rangeEnd []byte) []*vpp2papi.DataEntry {
+	walker := node.ringPtr.walker
+
+	ret := make([]*vpp2papi.DataEntry, 0, len(entries))
+	for _, v := range entries {
+		if walker.GtLe(v.Key, rangeStart, rangeEnd) {
+			ret = append(ret, v)
+		}
+	}
+
+	return ret
+}
+
+// dataEntries returns the entries for keys, with the number of seconds
+// they have left, rounded down so that copying an entry never extends
+// its lifetime. Unknown and expiring entries are skipped.
+func (node *Node) dataEntries(keys [][]byte) []*vpp2papi.DataEntry {
+	ret := make([]*vpp2papi.DataEntry, 0, len(keys))
+	for _, key := range keys {
+		value, ttl, ok := node.store.entry(key)
+		if !ok || ttl < time.Second {
+			continue
+		}
+		ret = append(ret, &vpp2papi.DataEntry{Key: key, Value: value, TTL: int32(ttl / time.Second)})
+	}
+
+	return ret
+}
+
+// storeEntries stores entries, as replicas, their lifetime being
+// capped by the ring DataLifetime. Returns the number of entries stored.
+func (node *Node) storeEntries(entries []*vpp2papi.DataEntry) int {
+	for _, v := range entries {
+		lifetime := time.Duration(v.TTL) * time.Second
+		if lifetime > node.ringPtr.dataLifetime {
+			lifetime = node.ringPtr.dataLifetime
+		}
+		node.store.put(v.Key, v.Value, lifetime)
+	}
+
+	return len(entries)
+}
+
+func (node *Node) remoteMerkle(target *vpp2papi.NodeInfo, rangeStart, rangeEnd []byte, prefixes [][]byte, entries []*vpp2papi.DataEntry, wantedKeys [][]byte) ([]*vpp2papi.MerkleNode, []*vpp2papi.DataEntry, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request := vpp2papi.NewMerkleRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.RangeStart = rangeStart
+	request.RangeEnd = rangeEnd
+	request.Prefixes = prefixes
+	request.Entries = entries
+	request.WantedKeys = wantedKeys
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.MerkleRequestSigBytes(request) })
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := targetAPI.Merkle(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if response == nil {
+		return nil, nil, fmt.Errorf("no response to remote merkle")
+	}
+
+	return response.Nodes, response.Entries, nil
+}
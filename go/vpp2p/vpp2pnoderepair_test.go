@@ -0,0 +1,187 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+	"time"
+)
+
+func TestRepairReplicas(t *testing.T) {
+	const nbNodes = 4
+	const nbKeys = 100
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	// store keys owned by the first node, along with their replicas
+	owner := nodes[0]
+	replica := nodes[1]
+	keys := make([][]byte, 0, nbKeys)
+	for i := 0; len(keys) < nbKeys; i++ {
+		key := vpsum.Checksum256([]byte(fmt.Sprintf("level item %d", i)))
+		if !owner.isKeyOnNode(key) {
+			continue
+		}
+		nbCopy, _, err := owner.Put(key, []byte(fmt.Sprintf("map %d", i)), false)
+		if err != nil || nbCopy != 3 {
+			t.Fatal("unable to put key", nbCopy, err)
+		}
+		keys = append(keys, key)
+	}
+
+	nbTransferred, err := owner.RepairReplicas()
+	if err != nil || nbTransferred != 0 {
+		t.Error("replicas in sync, but entries transferred", nbTransferred, err)
+	}
+
+	// one key lost by the replica, one changed on the replica,
+	// and one lost by the owner
+	replica.store.delete(keys[0])
+	replica.store.put(keys[1], []byte("another map"), replica.ringPtr.dataLifetime)
+	value, _ := owner.store.get(keys[2])
+	owner.store.delete(keys[2])
+
+	nbTransferred, err = owner.RepairReplicas()
+	if err != nil {
+		t.Error("unable to repair replicas", err)
+	}
+	if nbTransferred != 3 {
+		t.Errorf("bad number of entries transferred %d!=3", nbTransferred)
+	}
+	for i := 0; i < 3; i++ {
+		ownerValue, _ := owner.store.get(keys[i])
+		replicaValue, _ := replica.store.get(keys[i])
+		if ownerValue == nil || !bytes.Equal(ownerValue, replicaValue) {
+			t.Errorf("key %d not repaired", i)
+		}
+	}
+	ownerValue, _ := owner.store.get(keys[2])
+	if !bytes.Equal(ownerValue, value) {
+		t.Error("lost key not restored on owner")
+	}
+
+	nbTransferred, err = owner.RepairReplicas()
+	if err != nil || nbTransferred != 0 {
+		t.Error("replicas repaired, but entries transferred again", nbTransferred, err)
+	}
+}
+
+func TestRepairReplicasAutoSync(t *testing.T) {
+	const nbNodes = 4
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	// all nodes share the same ring, make the sync loop tick fast
+	nodes[0].ringPtr.syncDelay = 50 * time.Millisecond
+
+	owner := nodes[0]
+	replica := nodes[1]
+	var key []byte
+	for i := 0; key == nil; i++ {
+		k := vpsum.Checksum256([]byte(fmt.Sprintf("level item %d", i)))
+		if owner.isKeyOnNode(k) {
+			key = k
+		}
+	}
+	// the owner holds a value the replica never received
+	owner.store.put(key, []byte("map"), owner.ringPtr.dataLifetime)
+
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	var value []byte
+	for i := 0; i < 100 && value == nil; i++ {
+		time.Sleep(20 * time.Millisecond)
+		value, _ = replica.store.get(key)
+	}
+	if !bytes.Equal(value, []byte("map")) {
+		t.Error("replica not repaired by the sync loop")
+	}
+}
+
+func TestRepairReplicasRangeOwner(t *testing.T) {
+	const nbNodes = 4
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	owner := nodes[0]
+	replica := nodes[1]
+	rangeStart := owner.GetPredecessor().NodeID
+	rangeEnd := owner.Status.Info.NodeID
+	var inKey, outKey []byte
+	for i := 0; inKey == nil || outKey == nil; i++ {
+		k := vpsum.Checksum256([]byte(fmt.Sprintf("level item %d", i)))
+		if owner.isKeyOnNode(k) {
+			inKey = k
+		} else {
+			outKey = k
+		}
+	}
+	entries := []*vpp2papi.DataEntry{{Key: inKey, Value: []byte("map"), TTL: 60}, {Key: outKey, Value: []byte("map"), TTL: 60}}
+
+	_, _, err = owner.remoteMerkle(replica.Status.Info, rangeStart, rangeEnd, nil, entries, nil)
+	if err != nil {
+		t.Error("unable to push entries to replica", err)
+	}
+	if _, ok := replica.store.get(inKey); !ok {
+		t.Error("entry in range not stored")
+	}
+	if _, ok := replica.store.get(outKey); ok {
+		t.Error("entry out of range stored")
+	}
+
+	_, _, err = nodes[2].remoteMerkle(replica.Status.Info, rangeStart, rangeEnd, nil, entries, nil)
+	if err == nil {
+		t.Error("no error when pushing entries for a range owned by another node")
+	}
+	// starting just after the owner, the range covers the whole ring
+	_, _, err = owner.remoteMerkle(replica.Status.Info, owner.ringPtr.walker.Incr(rangeEnd), rangeEnd, nil, entries, nil)
+	if err == nil {
+		t.Error("no error when pushing entries for a range containing the target")
+	}
+}
@@ -0,0 +1,132 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"fmt"
+	"github.com/ufoot/vapor/go/vpcrypto"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+)
+
+// SecureMessageHandler is called when a node receives a secure message.
+// Source is the node which sent it, as claimed by the request, which
+// is only authenticated on signed rings.
+type SecureMessageHandler func(source *vpp2papi.NodeInfo, payload []byte)
+
+// SetSecureMessageHandler sets the function called when the node
+// receives a secure message, passing nil means messages are ignored.
+// It's thread-safe.
+func (node *Node) SetSecureMessageHandler(handler SecureMessageHandler) {
+	defer node.handlersAccess.Unlock()
+	node.handlersAccess.Lock()
+
+	node.secureHandler = handler
+}
+
+// SendSecure sends a payload to the target node, encrypted so that
+// only the host of the target node can read it, whichever hosts or
+// proxies the message goes through. The first message sent to a host
+// opens a session, its key is encrypted with the public key of the
+// host, later messages just use the session key, which is much faster.
+// The target host must have a key, unsigned hosts can send secure
+// messages, but not receive them. Returns true if the target node had
+// a handler to read the message.
+func (node *Node) SendSecure(target *vpp2papi.NodeInfo, payload []byte) (bool, error) {
+	_, err := vpp2pdat.CheckPayload(payload)
+	if err != nil {
+		return false, err
+	}
+	_, err = vpp2pdat.CheckNodeInfo(target)
+	if err != nil {
+		return false, err
+	}
+
+	session, err := node.hostPtr.secure.openOutgoing(target.HostPubKey)
+	if err != nil {
+		return false, err
+	}
+	encrypted, err := vpcrypto.SymEncrypt(payload, session.key)
+	if err != nil {
+		return false, err
+	}
+	var sessionKey []byte
+	if !session.confirmed {
+		sessionKey = session.encryptedKey
+	}
+
+	delivered, unknownSession, err := node.remoteSecureMessage(target, session.id, sessionKey, encrypted)
+	if err == nil && unknownSession && sessionKey == nil {
+		// target host forgot about the session, send the key again
+		delivered, unknownSession, err = node.remoteSecureMessage(target, session.id, session.encryptedKey, encrypted)
+	}
+	if err != nil {
+		return false, err
+	}
+	if unknownSession {
+		return false, fmt.Errorf("target host refused the session")
+	}
+	node.hostPtr.secure.confirm(target.HostPubKey, session.id)
+
+	return delivered, nil
+}
+
+// receiveSecure hands a decrypted secure message to the handler,
+// returns false if there's no handler.
+func (node *Node) receiveSecure(source *vpp2papi.NodeInfo, payload []byte) bool {
+	node.handlersAccess.RLock()
+	handler := node.secureHandler
+	node.handlersAccess.RUnlock()
+
+	if handler == nil {
+		return false
+	}
+	handler(source, payload)
+
+	return true
+}
+
+func (node *Node) remoteSecureMessage(target *vpp2papi.NodeInfo, sessionID, sessionKey, payload []byte) (bool, bool, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return false, false, err
+	}
+
+	request := vpp2papi.NewSecureMessageRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.SessionID = sessionID
+	request.SessionKey = sessionKey
+	request.Payload = payload
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.SecureMessageRequestSigBytes(request) })
+	if err != nil {
+		return false, false, err
+	}
+
+	response, err := targetAPI.SecureMessage(request)
+	if err != nil {
+		return false, false, err
+	}
+	if response == nil {
+		return false, false, fmt.Errorf("no response to remote secure message")
+	}
+
+	return response.Delivered, response.UnknownSession, nil
+}
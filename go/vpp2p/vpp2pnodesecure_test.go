@@ -0,0 +1,210 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+)
+
+// testSecureTransport records the secure messages going through it,
+// it plays the part of an intermediate hop.
+type testSecureTransport struct {
+	access   sync.Mutex
+	requests []*vpp2papi.SecureMessageRequest
+}
+
+type testSecureLink struct {
+	*Host
+	transport *testSecureTransport
+}
+
+func (t *testSecureTransport) Connect(host *Host) (vpp2papi.VpP2pApi, error) {
+	return &testSecureLink{Host: host, transport: t}, nil
+}
+
+func (l *testSecureLink) SecureMessage(request *vpp2papi.SecureMessageRequest) (*vpp2papi.SecureMessageResponse, error) {
+	l.transport.access.Lock()
+	l.transport.requests = append(l.transport.requests, request)
+	l.transport.access.Unlock()
+
+	return l.Host.SecureMessage(request)
+}
+
+func TestSecureMessage(t *testing.T) {
+	var received [][]byte
+	var receivedAccess sync.Mutex
+
+	env := NewEnv()
+	transport := &testSecureTransport{}
+	env.SetTransport(transport)
+	sender, err := NewHost(env, testTitle, testURL+"/secure/sender", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	receiver, err := NewHost(env, testTitle, testURL+"/secure/receiver", true)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	ring, err := NewRing(env, sender, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	senderNode, err := NewNode(env, sender, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	receiverNode, err := NewNode(env, receiver, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	senderNode.Start()
+	defer senderNode.Stop()
+	receiverNode.Start()
+	defer receiverNode.Stop()
+	receiverNode.SetSecureMessageHandler(func(source *vpp2papi.NodeInfo, payload []byte) {
+		if !bytes.Equal(source.NodeID, senderNode.Status.Info.NodeID) {
+			t.Error("bad source node")
+		}
+		receivedAccess.Lock()
+		received = append(received, payload)
+		receivedAccess.Unlock()
+	})
+
+	secret := []byte("meet me at the northern gate, bring the key")
+	for i := 0; i < 2; i++ {
+		delivered, err := senderNode.SendSecure(receiverNode.Status.Info, secret)
+		if err != nil || !delivered {
+			t.Fatal("unable to send secure message", err)
+		}
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("bad number of requests %d", len(transport.requests))
+	}
+	if len(transport.requests[0].SessionKey) == 0 {
+		t.Error("first message does not carry the session key")
+	}
+	if len(transport.requests[1].SessionKey) != 0 {
+		t.Error("session key sent again once the session is open")
+	}
+	for _, request := range transport.requests {
+		if bytes.Contains(request.Payload, secret) || bytes.Contains(request.SessionKey, secret) {
+			t.Error("secret readable by intermediate hop")
+		}
+	}
+
+	// receiver forgetting the session should not prevent delivery
+	receiver.secure = newSecureStore(SystemClock())
+	delivered, err := senderNode.SendSecure(receiverNode.Status.Info, secret)
+	if err != nil || !delivered {
+		t.Error("unable to send secure message after receiver forgot the session", err)
+	}
+	if len(transport.requests) != 4 || len(transport.requests[3].SessionKey) == 0 {
+		t.Error("session key not sent again after receiver forgot the session")
+	}
+
+	if len(received) != 3 {
+		t.Errorf("bad number of received messages %d", len(received))
+	}
+	for _, v := range received {
+		if !bytes.Equal(v, secret) {
+			t.Error("bad message received", string(v))
+		}
+	}
+
+	// hosts without keys can't receive secure messages
+	_, err = receiverNode.SendSecure(senderNode.Status.Info, secret)
+	if err == nil {
+		t.Error("secure message sent to a host without key")
+	}
+	_, err = senderNode.SendSecure(receiverNode.Status.Info, []byte{})
+	if err == nil {
+		t.Error("empty secure message sent")
+	}
+}
+
+func TestSecureMessageLoadHost(t *testing.T) {
+	var received [][]byte
+	var receivedAccess sync.Mutex
+
+	passphrase := []byte("this is a long enough passphrase")
+
+	dir, err := ioutil.TempDir("", "vpp2pnodesecure")
+	if err != nil {
+		t.Fatal("unable to create temp dir", err)
+	}
+	defer os.RemoveAll(dir)
+
+	env := NewEnv()
+	sender, err := NewHost(env, testTitle, testURL+"/secure/sender", false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	receiver, err := NewHost(env, testTitle, testURL+"/secure/receiver", true)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	err = receiver.Save(dir, passphrase)
+	if err != nil {
+		t.Fatal("unable to save host", err)
+	}
+	// the receiver restarts, from its saved state
+	receiver, err = LoadHost(env, dir, passphrase)
+	if err != nil {
+		t.Fatal("unable to load host", err)
+	}
+	ring, err := NewRing(env, sender, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	senderNode, err := NewNode(env, sender, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	receiverNode, err := NewNode(env, receiver, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	senderNode.Start()
+	defer senderNode.Stop()
+	receiverNode.Start()
+	defer receiverNode.Stop()
+	receiverNode.SetSecureMessageHandler(func(source *vpp2papi.NodeInfo, payload []byte) {
+		receivedAccess.Lock()
+		received = append(received, payload)
+		receivedAccess.Unlock()
+	})
+
+	secret := []byte("meet me at the northern gate, bring the key")
+	for i := 0; i < 2; i++ {
+		delivered, err := senderNode.SendSecure(receiverNode.Status.Info, secret)
+		if err != nil || !delivered {
+			t.Fatal("unable to send secure message to a loaded host", err)
+		}
+	}
+	if len(received) != 2 || !bytes.Equal(received[0], secret) || !bytes.Equal(received[1], secret) {
+		t.Error("secure messages not received by loaded host")
+	}
+}
@@ -0,0 +1,377 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"time"
+)
+
+// syncLoop runs SyncStep every SyncDelay seconds, until stop is closed.
+func (node *Node) syncLoop(stop chan bool) {
+	ticker := time.NewTicker(node.ringPtr.syncDelay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			node.SyncStep()
+		}
+	}
+}
+
+// SyncStep performs one synchronization step, the one the background loop
+// runs every SyncDelay seconds. It calls Stabilize, repairs replicas,
+// then purges expired data from the local store and directory, and
+// expired challenges. Simulations, which disable the background loop,
+// call it directly.
+func (node *Node) SyncStep() {
+	node.Stabilize()
+	node.RepairReplicas()
+	node.store.purge()
+	node.topics.purge()
+	node.directory.purge()
+	node.env.hostInfoCatalog.Purge()
+	node.purgeChallenges()
+}
+
+// Stabilize performs one stabilization step. It checks wether a node
+// has been inserted between this node and its successor, tells the
+// successor about this node, then refreshes the successors list, the
+// D pointer and the predecessor. Peers which have not been reachable
+// for more than DisconnectTimeout are dropped. This is called
+// periodically once the node is started, but one can call it
+// directly, typically to speed up things on a freshly joined node.
+func (node *Node) Stabilize() {
+	node.stabilizeSuccessors()
+	node.stabilizeD()
+	node.stabilizePredecessor()
+	node.forgetPeers()
+}
+
+func (node *Node) stabilizeSuccessors() {
+	walker := node.ringPtr.walker
+	nodeID := node.Status.Info.NodeID
+
+	successors := node.GetSuccessors()
+	if len(successors) == 0 {
+		predecessor := node.GetPredecessor()
+		if bytes.Equal(predecessor.NodeID, nodeID) {
+			// alone on the ring, nothing to do
+			return
+		}
+		// someone told us it's our predecessor, on a 2 nodes
+		// ring it's also our successor, start from there
+		successors = append(successors, predecessor)
+	}
+
+	for i := 0; i < len(successors); i++ {
+		successor := successors[i]
+
+		predecessor, err := node.remoteGetPredecessor(successor)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to get predecessor of successor", err)
+			if node.peerDisconnected(successor.NodeID) {
+				vplog.LoggerNoticef(node.env.Logger(), "dropping successor %s", vpp2pdat.NodeIDToShortString(successor.NodeID))
+				successors = append(successors[:i], successors[i+1:]...)
+				node.setSuccessors(successors)
+				i--
+				continue
+			}
+			// give it another chance on next round, meanwhile use
+			// the next successor, the list is rebuilt from there,
+			// the skipped one comes back if it answers again, since
+			// it would then be the predecessor of its successor
+			continue
+		}
+		node.peerSeen(successor.NodeID)
+
+		synced := false
+		if predecessor != nil && walker.GtLe(predecessor.NodeID, nodeID, successor.NodeID) && walker.Cmp(predecessor.NodeID, successor.NodeID) != 0 && node.checkPeer(predecessor) == nil {
+			// a node has been inserted between us and our successor,
+			// use it only if it answers, else it could be a dead node
+			// its successor has not dropped yet
+			err = node.remoteSync(predecessor)
+			if err == nil {
+				successor = predecessor
+				synced = true
+			} else {
+				vplog.LoggerDebug(node.env.Logger(), "unable to sync with inserted node", err)
+			}
+		}
+
+		if !synced {
+			err = node.remoteSync(successor)
+			if err != nil {
+				vplog.LoggerDebug(node.env.Logger(), "unable to sync with successor", err)
+			}
+		}
+
+		var successorSuccessors []*vpp2papi.NodeInfo
+		successorSuccessors, err = node.remoteGetSuccessors(successor)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to get successors of successor", err)
+			successorSuccessors = successors[i+1:]
+		}
+		node.setSuccessors(node.buildSuccessors(successor, successorSuccessors))
+
+		return
+	}
+}
+
+// buildSuccessors builds a successors list starting with successor, followed
+// by its own successors, stopping when the ring has been walked completely
+// or when the list is long enough to hold NbCopy elements. Successors
+// which do not pass checkPeer are skipped.
+func (node *Node) buildSuccessors(successor *vpp2papi.NodeInfo, successorSuccessors []*vpp2papi.NodeInfo) []*vpp2papi.NodeInfo {
+	nodeID := node.Status.Info.NodeID
+	nbSuccessors := int(node.ringPtr.Info.Config.NbCopy)
+
+	ret := make([]*vpp2papi.NodeInfo, 1, nbSuccessors)
+	ret[0] = successor
+	for _, v := range successorSuccessors {
+		if len(ret) >= nbSuccessors || v == nil || bytes.Equal(v.NodeID, nodeID) {
+			break
+		}
+		if bytes.Equal(v.NodeID, ret[len(ret)-1].NodeID) || node.checkPeer(v) != nil {
+			continue
+		}
+		ret = append(ret, v)
+	}
+
+	return ret
+}
+
+func (node *Node) stabilizeD() {
+	walker := node.ringPtr.walker
+
+	target := walker.NextFirst(node.Status.Info.NodeID)
+	found, path, err := node.Lookup(target, node.GetKeyShift(target), node.GetImaginaryNode(target))
+	if err != nil || !found || len(path) == 0 {
+		vplog.LoggerDebug(node.env.Logger(), "unable to lookup D", err)
+		d := node.GetD()
+		if d != nil && node.peerDisconnected(d.NodeID) {
+			vplog.LoggerNoticef(node.env.Logger(), "dropping D %s", vpp2pdat.NodeIDToShortString(d.NodeID))
+			node.resetD()
+		}
+		return
+	}
+
+	// owner is the first node after target, D is the node just before
+	// target, so in most cases it's the predecessor of the owner
+	owner := path[len(path)-1]
+	err = node.checkPeer(owner)
+	if err != nil {
+		vplog.LoggerDebug(node.env.Logger(), "unable to use D", err)
+		return
+	}
+	node.peerSeen(owner.NodeID)
+	d := owner
+	if walker.Cmp(owner.NodeID, target) != 0 {
+		var predecessor *vpp2papi.NodeInfo
+		predecessor, err = node.remoteGetPredecessor(owner)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to get predecessor of D", err)
+		} else if predecessor != nil && node.checkPeer(predecessor) == nil {
+			d = predecessor
+		}
+	}
+	node.setD(d)
+}
+
+func (node *Node) stabilizePredecessor() {
+	predecessor := node.GetPredecessor()
+	if bytes.Equal(predecessor.NodeID, node.Status.Info.NodeID) {
+		node.setPredecessors(nil)
+		return
+	}
+
+	// any call would do, this one checks the node is really
+	// there, and not only its host
+	_, err := node.remoteGetSuccessors(predecessor)
+	if err != nil {
+		vplog.LoggerDebug(node.env.Logger(), "unable to contact predecessor", err)
+		if node.peerDisconnected(predecessor.NodeID) {
+			vplog.LoggerNoticef(node.env.Logger(), "dropping predecessor %s", vpp2pdat.NodeIDToShortString(predecessor.NodeID))
+			node.resetPredecessor()
+		}
+		return
+	}
+	node.peerSeen(predecessor.NodeID)
+	node.refreshPredecessors(predecessor)
+}
+
+// refreshPredecessors walks the ring backwards from predecessor, and
+// records the NbCopy nodes preceding this one. The walk stops early
+// when it gets back to this node, on small rings, or when a node does
+// not answer, in which case the nodes found so far are kept.
+func (node *Node) refreshPredecessors(predecessor *vpp2papi.NodeInfo) {
+	nodeID := node.Status.Info.NodeID
+	nbCopy := int(node.ringPtr.Info.Config.NbCopy)
+
+	predecessors := []*vpp2papi.NodeInfo{predecessor}
+	for len(predecessors) < nbCopy {
+		last := predecessors[len(predecessors)-1]
+		if bytes.Equal(last.NodeID, nodeID) {
+			break
+		}
+		previous, err := node.remoteGetPredecessor(last)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to get predecessor of predecessor", err)
+			break
+		}
+		if previous == nil || bytes.Equal(previous.NodeID, last.NodeID) || node.checkPeer(previous) != nil {
+			break
+		}
+		predecessors = append(predecessors, previous)
+	}
+	node.setPredecessors(predecessors)
+}
+
+// peerSeen records the fact a peer has been successfully contacted.
+func (node *Node) peerSeen(nodeID []byte) {
+	defer node.peersAccess.Unlock()
+	node.peersAccess.Lock()
+
+	node.lastSeen[vpp2pdat.NodeIDToBuf(nodeID)] = node.clock.Now()
+}
+
+// peerDisconnected returns true if a peer has not been successfully
+// contacted for more than DisconnectTimeout. A peer that has never
+// been seen gets the benefit of the doubt, the first failure starts
+// the countdown.
+func (node *Node) peerDisconnected(nodeID []byte) bool {
+	defer node.peersAccess.Unlock()
+	node.peersAccess.Lock()
+
+	nodeIDBuf := vpp2pdat.NodeIDToBuf(nodeID)
+	lastSeen, ok := node.lastSeen[nodeIDBuf]
+	if !ok {
+		node.lastSeen[nodeIDBuf] = node.clock.Now()
+		return false
+	}
+	if node.clock.Now().Sub(lastSeen) > node.ringPtr.disconnectTimeout {
+		delete(node.lastSeen, nodeIDBuf)
+		return true
+	}
+
+	return false
+}
+
+// forgetPeers removes the peers which are not successors, D or
+// predecessor any more from the last seen register.
+func (node *Node) forgetPeers() {
+	peers := make(map[[vpp2pdat.NodeIDBufNbBytes]byte]bool)
+	for _, v := range node.GetSuccessors() {
+		peers[vpp2pdat.NodeIDToBuf(v.NodeID)] = true
+	}
+	if d := node.GetD(); d != nil {
+		peers[vpp2pdat.NodeIDToBuf(d.NodeID)] = true
+	}
+	peers[vpp2pdat.NodeIDToBuf(node.GetPredecessor().NodeID)] = true
+
+	defer node.peersAccess.Unlock()
+	node.peersAccess.Lock()
+
+	for k := range node.lastSeen {
+		if !peers[k] {
+			delete(node.lastSeen, k)
+		}
+	}
+}
+
+func (node *Node) remoteGetSuccessors(target *vpp2papi.NodeInfo) ([]*vpp2papi.NodeInfo, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return nil, err
+	}
+
+	request := vpp2papi.NewGetSuccessorsRequest()
+	request.Context = node.contextInfo(target.NodeID)
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.GetSuccessorsRequestSigBytes(request) })
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := targetAPI.GetSuccessors(request)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, fmt.Errorf("no successors returned by remote node")
+	}
+
+	return response.SuccessorNodes, nil
+}
+
+func (node *Node) remoteGetPredecessor(target *vpp2papi.NodeInfo) (*vpp2papi.NodeInfo, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return nil, err
+	}
+
+	request := vpp2papi.NewGetPredecessorRequest()
+	request.Context = node.contextInfo(target.NodeID)
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.GetPredecessorRequestSigBytes(request) })
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := targetAPI.GetPredecessor(request)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, fmt.Errorf("no predecessor returned by remote node")
+	}
+
+	return response.PredecessorNode, nil
+}
+
+// remoteSync tells target that this node is, or might be, its predecessor.
+func (node *Node) remoteSync(target *vpp2papi.NodeInfo) error {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return err
+	}
+
+	nodeID := node.Status.Info.NodeID
+	request := vpp2papi.NewSyncRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.KeyShift = node.GetKeyShift(nodeID)
+	request.ImaginaryNode = node.GetImaginaryNode(nodeID)
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.SyncRequestSigBytes(request) })
+	if err != nil {
+		return err
+	}
+
+	_, err = targetAPI.Sync(request)
+
+	return err
+}
@@ -0,0 +1,147 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"testing"
+)
+
+func checkLinkedNodes(t *testing.T, nodes []*Node, expected []*vpp2papi.NodeStatus) {
+	for i, node := range nodes {
+		nodeID := hex.EncodeToString(node.Status.Info.NodeID)
+		successors := node.GetSuccessors()
+		if len(successors) != len(expected[i].Peers.Successors) {
+			t.Errorf("bad number of successors for %s: %d!=%d", nodeID, len(successors), len(expected[i].Peers.Successors))
+			continue
+		}
+		for j, successor := range successors {
+			if bytes.Compare(successor.NodeID, expected[i].Peers.Successors[j].NodeID) != 0 {
+				t.Errorf("bad successor %d for %s", j, nodeID)
+			}
+		}
+		if bytes.Compare(node.GetPredecessor().NodeID, expected[i].Predecessor.NodeID) != 0 {
+			t.Errorf("bad predecessor for %s", nodeID)
+		}
+		if node.GetD() == nil || bytes.Compare(node.GetD().NodeID, expected[i].Peers.D.NodeID) != 0 {
+			t.Errorf("bad D for %s", nodeID)
+		}
+	}
+}
+
+func TestStabilize(t *testing.T) {
+	const nbNodes = 16
+	const nbRounds = 10
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	// keep what a fully linked ring should look like, then
+	// only keep the direct successors, and let stabilization
+	// do the rest
+	expected := make([]*vpp2papi.NodeStatus, nbNodes)
+	for i, node := range nodes {
+		expected[i] = vpp2papi.NewNodeStatus()
+		expected[i].Peers = vpp2papi.NewNodePeers()
+		expected[i].Peers.Successors = node.GetSuccessors()
+		expected[i].Peers.D = node.GetD()
+		expected[i].Predecessor = node.GetPredecessor()
+	}
+	for _, node := range nodes {
+		node.setSuccessors(node.GetSuccessors()[0:1])
+		node.resetD()
+		node.resetPredecessor()
+	}
+
+	for i := 0; i < nbRounds; i++ {
+		for _, node := range nodes {
+			node.Stabilize()
+		}
+	}
+	checkLinkedNodes(t, nodes, expected)
+
+	// now stop a node, and check nobody refers to it any more
+	stopped := nodes[nbNodes/2]
+	stopped.Stop()
+	stopped.ringPtr.disconnectTimeout = 0
+	nodes = append(nodes[:nbNodes/2], nodes[nbNodes/2+1:]...)
+
+	for i := 0; i < nbRounds; i++ {
+		for _, node := range nodes {
+			node.Stabilize()
+		}
+	}
+	for _, node := range nodes {
+		nodeID := hex.EncodeToString(node.Status.Info.NodeID)
+		for _, successor := range node.GetSuccessors() {
+			if bytes.Compare(successor.NodeID, stopped.Status.Info.NodeID) == 0 {
+				t.Errorf("stopped node still a successor of %s", nodeID)
+			}
+		}
+		if bytes.Compare(node.GetPredecessor().NodeID, stopped.Status.Info.NodeID) == 0 {
+			t.Errorf("stopped node still the predecessor of %s", nodeID)
+		}
+		if node.GetD() != nil && bytes.Compare(node.GetD().NodeID, stopped.Status.Info.NodeID) == 0 {
+			t.Errorf("stopped node still the D of %s", nodeID)
+		}
+	}
+}
+
+func TestStabilizeUnreachableSuccessor(t *testing.T) {
+	const nbNodes = 6
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	// the successor is unreachable, but not for long enough to be
+	// dropped, the next one is used meanwhile
+	nodes[1].Stop()
+	for i := 0; i < 2; i++ {
+		nodes[0].Stabilize()
+		if bytes.Compare(nodes[0].GetSuccessors()[0].NodeID, nodes[2].Status.Info.NodeID) != 0 {
+			t.Error("unreachable successor not skipped")
+		}
+	}
+
+	// it answers again, it's used again
+	nodes[1].Start()
+	nodes[0].Stabilize()
+	if bytes.Compare(nodes[0].GetSuccessors()[0].NodeID, nodes[1].Status.Info.NodeID) != 0 {
+		t.Error("successor not used again once reachable")
+	}
+}
@@ -0,0 +1,326 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+)
+
+// MessageHandler is called when a message is published on a topic
+// a node is subscribed to. It is called while the node holding the
+// topic fans the message out, so it should return quickly.
+type MessageHandler func(topic string, message []byte)
+
+// Subscribe subscribes the node to a topic, handler being called for
+// each message published on it. The subscription is stored on the node
+// holding the key of the topic, and on its NbCopy-1 successors. Just
+// like data, it expires after the ring DataLifetime, so Subscribe must
+// be called again, typically every DataLifetime/2, to renew it.
+// Returns the number of copies of the subscription stored.
+func (node *Node) Subscribe(topic string, handler MessageHandler) (int, []*vpp2papi.NodeInfo, error) {
+	_, err := vpp2pdat.CheckTopic(topic)
+	if err != nil {
+		return 0, nil, err
+	}
+	if handler == nil {
+		return 0, nil, fmt.Errorf("no handler")
+	}
+
+	node.handlersAccess.Lock()
+	node.handlers[topic] = handler
+	node.handlersAccess.Unlock()
+
+	return node.subscribe(topic, node.Status.Info, false)
+}
+
+// Unsubscribe unsubscribes the node from a topic. Returns the number
+// of copies of the subscription removed.
+func (node *Node) Unsubscribe(topic string) (int, []*vpp2papi.NodeInfo, error) {
+	_, err := vpp2pdat.CheckTopic(topic)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	node.handlersAccess.Lock()
+	delete(node.handlers, topic)
+	node.handlersAccess.Unlock()
+
+	return node.unsubscribe(topic, node.Status.Info, false)
+}
+
+// Publish sends a message to all the subscribers of a topic, through
+// the node holding the key of the topic. Returns the number of nodes
+// the message has been delivered to.
+func (node *Node) Publish(topic string, message []byte) (int, []*vpp2papi.NodeInfo, error) {
+	_, err := vpp2pdat.CheckTopic(topic)
+	if err != nil {
+		return 0, nil, err
+	}
+	_, err = vpp2pdat.CheckMessage(message)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return node.publish(topic, message, false)
+}
+
+// subscribe records a subscriber. If replica is true, it is recorded
+// on this node only. Otherwise it is recorded on the node holding the
+// topic key, and on its NbCopy-1 successors.
+func (node *Node) subscribe(topic string, subscriber *vpp2papi.NodeInfo, replica bool) (int, []*vpp2papi.NodeInfo, error) {
+	key := vpp2pdat.TopicToKey(topic)
+	if replica {
+		node.topics.add(key, subscriber, node.ringPtr.dataLifetime)
+		return 1, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+	}
+
+	path, err := node.lookupOwner(key)
+	if err != nil {
+		return 0, path, err
+	}
+	owner := path[len(path)-1]
+	if !bytes.Equal(owner.NodeID, node.Status.Info.NodeID) {
+		nbCopy, err := node.remoteSubscribe(owner, topic, subscriber, false)
+		return nbCopy, path, err
+	}
+
+	node.topics.add(key, subscriber, node.ringPtr.dataLifetime)
+	nbCopy := 1
+	for _, replica := range node.replicas() {
+		_, err = node.remoteSubscribe(replica, topic, subscriber, true)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to subscribe on replica", err)
+			continue
+		}
+		nbCopy++
+	}
+
+	return nbCopy, path, nil
+}
+
+// unsubscribe removes a subscriber. If replica is true, it is removed
+// from this node only. Otherwise it is removed from the node holding
+// the topic key, and from its NbCopy-1 successors.
+func (node *Node) unsubscribe(topic string, subscriber *vpp2papi.NodeInfo, replica bool) (int, []*vpp2papi.NodeInfo, error) {
+	key := vpp2pdat.TopicToKey(topic)
+	if replica {
+		if node.topics.remove(key, subscriber) {
+			return 1, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+		}
+		return 0, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+	}
+
+	path, err := node.lookupOwner(key)
+	if err != nil {
+		return 0, path, err
+	}
+	owner := path[len(path)-1]
+	if !bytes.Equal(owner.NodeID, node.Status.Info.NodeID) {
+		nbCopy, err := node.remoteUnsubscribe(owner, topic, subscriber, false)
+		return nbCopy, path, err
+	}
+
+	nbCopy := 0
+	if node.topics.remove(key, subscriber) {
+		nbCopy++
+	}
+	for _, replica := range node.replicas() {
+		n, err := node.remoteUnsubscribe(replica, topic, subscriber, true)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to unsubscribe on replica", err)
+			continue
+		}
+		nbCopy += n
+	}
+
+	return nbCopy, path, nil
+}
+
+// publish sends a message. If deliver is true, the message is handed
+// to the handler of this node, if it's subscribed. Otherwise it is sent
+// to the node holding the topic key, which delivers it to all subscribers.
+func (node *Node) publish(topic string, message []byte, deliver bool) (int, []*vpp2papi.NodeInfo, error) {
+	if deliver {
+		if node.deliver(topic, message) {
+			return 1, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+		}
+		return 0, []*vpp2papi.NodeInfo{node.Status.Info}, nil
+	}
+
+	key := vpp2pdat.TopicToKey(topic)
+	path, err := node.lookupOwner(key)
+	if err != nil {
+		return 0, path, err
+	}
+	owner := path[len(path)-1]
+	if !bytes.Equal(owner.NodeID, node.Status.Info.NodeID) {
+		nbDelivered, err := node.remotePublish(owner, topic, message, false)
+		return nbDelivered, path, err
+	}
+
+	nbDelivered := 0
+	for _, subscriber := range node.topics.list(key) {
+		if bytes.Equal(subscriber.NodeID, node.Status.Info.NodeID) {
+			if node.deliver(topic, message) {
+				nbDelivered++
+			}
+			continue
+		}
+		n, err := node.remotePublish(subscriber, topic, message, true)
+		if err != nil {
+			vplog.LoggerDebug(node.env.Logger(), "unable to deliver message", err)
+			continue
+		}
+		nbDelivered += n
+	}
+
+	return nbDelivered, path, nil
+}
+
+// checkSubscriber checks that a subscriber, as given in a request
+// made by source, is a valid node of the ring. A node can only
+// subscribe or unsubscribe itself, so that it can't get messages sent
+// to others, nor cut them from a topic. Replicas are only pushed by
+// the node holding the topic key, or by one of its replicas.
+func (node *Node) checkSubscriber(source, subscriber *vpp2papi.NodeInfo, topic string, replica bool) error {
+	if subscriber == nil {
+		return fmt.Errorf("no subscriber")
+	}
+	_, err := vpp2pdat.CheckNodeInfo(subscriber)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(subscriber.RingID, node.ringPtr.Info.RingID) {
+		return fmt.Errorf("subscriber is not on the ring")
+	}
+	if replica {
+		err = node.checkKeyOwner(source, vpp2pdat.TopicToKey(topic), true)
+	} else if source == nil || !bytes.Equal(subscriber.NodeID, source.NodeID) {
+		err = fmt.Errorf("subscriber is not the source node")
+	}
+	if err != nil {
+		return err
+	}
+
+	return node.checkPeer(subscriber)
+}
+
+// deliver calls the handler of a topic, returns false if the node
+// is not subscribed to it.
+func (node *Node) deliver(topic string, message []byte) bool {
+	node.handlersAccess.RLock()
+	handler := node.handlers[topic]
+	node.handlersAccess.RUnlock()
+
+	if handler == nil {
+		return false
+	}
+	handler(topic, message)
+
+	return true
+}
+
+func (node *Node) remoteSubscribe(target *vpp2papi.NodeInfo, topic string, subscriber *vpp2papi.NodeInfo, replica bool) (int, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return 0, err
+	}
+
+	request := vpp2papi.NewSubscribeRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.Topic = topic
+	request.Subscriber = subscriber
+	request.Replica = replica
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.SubscribeRequestSigBytes(request) })
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := targetAPI.Subscribe(request)
+	if err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, fmt.Errorf("no response to remote subscribe")
+	}
+
+	return int(response.NbCopy), nil
+}
+
+func (node *Node) remoteUnsubscribe(target *vpp2papi.NodeInfo, topic string, subscriber *vpp2papi.NodeInfo, replica bool) (int, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return 0, err
+	}
+
+	request := vpp2papi.NewUnsubscribeRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.Topic = topic
+	request.Subscriber = subscriber
+	request.Replica = replica
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.UnsubscribeRequestSigBytes(request) })
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := targetAPI.Unsubscribe(request)
+	if err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, fmt.Errorf("no response to remote unsubscribe")
+	}
+
+	return int(response.NbCopy), nil
+}
+
+func (node *Node) remotePublish(target *vpp2papi.NodeInfo, topic string, message []byte, deliver bool) (int, error) {
+	targetAPI, err := node.env.nodeCatalog.ConnectToNode(target)
+	if err != nil {
+		return 0, err
+	}
+
+	request := vpp2papi.NewPublishRequest()
+	request.Context = node.contextInfo(target.NodeID)
+	request.Topic = topic
+	request.Message = message
+	request.Deliver = deliver
+
+	request.Sig, err = node.authenticate(targetAPI, request.Context, func() []byte { return vpp2pdat.PublishRequestSigBytes(request) })
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := targetAPI.Publish(request)
+	if err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, fmt.Errorf("no response to remote publish")
+	}
+
+	return int(response.NbDelivered), nil
+}
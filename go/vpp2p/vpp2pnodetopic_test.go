@@ -0,0 +1,165 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sync"
+	"testing"
+)
+
+func TestPublishSubscribe(t *testing.T) {
+	const nbNodes = 16
+	const nbSubscribers = 4
+	const topic = "chat"
+	var nodes []*Node
+	var err error
+	var received []string
+	var receivedAccess sync.Mutex
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	message := []byte("hello")
+	nbCopy := int(nodes[0].ringPtr.Info.Config.NbCopy)
+	handler := func(topicF string, messageF []byte) {
+		if topicF != topic || bytes.Compare(messageF, message) != 0 {
+			t.Error("bad message received", topicF, string(messageF))
+		}
+		receivedAccess.Lock()
+		received = append(received, string(messageF))
+		receivedAccess.Unlock()
+	}
+
+	for i := 0; i < nbSubscribers; i++ {
+		n, _, err := nodes[i].Subscribe(topic, handler)
+		if err != nil {
+			t.Fatal("unable to subscribe", err)
+		}
+		if n != nbCopy {
+			t.Errorf("bad number of copies %d!=%d", n, nbCopy)
+		}
+	}
+	// subscribing twice only renews the subscription
+	_, _, err = nodes[0].Subscribe(topic, handler)
+	if err != nil {
+		t.Error("unable to renew subscription", err)
+	}
+
+	n, _, err := nodes[nbNodes-1].Publish(topic, message)
+	if err != nil {
+		t.Fatal("unable to publish", err)
+	}
+	if n != nbSubscribers || len(received) != nbSubscribers {
+		t.Errorf("bad number of deliveries %d/%d!=%d", n, len(received), nbSubscribers)
+	}
+
+	n, _, err = nodes[0].Unsubscribe(topic)
+	if err != nil {
+		t.Error("unable to unsubscribe", err)
+	}
+	if n != nbCopy {
+		t.Errorf("bad number of removed copies %d!=%d", n, nbCopy)
+	}
+	received = nil
+	n, _, err = nodes[nbNodes/2].Publish(topic, message)
+	if err != nil {
+		t.Error("unable to publish", err)
+	}
+	if n != nbSubscribers-1 || len(received) != nbSubscribers-1 {
+		t.Errorf("bad number of deliveries after unsubscribe %d/%d!=%d", n, len(received), nbSubscribers-1)
+	}
+
+	n, _, err = nodes[0].Publish("nobody listens", message)
+	if err != nil || n != 0 {
+		t.Error("message delivered on a topic with no subscribers", err)
+	}
+	_, _, err = nodes[0].Subscribe("", handler)
+	if err == nil {
+		t.Error("subscribed to an empty topic")
+	}
+	_, _, err = nodes[0].Subscribe(topic, nil)
+	if err == nil {
+		t.Error("subscribed without a handler")
+	}
+}
+
+func TestSubscribeChecks(t *testing.T) {
+	const nbNodes = 8
+	const topic = "chat"
+	var nodes []*Node
+	var err error
+
+	nodes, err = setupLinkedNodes(t, nbNodes)
+	if err != nil {
+		t.Fatal("unable to setup nodes", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+		node.Start()
+	}
+
+	path, err := nodes[0].lookupOwner(vpp2pdat.TopicToKey(topic))
+	if err != nil {
+		t.Fatal("unable to find topic owner", err)
+	}
+	owner := 0
+	for i, node := range nodes {
+		if bytes.Equal(node.Status.Info.NodeID, path[len(path)-1].NodeID) {
+			owner = i
+		}
+	}
+	replica := nodes[(owner+1)%nbNodes]
+	other := nodes[(owner+nbNodes/2)%nbNodes]
+	victim := nodes[(owner+nbNodes/2+1)%nbNodes]
+
+	_, err = other.remoteSubscribe(nodes[owner].Status.Info, topic, victim.Status.Info, false)
+	if err == nil {
+		t.Error("node subscribed another node")
+	}
+	_, err = other.remoteUnsubscribe(nodes[owner].Status.Info, topic, victim.Status.Info, false)
+	if err == nil {
+		t.Error("node unsubscribed another node")
+	}
+	_, err = other.remoteSubscribe(replica.Status.Info, topic, other.Status.Info, true)
+	if err == nil {
+		t.Error("replica pushed by a node which does not hold the topic")
+	}
+	_, err = other.remoteUnsubscribe(replica.Status.Info, topic, victim.Status.Info, true)
+	if err == nil {
+		t.Error("replica removed by a node which does not hold the topic")
+	}
+
+	n, err := nodes[owner].remoteSubscribe(replica.Status.Info, topic, other.Status.Info, true)
+	if err != nil || n != 1 {
+		t.Error("replica refused from the node holding the topic", err)
+	}
+	n, err = other.remoteSubscribe(nodes[owner].Status.Info, topic, other.Status.Info, false)
+	if err != nil || n != int(nodes[owner].ringPtr.Info.Config.NbCopy) {
+		t.Error("unable to subscribe", n, err)
+	}
+}
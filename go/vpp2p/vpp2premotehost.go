@@ -0,0 +1,722 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"fmt"
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/ufoot/vapor/go/vpcommonapi"
+	"github.com/ufoot/vapor/go/vperror"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RemoteHostMaxIdle is the maximum number of idle connections
+	// kept open for a given remote host.
+	RemoteHostMaxIdle = 4
+	// RemoteHostIncompatibleDelay is how long a remote host found
+	// incompatible is refused, before the handshake is tried again,
+	// in case it has been upgraded meanwhile.
+	RemoteHostIncompatibleDelay = 10 * time.Minute
+)
+
+// RemoteHost is a proxy on a host which is not in this process.
+// It implements VpP2pApi by forwarding all calls to the host
+// over the network, using Thrift. Connections are pooled, so that
+// several calls can be made concurrently, and re-used. Before the
+// first call, hosts agree on a protocol version and capabilities,
+// and incompatible hosts are refused with a vpp2pdat.IncompatibleError.
+type RemoteHost struct {
+	// HostURL is the URL of the remote host.
+	HostURL string
+
+	dialer          Dialer
+	timeout         time.Duration
+	hostInfoCatalog *HostInfoCatalog
+	access          sync.Mutex
+	idle            []*remoteConn
+
+	handshakeAccess   sync.Mutex
+	clock             Clock
+	local             *vpp2papi.HandshakeInfo
+	protocol          int32
+	capabilities      []string
+	incompatible      error
+	incompatibleUntil time.Time
+}
+
+type remoteConn struct {
+	transport thrift.TTransport
+	client    *vpp2papi.VpP2pApiClient
+}
+
+// RemoteHostPool keeps track of remote hosts, so that connections
+// to a given host are shared. Each host is contacted with the dialer
+// matching the scheme of its URL, as returned by DialerForURL.
+type RemoteHostPool struct {
+	access          sync.Mutex
+	hosts           map[string]*RemoteHost
+	hostInfoCatalog *HostInfoCatalog
+}
+
+// Dialer opens a Thrift transport to the host with the given URL.
+// The timeout applies to the connection, when the transport
+// supports it.
+type Dialer func(hostURL string, timeout time.Duration) (thrift.TTransport, error)
+
+// DialTCP opens a raw Thrift socket on the host:port given by hostURL.
+// This is the default, and the most efficient way to contact a host.
+func DialTCP(hostURL string, timeout time.Duration) (thrift.TTransport, error) {
+	addr, err := vpp2pdat.HostURLToAddr(hostURL)
+	if err != nil {
+		return nil, err
+	}
+	socket, err := thrift.NewTSocketTimeout(addr, timeout)
+	if err != nil {
+		return nil, vperror.Chainf(err, "unable to create socket for %s", addr)
+	}
+	transport := thrift.NewTTransportFactory().GetTransport(socket)
+	err = transport.Open()
+	if err != nil {
+		return nil, vperror.Chainf(err, "unable to connect to %s", addr)
+	}
+
+	return transport, nil
+}
+
+// DialHTTP returns a Thrift HTTP client which posts calls to hostURL
+// itself. This works through HTTP proxies, which are configured the
+// usual way with the HTTP_PROXY environment variable. The timeout
+// applies to each call, from connection to the end of the reply.
+func DialHTTP(hostURL string, timeout time.Duration) (thrift.TTransport, error) {
+	parsedURL, err := url.Parse(hostURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, fmt.Errorf("bad scheme \"%s\" in URL \"%s\", should be http or https", parsedURL.Scheme, hostURL)
+	}
+	if parsedURL.Host == "" {
+		return nil, fmt.Errorf("no host in URL \"%s\"", hostURL)
+	}
+
+	return newHTTPTransport(hostURL, timeout), nil
+}
+
+// DialerForURL returns the dialer matching the scheme of hostURL,
+// DialHTTP for http and https, DialTCP for anything else, typically tcp.
+func DialerForURL(hostURL string) Dialer {
+	parsedURL, err := url.Parse(hostURL)
+	if err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") {
+		return DialHTTP
+	}
+
+	return DialTCP
+}
+
+// NewRemoteHost creates a new remote host proxy, using the dialer
+// matching the scheme of hostURL, as returned by DialerForURL.
+// It does not connect to the host, this is done on the first call.
+// If hostInfoCatalog is not nil, the hosts refs returned by the remote
+// host are recorded in it.
+func NewRemoteHost(hostURL string, hostInfoCatalog *HostInfoCatalog) (*RemoteHost, error) {
+	return NewRemoteHostDialer(hostURL, hostInfoCatalog, nil)
+}
+
+// NewRemoteHostDialer creates a new remote host proxy, which uses
+// dialer to connect to the host. A nil dialer means the one matching
+// the scheme of hostURL, as returned by DialerForURL.
+func NewRemoteHostDialer(hostURL string, hostInfoCatalog *HostInfoCatalog, dialer Dialer) (*RemoteHost, error) {
+	var ret RemoteHost
+
+	if dialer == nil {
+		dialer = DialerForURL(hostURL)
+	}
+	// check the URL now, rather than on the first call
+	_, err := vpp2pdat.HostURLToAddr(hostURL)
+	if err != nil {
+		return nil, err
+	}
+	ret.HostURL = hostURL
+	ret.dialer = dialer
+	ret.timeout = time.Second * time.Duration(vpp2pdat.DefaultCallTimeout)
+	ret.hostInfoCatalog = hostInfoCatalog
+	ret.local = vpp2pdat.DefaultHandshakeInfo()
+	ret.clock = SystemClock()
+
+	return &ret, nil
+}
+
+// NewHTTPRemoteHost creates a new remote host proxy which contacts the
+// host described by hostInfo over HTTP, posting calls to its URL.
+func NewHTTPRemoteHost(hostInfo *vpp2papi.HostInfo, hostInfoCatalog *HostInfoCatalog) (*RemoteHost, error) {
+	if hostInfo == nil {
+		return nil, fmt.Errorf("no host info")
+	}
+
+	return NewRemoteHostDialer(hostInfo.HostURL, hostInfoCatalog, DialHTTP)
+}
+
+func (rh *RemoteHost) acquire() (*remoteConn, error) {
+	rh.access.Lock()
+	if len(rh.idle) > 0 {
+		ret := rh.idle[len(rh.idle)-1]
+		rh.idle = rh.idle[:len(rh.idle)-1]
+		rh.access.Unlock()
+		return ret, nil
+	}
+	rh.access.Unlock()
+
+	transport, err := rh.dialer(rh.HostURL, rh.timeout)
+	if err != nil {
+		return nil, err
+	}
+	client := vpp2papi.NewVpP2pApiClientFactory(transport, thrift.NewTBinaryProtocolFactoryDefault())
+
+	return &remoteConn{transport: transport, client: client}, nil
+}
+
+// release puts back a connection in the pool, a connection which
+// has been used by a failed call is closed, since the server
+// closes it too, and there's no telling in which state it is.
+func (rh *RemoteHost) release(conn *remoteConn, err error) {
+	if err == nil {
+		rh.access.Lock()
+		if len(rh.idle) < RemoteHostMaxIdle {
+			rh.idle = append(rh.idle, conn)
+			conn = nil
+		}
+		rh.access.Unlock()
+	}
+	if conn != nil {
+		conn.transport.Close()
+	}
+}
+
+// SetHandshakeInfo sets what this program claims to speak when
+// contacting the remote host, the default is vpp2pdat.DefaultHandshakeInfo.
+// The handshake is done again on next call.
+// It's thread-safe.
+func (rh *RemoteHost) SetHandshakeInfo(info *vpp2papi.HandshakeInfo) {
+	defer rh.handshakeAccess.Unlock()
+	rh.handshakeAccess.Lock()
+
+	if info == nil {
+		info = vpp2pdat.DefaultHandshakeInfo()
+	}
+	rh.local = info
+	rh.protocol = 0
+	rh.capabilities = nil
+	rh.incompatible = nil
+}
+
+// SetClock sets the clock used to decide when to try the handshake
+// again with a remote host found incompatible.
+// It's thread-safe.
+func (rh *RemoteHost) SetClock(clock Clock) {
+	defer rh.handshakeAccess.Unlock()
+	rh.handshakeAccess.Lock()
+
+	rh.clock = clock
+}
+
+// Protocol returns the protocol version agreed on with the remote
+// host, 0 if there has been no successful handshake yet.
+// It's thread-safe.
+func (rh *RemoteHost) Protocol() int32 {
+	defer rh.handshakeAccess.Unlock()
+	rh.handshakeAccess.Lock()
+
+	return rh.protocol
+}
+
+// Capabilities returns the capabilities both this program and the
+// remote host have, nil if there has been no successful handshake yet.
+// It's thread-safe.
+func (rh *RemoteHost) Capabilities() []string {
+	defer rh.handshakeAccess.Unlock()
+	rh.handshakeAccess.Lock()
+
+	return rh.capabilities
+}
+
+// HasCapability returns true if both this program and the remote
+// host have the given capability.
+// It's thread-safe.
+func (rh *RemoteHost) HasCapability(capability string) bool {
+	for _, v := range rh.Capabilities() {
+		if v == capability {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handshake agrees on a protocol version with the remote host, if not
+// done yet. Network errors, and other failures, are reported, and the
+// handshake is tried again on next call, but once the host has been
+// found incompatible, it is refused for RemoteHostIncompatibleDelay.
+func (rh *RemoteHost) handshake() error {
+	var response *vpp2papi.HandshakeResponse
+
+	defer rh.handshakeAccess.Unlock()
+	rh.handshakeAccess.Lock()
+
+	if rh.protocol != 0 {
+		return nil
+	}
+	if rh.incompatible != nil {
+		if rh.clock.Now().Before(rh.incompatibleUntil) {
+			return rh.incompatible
+		}
+		rh.incompatible = nil
+	}
+
+	err := rh.rawCall(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		response, errF = client.Handshake(&vpp2papi.HandshakeRequest{Info: rh.local})
+		return errF
+	})
+	if err != nil {
+		if vpp2pdat.IsIncompatible(err) {
+			// in-process hosts return the error as is
+			return rh.setIncompatible(err.(*vpp2pdat.IncompatibleError))
+		}
+		appErr, ok := err.(thrift.TApplicationException)
+		if ok && appErr.TypeId() == thrift.UNKNOWN_METHOD {
+			// hosts which predate the handshake can't be trusted
+			// to understand what we send them
+			return rh.setIncompatible(&vpp2pdat.IncompatibleError{Reason: "peer does not support handshake", Local: rh.local, Remote: nil})
+		}
+		if ok && appErr.TypeId() == thrift.INTERNAL_ERROR && strings.Contains(appErr.Error(), vpp2pdat.IncompatiblePrefix) {
+			// the host did answer, and refused to talk with us,
+			// other errors might be transient, and are not cached
+			return rh.setIncompatible(&vpp2pdat.IncompatibleError{Reason: fmt.Sprintf("peer refused handshake: %s", appErr.Error()), Local: rh.local, Remote: nil})
+		}
+		return err
+	}
+	if response == nil {
+		return fmt.Errorf("no handshake response from %s", rh.HostURL)
+	}
+	protocol, capabilities, err := vpp2pdat.Negotiate(rh.local, response.Info)
+	if err != nil {
+		return rh.setIncompatible(err.(*vpp2pdat.IncompatibleError))
+	}
+	if response.Protocol != protocol {
+		return rh.setIncompatible(&vpp2pdat.IncompatibleError{Reason: fmt.Sprintf("peer agreed on protocol %d instead of %d", response.Protocol, protocol), Local: rh.local, Remote: response.Info})
+	}
+	rh.protocol = protocol
+	rh.capabilities = capabilities
+
+	return nil
+}
+
+// setIncompatible records that the remote host is incompatible, until
+// RemoteHostIncompatibleDelay has elapsed, and returns err.
+// Must be called with the handshake lock held.
+func (rh *RemoteHost) setIncompatible(err *vpp2pdat.IncompatibleError) error {
+	rh.incompatible = err
+	rh.incompatibleUntil = rh.clock.Now().Add(RemoteHostIncompatibleDelay)
+
+	return err
+}
+
+func (rh *RemoteHost) call(f func(client *vpp2papi.VpP2pApiClient) error) error {
+	err := rh.handshake()
+	if err != nil {
+		return err
+	}
+
+	return rh.rawCall(f)
+}
+
+func (rh *RemoteHost) rawCall(f func(client *vpp2papi.VpP2pApiClient) error) error {
+	conn, err := rh.acquire()
+	if err != nil {
+		return err
+	}
+	err = f(conn.client)
+	rh.release(conn, err)
+
+	return err
+}
+
+// learn records the hosts refs returned by the remote host, so that
+// nodes it refers to can be contacted later. The ref describing the
+// remote host itself, the one with its URL, is a direct contact.
+// Invalid refs are skipped by the catalog, this does not make the call fail.
+func (rh *RemoteHost) learn(hostsRefs map[string]*vpp2papi.HostInfo) {
+	if rh.hostInfoCatalog == nil || hostsRefs == nil {
+		return
+	}
+	rh.hostInfoCatalog.UpdateHostsRefs(hostsRefs)
+	for _, v := range hostsRefs {
+		if v != nil && v.HostURL == rh.HostURL {
+			rh.hostInfoCatalog.RegisterHost(v)
+		}
+	}
+}
+
+// Close closes all idle connections.
+func (rh *RemoteHost) Close() {
+	rh.access.Lock()
+	idle := rh.idle
+	rh.idle = nil
+	rh.access.Unlock()
+
+	for _, conn := range idle {
+		conn.transport.Close()
+	}
+}
+
+// Ping is a simple ping function
+func (rh *RemoteHost) Ping() error {
+	return rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		return client.Ping()
+	})
+}
+
+// Uptime returns the remote host uptime.
+func (rh *RemoteHost) Uptime() (int64, error) {
+	var ret int64
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Uptime()
+		return errF
+	})
+	return ret, err
+}
+
+// GetPackage returns the remote host package information.
+func (rh *RemoteHost) GetPackage() (*vpcommonapi.Package, error) {
+	var ret *vpcommonapi.Package
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.GetPackage()
+		return errF
+	})
+	return ret, err
+}
+
+// GetVersion returns the remote host version information.
+func (rh *RemoteHost) GetVersion() (*vpcommonapi.Version, error) {
+	var ret *vpcommonapi.Version
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.GetVersion()
+		return errF
+	})
+	return ret, err
+}
+
+// Status returns the remote host status.
+func (rh *RemoteHost) Status() (*vpp2papi.HostStatus, error) {
+	var ret *vpp2papi.HostStatus
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Status()
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Challenge forwards a Challenge request to the remote host.
+func (rh *RemoteHost) Challenge(request *vpp2papi.ChallengeRequest) (*vpp2papi.ChallengeResponse, error) {
+	var ret *vpp2papi.ChallengeResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Challenge(request)
+		return errF
+	})
+	return ret, err
+}
+
+// Lookup forwards a Lookup request to the remote host.
+func (rh *RemoteHost) Lookup(request *vpp2papi.LookupRequest) (*vpp2papi.LookupResponse, error) {
+	var ret *vpp2papi.LookupResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Lookup(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// LookupMany forwards a LookupMany request to the remote host.
+func (rh *RemoteHost) LookupMany(request *vpp2papi.LookupManyRequest) (*vpp2papi.LookupManyResponse, error) {
+	var ret *vpp2papi.LookupManyResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.LookupMany(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// GetSuccessors forwards a GetSuccessors request to the remote host.
+func (rh *RemoteHost) GetSuccessors(request *vpp2papi.GetSuccessorsRequest) (*vpp2papi.GetSuccessorsResponse, error) {
+	var ret *vpp2papi.GetSuccessorsResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.GetSuccessors(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// GetPredecessor forwards a GetPredecessor request to the remote host.
+func (rh *RemoteHost) GetPredecessor(request *vpp2papi.GetPredecessorRequest) (*vpp2papi.GetPredecessorResponse, error) {
+	var ret *vpp2papi.GetPredecessorResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.GetPredecessor(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Sync forwards a Sync request to the remote host.
+func (rh *RemoteHost) Sync(request *vpp2papi.SyncRequest) (*vpp2papi.SyncResponse, error) {
+	var ret *vpp2papi.SyncResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Sync(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Put forwards a Put request to the remote host.
+func (rh *RemoteHost) Put(request *vpp2papi.PutRequest) (*vpp2papi.PutResponse, error) {
+	var ret *vpp2papi.PutResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Put(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Get forwards a Get request to the remote host.
+func (rh *RemoteHost) Get(request *vpp2papi.GetRequest) (*vpp2papi.GetResponse, error) {
+	var ret *vpp2papi.GetResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Get(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Delete forwards a Delete request to the remote host.
+func (rh *RemoteHost) Delete(request *vpp2papi.DeleteRequest) (*vpp2papi.DeleteResponse, error) {
+	var ret *vpp2papi.DeleteResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Delete(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Merkle forwards a Merkle request to the remote host.
+func (rh *RemoteHost) Merkle(request *vpp2papi.MerkleRequest) (*vpp2papi.MerkleResponse, error) {
+	var ret *vpp2papi.MerkleResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Merkle(request)
+		return errF
+	})
+	return ret, err
+}
+
+// Leave forwards a Leave request to the remote host.
+func (rh *RemoteHost) Leave(request *vpp2papi.LeaveRequest) (*vpp2papi.LeaveResponse, error) {
+	var ret *vpp2papi.LeaveResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Leave(request)
+		return errF
+	})
+	return ret, err
+}
+
+// AnnounceRing forwards a AnnounceRing request to the remote host.
+func (rh *RemoteHost) AnnounceRing(request *vpp2papi.AnnounceRingRequest) (*vpp2papi.AnnounceRingResponse, error) {
+	var ret *vpp2papi.AnnounceRingResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.AnnounceRing(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// ListRings forwards a ListRings request to the remote host.
+func (rh *RemoteHost) ListRings(request *vpp2papi.ListRingsRequest) (*vpp2papi.ListRingsResponse, error) {
+	var ret *vpp2papi.ListRingsResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.ListRings(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Subscribe forwards a Subscribe request to the remote host.
+func (rh *RemoteHost) Subscribe(request *vpp2papi.SubscribeRequest) (*vpp2papi.SubscribeResponse, error) {
+	var ret *vpp2papi.SubscribeResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Subscribe(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Unsubscribe forwards a Unsubscribe request to the remote host.
+func (rh *RemoteHost) Unsubscribe(request *vpp2papi.UnsubscribeRequest) (*vpp2papi.UnsubscribeResponse, error) {
+	var ret *vpp2papi.UnsubscribeResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Unsubscribe(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// Publish forwards a Publish request to the remote host.
+func (rh *RemoteHost) Publish(request *vpp2papi.PublishRequest) (*vpp2papi.PublishResponse, error) {
+	var ret *vpp2papi.PublishResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Publish(request)
+		return errF
+	})
+	if err == nil && ret != nil {
+		rh.learn(ret.HostsRefs)
+	}
+	return ret, err
+}
+
+// SecureMessage forwards a SecureMessage request to the remote host.
+func (rh *RemoteHost) SecureMessage(request *vpp2papi.SecureMessageRequest) (*vpp2papi.SecureMessageResponse, error) {
+	var ret *vpp2papi.SecureMessageResponse
+	err := rh.call(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.SecureMessage(request)
+		return errF
+	})
+	return ret, err
+}
+
+// Handshake forwards a Handshake request to the remote host. It is
+// done automatically before the first call, there's usually no need
+// to call it explicitly.
+func (rh *RemoteHost) Handshake(request *vpp2papi.HandshakeRequest) (*vpp2papi.HandshakeResponse, error) {
+	var ret *vpp2papi.HandshakeResponse
+	err := rh.rawCall(func(client *vpp2papi.VpP2pApiClient) error {
+		var errF error
+		ret, errF = client.Handshake(request)
+		return errF
+	})
+	return ret, err
+}
+
+// NewRemoteHostPool creates a new pool of remote hosts.
+// The hosts refs returned by remote hosts are recorded in hostInfoCatalog.
+func NewRemoteHostPool(hostInfoCatalog *HostInfoCatalog) *RemoteHostPool {
+	return &RemoteHostPool{hosts: make(map[string]*RemoteHost), hostInfoCatalog: hostInfoCatalog}
+}
+
+// Connect returns the remote host for the given URL, creating it if needed.
+// It's thread-safe.
+func (p *RemoteHostPool) Connect(hostURL string) (*RemoteHost, error) {
+	defer p.access.Unlock()
+	p.access.Lock()
+
+	ret := p.hosts[hostURL]
+	if ret != nil {
+		return ret, nil
+	}
+	ret, err := NewRemoteHost(hostURL, p.hostInfoCatalog)
+	if err != nil {
+		return nil, err
+	}
+	p.hosts[hostURL] = ret
+
+	return ret, nil
+}
+
+// Close closes all the connections of all the remote hosts.
+// It's thread-safe.
+func (p *RemoteHostPool) Close() {
+	defer p.access.Unlock()
+	p.access.Lock()
+
+	for _, v := range p.hosts {
+		v.Close()
+	}
+}
@@ -55,10 +55,13 @@ type Ring struct {
 	Info   vpp2papi.RingInfo
 	secret RingSecret
 
+	env               *Env
 	walker            vpbruijn.BruijnWalker
 	localNodes        []Node
 	callTimeout       time.Duration
+	syncDelay         time.Duration
 	disconnectTimeout time.Duration
+	dataLifetime      time.Duration
 }
 
 type ringStuffAppender struct {
@@ -80,13 +83,17 @@ func (rsa *ringStuffAppender) Transform(ringID []byte) []byte {
 	return vpp2pdat.RingInfoSigBytes(ri)
 }
 
-// NewRing creates a new ring from static data.
-func NewRing(host *Host, ringTitle, ringDescription string, appID []byte, config *vpp2papi.RingConfig, fc vpid.FilterChecker, passwordHash []byte) (*Ring, error) {
+// NewRing creates a new ring from static data. The host must
+// live within env.
+func NewRing(env *Env, host *Host, ringTitle, ringDescription string, appID []byte, config *vpp2papi.RingConfig, fc vpid.FilterChecker, passwordHash []byte) (*Ring, error) {
 	var ret Ring
 	var err error
 	var intRingID *big.Int
 	var sig []byte
 
+	if host.env != env {
+		return nil, fmt.Errorf("host does not live within env")
+	}
 	if config == nil {
 		config = vpp2pdat.DefaultRingConfig()
 	}
@@ -131,16 +138,20 @@ func NewRing(host *Host, ringTitle, ringDescription string, appID []byte, config
 	if err != nil {
 		return nil, err
 	}
+	ret.env = env
 	ret.localNodes = make([]Node, 0)
 	ret.callTimeout = time.Second * time.Duration(ret.Info.Config.CallTimeout)
+	ret.syncDelay = time.Second * time.Duration(ret.Info.Config.SyncDelay)
 	ret.disconnectTimeout = time.Second * time.Duration(ret.Info.Config.DisconnectTimeout)
+	ret.dataLifetime = time.Second * time.Duration(ret.Info.Config.DataLifetime)
 
 	return &ret, nil
 }
 
 // RingFromInfo creates a new Ring object from its info static data, typically
 // retrieved from the network, on an application directory.
-func RingFromInfo(ringInfo *vpp2papi.RingInfo, passwordHash []byte) (*Ring, error) {
+// The ring is created within env.
+func RingFromInfo(env *Env, ringInfo *vpp2papi.RingInfo, passwordHash []byte) (*Ring, error) {
 	var ret Ring
 	var err error
 
@@ -168,32 +179,37 @@ func RingFromInfo(ringInfo *vpp2papi.RingInfo, passwordHash []byte) (*Ring, erro
 	if err != nil {
 		return nil, err
 	}
+	ret.env = env
 	ret.localNodes = make([]Node, 0)
 	ret.callTimeout = time.Second * time.Duration(ret.Info.Config.CallTimeout)
+	ret.syncDelay = time.Second * time.Duration(ret.Info.Config.SyncDelay)
 	ret.disconnectTimeout = time.Second * time.Duration(ret.Info.Config.DisconnectTimeout)
+	ret.dataLifetime = time.Second * time.Duration(ret.Info.Config.DataLifetime)
 
 	return &ret, nil
 }
 
-// NewRing0 creates a new instance of the default directory ring.
-func NewRing0() (*Ring, error) {
+// NewRing0 creates a new instance of the default directory ring,
+// within env.
+func NewRing0(env *Env) (*Ring, error) {
 	var host0 *Host
 	var ring0 *Ring
 	var err error
 
-	host0, err = NewHost(vpp2pdat.Host0Title, vpp2pdat.Host0URL, true, GlobalHostInfoCatalog())
+	host0, err = NewHost(env, vpp2pdat.Host0Title, vpp2pdat.Host0URL, true)
 	if err != nil {
 		return nil, err
 	}
 	appID := vpapp.CalcID(vpapp.DefaultPackage(), vpapp.DefaultVersion())
 	config := vpp2pdat.DefaultRingConfig()
-	ring0, err = NewRing(host0, vpp2pdat.Ring0Title, vpp2pdat.Ring0Description, appID, config, nil, nil)
+	ring0, err = NewRing(env, host0, vpp2pdat.Ring0Title, vpp2pdat.Ring0Description, appID, config, nil, nil)
 
 	return ring0, nil
 }
 
-// BuiltinRing0 creates an instance of the default directory ring.
-func BuiltinRing0() (*Ring, error) {
+// BuiltinRing0 creates an instance of the default directory ring,
+// within env.
+func BuiltinRing0(env *Env) (*Ring, error) {
 	var info vpp2papi.RingInfo
 	var err error
 
@@ -218,7 +234,12 @@ func BuiltinRing0() (*Ring, error) {
 		return nil, err
 	}
 
-	return RingFromInfo(&info, nil)
+	return RingFromInfo(env, &info, nil)
+}
+
+// Env returns the environment the ring lives in.
+func (ring *Ring) Env() *Env {
+	return ring.env
 }
 
 // IsSigned returns true if the ring has been signed by corresponding host.
@@ -30,12 +30,13 @@ func TestNewRing(t *testing.T) {
 	var ring *Ring
 	var err error
 	var zeroes, zeroes2 int
+	var env = NewEnv()
 
-	host, err = NewHost(testTitle, testURL, true, GlobalHostInfoCatalog())
+	host, err = NewHost(env, testTitle, testURL, true)
 	if err != nil {
 		t.Error("unable to create host with a valid pubKey", err)
 	}
-	ring, err = NewRing(host, testTitle, testDescription, testID, nil, nil, nil)
+	ring, err = NewRing(env, host, testTitle, testDescription, testID, nil, nil, nil)
 	if err != nil {
 		t.Error("unable to create ring with a valid pubKey", err)
 	}
@@ -60,11 +61,11 @@ func TestNewRing(t *testing.T) {
 		t.Error("failed to report a broken sig", err)
 	}
 
-	host, err = NewHost(testTitle, testURL, false, GlobalHostInfoCatalog())
+	host, err = NewHost(env, testTitle, testURL, false)
 	if err != nil {
 		t.Error("unable to create host with a valid pubKey", err)
 	}
-	ring, err = NewRing(host, testTitle, testDescription, testID, nil, nil, nil)
+	ring, err = NewRing(env, host, testTitle, testDescription, testID, nil, nil, nil)
 	if err != nil {
 		t.Error("unable to create ring with a valid pubKey", err)
 	}
@@ -80,7 +81,7 @@ func TestNewRing(t *testing.T) {
 }
 
 func TestBuiltinRing0(t *testing.T) {
-	ring0, err := BuiltinRing0()
+	ring0, err := BuiltinRing0(NewEnv())
 	if err != nil {
 		t.Error("unable to create builtin ring0", err)
 	}
@@ -0,0 +1,136 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RingDirectoryMaxEntries is the maximum number of rings a node
+	// keeps in its ring directory.
+	RingDirectoryMaxEntries = 10000
+)
+
+// ringDirectoryEntry is a ring announced in a directory, along with
+// its expiration date.
+type ringDirectoryEntry struct {
+	info    *vpp2papi.RingInfo
+	expires time.Time
+}
+
+// ringDirectory is the local ring directory of a node.
+type ringDirectory struct {
+	access  sync.RWMutex
+	entries map[[vpp2pdat.RingIDBufNbBytes]byte]*ringDirectoryEntry
+	clock   Clock
+}
+
+// ringInfoList sorts rings by title, then by ID.
+type ringInfoList []*vpp2papi.RingInfo
+
+func (l ringInfoList) Len() int {
+	return len(l)
+}
+
+func (l ringInfoList) Less(i, j int) bool {
+	if l[i].RingTitle != l[j].RingTitle {
+		return l[i].RingTitle < l[j].RingTitle
+	}
+	return bytes.Compare(l[i].RingID, l[j].RingID) < 0
+}
+
+func (l ringInfoList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func newRingDirectory(clock Clock) *ringDirectory {
+	return &ringDirectory{entries: make(map[[vpp2pdat.RingIDBufNbBytes]byte]*ringDirectoryEntry), clock: clock}
+}
+
+// announce stores a ring, or refreshes it if it already exists.
+// It's thread-safe.
+func (rd *ringDirectory) announce(info *vpp2papi.RingInfo, lifetime time.Duration) error {
+	ringIDBuf := vpp2pdat.RingIDToBuf(info.RingID)
+
+	defer rd.access.Unlock()
+	rd.access.Lock()
+
+	if _, ok := rd.entries[ringIDBuf]; !ok && len(rd.entries) >= RingDirectoryMaxEntries {
+		return fmt.Errorf("ring directory is full")
+	}
+	rd.entries[ringIDBuf] = &ringDirectoryEntry{info: info, expires: rd.clock.Now().Add(lifetime)}
+
+	return nil
+}
+
+// list returns the rings matching appID, if not empty, and whose title
+// starts with titlePrefix. Expired entries are ignored. Rings are sorted
+// by title, then by ID.
+// It's thread-safe.
+func (rd *ringDirectory) list(appID []byte, titlePrefix string) []*vpp2papi.RingInfo {
+	now := rd.clock.Now()
+
+	defer rd.access.RUnlock()
+	rd.access.RLock()
+
+	ret := make([]*vpp2papi.RingInfo, 0)
+	for _, v := range rd.entries {
+		if now.After(v.expires) {
+			continue
+		}
+		if len(appID) > 0 && !bytes.Equal(appID, v.info.AppID) {
+			continue
+		}
+		if !strings.HasPrefix(v.info.RingTitle, titlePrefix) {
+			continue
+		}
+		ret = append(ret, v.info)
+	}
+	sort.Sort(ringInfoList(ret))
+
+	return ret
+}
+
+// purge removes all expired entries, returns the number of removed entries.
+// It's thread-safe.
+func (rd *ringDirectory) purge() int {
+	ret := 0
+	now := rd.clock.Now()
+
+	defer rd.access.Unlock()
+	rd.access.Lock()
+
+	for k, v := range rd.entries {
+		if now.After(v.expires) {
+			delete(rd.entries, k)
+			ret++
+		}
+	}
+
+	return ret
+}
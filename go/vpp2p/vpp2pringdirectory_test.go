@@ -0,0 +1,71 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+	"time"
+)
+
+func TestRingDirectory(t *testing.T) {
+	rd := newRingDirectory(SystemClock())
+	appID := vpsum.Checksum256([]byte("app"))
+	info1 := &vpp2papi.RingInfo{RingID: vpsum.Checksum256([]byte("ring1")), AppID: appID, RingTitle: "Liquid War 1"}
+	info2 := &vpp2papi.RingInfo{RingID: vpsum.Checksum256([]byte("ring2")), AppID: appID, RingTitle: "Liquid War 2"}
+	info3 := &vpp2papi.RingInfo{RingID: vpsum.Checksum256([]byte("ring3")), AppID: testID, RingTitle: "Other"}
+
+	for _, info := range []*vpp2papi.RingInfo{info2, info1, info3} {
+		if err := rd.announce(info, time.Hour); err != nil {
+			t.Error("unable to announce ring", err)
+		}
+	}
+	rings := rd.list(nil, "")
+	if len(rings) != 3 || rings[0] != info1 || rings[1] != info2 || rings[2] != info3 {
+		t.Error("bad list of all rings", rings)
+	}
+	rings = rd.list(appID, "")
+	if len(rings) != 2 {
+		t.Error("bad list by app ID", rings)
+	}
+	rings = rd.list(nil, "Liquid War 2")
+	if len(rings) != 1 || rings[0] != info2 {
+		t.Error("bad list by title prefix", rings)
+	}
+	rings = rd.list(testID, "Liquid")
+	if len(rings) != 0 {
+		t.Error("bad list by app ID and title prefix", rings)
+	}
+
+	rd.announce(info3, -time.Second)
+	rings = rd.list(nil, "")
+	if len(rings) != 2 {
+		t.Error("expired ring listed", rings)
+	}
+	if rd.purge() != 1 {
+		t.Error("bad purge")
+	}
+	// refreshing an existing ring does not need room
+	rd.announce(info1, time.Hour)
+	if len(rd.list(nil, "")) != 2 {
+		t.Error("refresh should not create a new entry")
+	}
+}
@@ -0,0 +1,222 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpcrypto"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sync"
+	"time"
+)
+
+const (
+	// SecureSessionLifetime is how long a secure session is kept
+	// after it has last been used.
+	SecureSessionLifetime = time.Hour
+	// MaxSecureSessions is the maximum number of incoming secure
+	// sessions a host keeps track of.
+	MaxSecureSessions = 10000
+)
+
+// outgoingSession is a session used to send secure messages to a host.
+// The key is sent along with the messages, encrypted with the public
+// key of the target host, until the target host has acknowledged it.
+type outgoingSession struct {
+	id           []byte
+	key          []byte
+	encryptedKey []byte
+	confirmed    bool
+	expires      time.Time
+}
+
+// incomingSession is a session used by a host to send us secure messages.
+type incomingSession struct {
+	key              []byte
+	sourceHostPubKey []byte
+	expires          time.Time
+}
+
+// secureStore keeps the secure sessions of a host, outgoing ones
+// indexed by target host, incoming ones indexed by session ID.
+type secureStore struct {
+	access   sync.Mutex
+	outgoing map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*outgoingSession
+	incoming map[[vpp2pdat.SessionIDNbBytes]byte]*incomingSession
+	clock    Clock
+}
+
+func newSecureStore(clock Clock) *secureStore {
+	return &secureStore{outgoing: make(map[[vpp2pdat.HostPubKeyBufNbBytes]byte]*outgoingSession), incoming: make(map[[vpp2pdat.SessionIDNbBytes]byte]*incomingSession), clock: clock}
+}
+
+// randomBytes returns n bytes from the system secure random source,
+// vprand is fine for IDs, but not for keys.
+func randomBytes(n int) ([]byte, error) {
+	ret := make([]byte, n)
+	_, err := rand.Read(ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// newOutgoingSession creates a session to send messages to the host
+// with the given public key. This is slow, since the session key is
+// encrypted using the public key, so it's done once per host.
+func newOutgoingSession(targetHostPubKey []byte) (*outgoingSession, error) {
+	var ret outgoingSession
+	var err error
+
+	targetKey, err := vpcrypto.ImportPubKey(targetHostPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("target host is unable to receive secure messages, no valid public key")
+	}
+	ret.id, err = randomBytes(vpp2pdat.SessionIDNbBytes)
+	if err != nil {
+		return nil, err
+	}
+	ret.key, err = randomBytes(vpp2pdat.SessionKeyNbBytes)
+	if err != nil {
+		return nil, err
+	}
+	ret.encryptedKey, err = targetKey.Encrypt(ret.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// openOutgoing returns a copy of the session used to send messages to the
+// host with the given public key, creating it if needed.
+// It's thread-safe.
+func (ss *secureStore) openOutgoing(targetHostPubKey []byte) (outgoingSession, error) {
+	targetBuf := vpp2pdat.HostPubKeyToBuf(targetHostPubKey)
+	now := ss.clock.Now()
+
+	ss.access.Lock()
+	session := ss.outgoing[targetBuf]
+	if session != nil && now.Before(session.expires) {
+		session.expires = now.Add(SecureSessionLifetime)
+		ret := *session
+		ss.access.Unlock()
+		return ret, nil
+	}
+	ss.access.Unlock()
+
+	// not done with the lock held, encryption takes time
+	session, err := newOutgoingSession(targetHostPubKey)
+	if err != nil {
+		return outgoingSession{}, err
+	}
+	session.expires = now.Add(SecureSessionLifetime)
+
+	defer ss.access.Unlock()
+	ss.access.Lock()
+
+	ss.purgeLocked(now)
+	existing := ss.outgoing[targetBuf]
+	if existing != nil {
+		// another message created a session in the meantime
+		return *existing, nil
+	}
+	ss.outgoing[targetBuf] = session
+
+	return *session, nil
+}
+
+// confirm records that the target host knows the session, so that
+// the session key does not need to be sent any more.
+// It's thread-safe.
+func (ss *secureStore) confirm(targetHostPubKey, sessionID []byte) {
+	defer ss.access.Unlock()
+	ss.access.Lock()
+
+	session := ss.outgoing[vpp2pdat.HostPubKeyToBuf(targetHostPubKey)]
+	if session != nil && bytes.Equal(session.id, sessionID) {
+		session.confirmed = true
+	}
+}
+
+// addIncoming records a session key sent by a source host. A session
+// ID can't be re-used with another key, or by another host.
+// It's thread-safe.
+func (ss *secureStore) addIncoming(sessionID, key, sourceHostPubKey []byte) error {
+	idBuf := vpp2pdat.SessionIDToBuf(sessionID)
+	now := ss.clock.Now()
+
+	defer ss.access.Unlock()
+	ss.access.Lock()
+
+	session := ss.incoming[idBuf]
+	if session != nil && now.Before(session.expires) {
+		if !bytes.Equal(session.key, key) || !bytes.Equal(session.sourceHostPubKey, sourceHostPubKey) {
+			return fmt.Errorf("session ID already in use")
+		}
+		session.expires = now.Add(SecureSessionLifetime)
+		return nil
+	}
+	if len(ss.incoming) >= MaxSecureSessions {
+		ss.purgeLocked(now)
+		if len(ss.incoming) >= MaxSecureSessions {
+			return fmt.Errorf("too many secure sessions")
+		}
+	}
+	ss.incoming[idBuf] = &incomingSession{key: key, sourceHostPubKey: sourceHostPubKey, expires: now.Add(SecureSessionLifetime)}
+
+	return nil
+}
+
+// incomingKey returns the key of a session, nil if the session is
+// unknown, expired, or if it's been opened by another host.
+// It's thread-safe.
+func (ss *secureStore) incomingKey(sessionID, sourceHostPubKey []byte) []byte {
+	now := ss.clock.Now()
+
+	defer ss.access.Unlock()
+	ss.access.Lock()
+
+	session := ss.incoming[vpp2pdat.SessionIDToBuf(sessionID)]
+	if session == nil || now.After(session.expires) || !bytes.Equal(session.sourceHostPubKey, sourceHostPubKey) {
+		return nil
+	}
+	session.expires = now.Add(SecureSessionLifetime)
+
+	return session.key
+}
+
+// purgeLocked removes expired sessions.
+// Must be called with the lock held.
+func (ss *secureStore) purgeLocked(now time.Time) {
+	for k, v := range ss.outgoing {
+		if now.After(v.expires) {
+			delete(ss.outgoing, k)
+		}
+	}
+	for k, v := range ss.incoming {
+		if now.After(v.expires) {
+			delete(ss.incoming, k)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpcrypto"
+	"github.com/ufoot/vapor/go/vperror"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// StateHostFile is the name of the file containing the host,
+	// within a state directory.
+	StateHostFile = "host.json"
+	// StateRingsDir is the name of the directory containing the rings,
+	// within a state directory.
+	StateRingsDir = "rings"
+	// StateNodesDir is the name of the directory containing the nodes,
+	// within a state directory.
+	StateNodesDir = "nodes"
+	// StateFileSuffix is the suffix of all state files.
+	StateFileSuffix = ".json"
+)
+
+// hostState is the persistent form of a host.
+type hostState struct {
+	Info    *vpp2papi.HostInfo
+	PrivKey []byte
+}
+
+// ringState is the persistent form of a ring, the secret is
+// encrypted using the state passphrase.
+type ringState struct {
+	Info   *vpp2papi.RingInfo
+	Secret []byte
+}
+
+// writeState writes a state file, first in a temporary file, then
+// renaming it, so that an existing state is never half-written.
+func writeState(path string, v interface{}) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return vperror.Chainf(err, "unable to marshal \"%s\"", path)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return vperror.Chainf(err, "unable to create directory for \"%s\"", path)
+	}
+	tmpPath := path + ".tmp"
+	err = ioutil.WriteFile(tmpPath, content, 0600)
+	if err != nil {
+		return vperror.Chainf(err, "unable to write \"%s\"", tmpPath)
+	}
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return vperror.Chainf(err, "unable to rename \"%s\"", tmpPath)
+	}
+
+	return nil
+}
+
+// readState reads a state file.
+func readState(path string, v interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return vperror.Chainf(err, "unable to read \"%s\"", path)
+	}
+	err = json.Unmarshal(content, v)
+	if err != nil {
+		return vperror.Chainf(err, "unable to unmarshal \"%s\"", path)
+	}
+
+	return nil
+}
+
+// listState returns the state files within a state sub-directory.
+// A missing directory is not an error, there's simply nothing in it.
+func listState(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, vperror.Chainf(err, "unable to list \"%s\"", dir)
+	}
+	ret := make([]string, 0, len(files))
+	for _, v := range files {
+		if !v.IsDir() && strings.HasSuffix(v.Name(), StateFileSuffix) {
+			ret = append(ret, filepath.Join(dir, v.Name()))
+		}
+	}
+
+	return ret, nil
+}
+
+// Save writes the host in the state directory. The private key, if any,
+// is encrypted with the passphrase, which must be at least 16 bytes long.
+func (host *Host) Save(dir string, passphrase []byte) error {
+	var state hostState
+	var err error
+
+	state.Info = &(host.Info)
+	if host.CanSign() {
+		state.PrivKey, err = host.key.ExportPriv(passphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeState(filepath.Join(dir, StateHostFile), &state)
+}
+
+// LoadHost reads a host previously saved in the state directory,
+// and restores it within env.
+func LoadHost(env *Env, dir string, passphrase []byte) (*Host, error) {
+	var state hostState
+	var key *vpcrypto.Key
+	var err error
+
+	err = readState(filepath.Join(dir, StateHostFile), &state)
+	if err != nil {
+		return nil, err
+	}
+	if state.Info == nil {
+		return nil, fmt.Errorf("no host info in state")
+	}
+	_, err = vpp2pdat.CheckHostInfo(state.Info)
+	if err != nil {
+		return nil, err
+	}
+	if state.PrivKey != nil && len(state.PrivKey) > 0 {
+		var pubKey []byte
+
+		key, err = vpcrypto.ImportPrivKey(state.PrivKey, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err = key.ExportPub()
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(pubKey, state.Info.HostPubKey) {
+			return nil, fmt.Errorf("private key does not match host public key")
+		}
+	} else if vpp2pdat.IsPubKeyExpectedToSign(state.Info.HostPubKey) {
+		return nil, fmt.Errorf("no private key in state for a signing host")
+	}
+
+	return newHost(env, state.Info, key)
+}
+
+func ringStatePath(dir string, ringID []byte) string {
+	return filepath.Join(dir, StateRingsDir, hex.EncodeToString(ringID)+StateFileSuffix)
+}
+
+// Save writes the ring in the state directory. Its secret, if any,
+// is encrypted with the passphrase, which must be at least 16 bytes long.
+func (ring *Ring) Save(dir string, passphrase []byte) error {
+	var state ringState
+	var err error
+
+	state.Info = &(ring.Info)
+	if ring.secret.PasswordHash != nil && len(ring.secret.PasswordHash) > 0 {
+		var secret []byte
+
+		secret, err = json.Marshal(&(ring.secret))
+		if err != nil {
+			return vperror.Chain(err, "unable to marshal ring secret")
+		}
+		state.Secret, err = vpcrypto.SymEncrypt(secret, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeState(ringStatePath(dir, ring.Info.RingID), &state)
+}
+
+// LoadRings reads all the rings previously saved in the state directory,
+// and restores them within env.
+func LoadRings(env *Env, dir string, passphrase []byte) ([]*Ring, error) {
+	paths, err := listState(filepath.Join(dir, StateRingsDir))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*Ring, 0, len(paths))
+	for _, path := range paths {
+		var state ringState
+		var secret RingSecret
+		var ring *Ring
+
+		err = readState(path, &state)
+		if err != nil {
+			return nil, err
+		}
+		if state.Info == nil || state.Info.Config == nil {
+			return nil, fmt.Errorf("no ring info in \"%s\"", path)
+		}
+		if state.Secret != nil && len(state.Secret) > 0 {
+			var content []byte
+
+			content, err = vpcrypto.SymDecrypt(state.Secret, passphrase)
+			if err != nil {
+				return nil, vperror.Chainf(err, "unable to decrypt ring secret in \"%s\"", path)
+			}
+			err = json.Unmarshal(content, &secret)
+			if err != nil {
+				return nil, vperror.Chainf(err, "unable to unmarshal ring secret in \"%s\"", path)
+			}
+		}
+		ring, err = RingFromInfo(env, state.Info, secret.PasswordHash)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, ring)
+	}
+
+	return ret, nil
+}
+
+func nodeStatePath(dir string, nodeID []byte) string {
+	return filepath.Join(dir, StateNodesDir, hex.EncodeToString(nodeID)+StateFileSuffix)
+}
+
+// Save writes the node static info in the state directory, so that
+// it can be restarted later with the same ID.
+func (node *Node) Save(dir string) error {
+	return writeState(nodeStatePath(dir, node.Status.Info.NodeID), node.Status.Info)
+}
+
+// LoadNodes reads all the nodes previously saved in the state directory,
+// and which belong to the host and one of the given rings. Other nodes
+// are ignored. Nodes are restored within env.
+func LoadNodes(env *Env, dir string, host *Host, rings []*Ring) ([]*Node, error) {
+	paths, err := listState(filepath.Join(dir, StateNodesDir))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*Node, 0, len(paths))
+	for _, path := range paths {
+		var info vpp2papi.NodeInfo
+		var node *Node
+
+		err = readState(path, &info)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(info.HostPubKey, host.Info.HostPubKey) {
+			continue
+		}
+		for _, ring := range rings {
+			if !bytes.Equal(info.RingID, ring.Info.RingID) {
+				continue
+			}
+			node, err = NodeFromInfo(env, host, ring, &info)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, node)
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+// SaveState writes a host, its rings and its nodes in the state directory.
+func SaveState(dir string, passphrase []byte, host *Host, rings []*Ring, nodes []*Node) error {
+	err := host.Save(dir, passphrase)
+	if err != nil {
+		return err
+	}
+	for _, ring := range rings {
+		err = ring.Save(dir, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+	for _, node := range nodes {
+		err = node.Save(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadState restores a host, its rings and its nodes from the state
+// directory, so that the program gets the same identity across restarts.
+// Everything is restored within env, nodes are not started.
+func LoadState(env *Env, dir string, passphrase []byte) (*Host, []*Ring, []*Node, error) {
+	host, err := LoadHost(env, dir, passphrase)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	rings, err := LoadRings(env, dir, passphrase)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	nodes, err := LoadNodes(env, dir, host, rings)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return host, rings, nodes, nil
+}
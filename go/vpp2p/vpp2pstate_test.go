@@ -0,0 +1,155 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"github.com/ufoot/vapor/go/vpsum"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	env := NewEnv()
+
+	passphrase := []byte("this is a long enough passphrase")
+
+	dir, err := ioutil.TempDir("", "vpp2pstate")
+	if err != nil {
+		t.Fatal("unable to create temp dir", err)
+	}
+	defer os.RemoveAll(dir)
+
+	host, err := NewHost(env, testTitle, testURL+"/state", true)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	ring, err := NewRing(env, host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, vpsum.Checksum256([]byte("password")))
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	node, err := NewNode(env, host, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+
+	err = SaveState(dir, passphrase, host, []*Ring{ring}, []*Node{node})
+	if err != nil {
+		t.Fatal("unable to save state", err)
+	}
+	_, _, _, err = LoadState(NewEnv(), dir, []byte("this is a wrong passphrase"))
+	if err == nil {
+		t.Error("state loaded with a wrong passphrase")
+	}
+	// restore in a fresh env, as a restarted program would do
+	env2 := NewEnv()
+	host2, rings2, nodes2, err := LoadState(env2, dir, passphrase)
+	if err != nil {
+		t.Fatal("unable to load state", err)
+	}
+
+	if bytes.Compare(host2.Info.HostPubKey, host.Info.HostPubKey) != 0 || !host2.CanSign() {
+		t.Error("host not restored")
+	}
+	if len(rings2) != 1 || bytes.Compare(rings2[0].Info.RingID, ring.Info.RingID) != 0 {
+		t.Fatal("ring not restored")
+	}
+	if bytes.Compare(rings2[0].secret.PasswordHash, ring.secret.PasswordHash) != 0 {
+		t.Error("ring secret not restored")
+	}
+	if len(nodes2) != 1 || bytes.Compare(nodes2[0].Status.Info.NodeID, node.Status.Info.NodeID) != 0 {
+		t.Fatal("node not restored")
+	}
+	_, err = nodes2[0].CheckSig()
+	if err != nil {
+		t.Error("restored node has a bad sig", err)
+	}
+	if nodes2[0].hostPtr != host2 || nodes2[0].ringPtr != rings2[0] {
+		t.Error("restored node not linked to restored host and ring")
+	}
+	if host2.Env() != env2 || rings2[0].Env() != env2 || nodes2[0].env != env2 {
+		t.Error("state not restored within env")
+	}
+}
+
+func TestLoadHostRPC(t *testing.T) {
+	env := NewEnv()
+
+	passphrase := []byte("this is a long enough passphrase")
+
+	dir, err := ioutil.TempDir("", "vpp2pstate")
+	if err != nil {
+		t.Fatal("unable to create temp dir", err)
+	}
+	defer os.RemoveAll(dir)
+
+	host, err := NewHost(env, testTitle, testURL+"/state/rpc", true)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	ring, err := NewRing(env, host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	node, err := NewNode(env, host, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	err = host.Save(dir, passphrase)
+	if err != nil {
+		t.Fatal("unable to save host", err)
+	}
+
+	// restore in a fresh env, and call it from the original one,
+	// so that the call is handled like one from a remote host
+	env2 := NewEnv()
+	host2, err := LoadHost(env2, dir, passphrase)
+	if err != nil {
+		t.Fatal("unable to load host", err)
+	}
+	ring2, err := RingFromInfo(env2, &(ring.Info), nil)
+	if err != nil {
+		t.Fatal("unable to restore ring", err)
+	}
+	node2, err := NewNode(env2, host2, ring2, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	node2.Start()
+	defer node2.Stop()
+
+	key := vpsum.Checksum256([]byte("level"))
+	request := vpp2papi.NewLookupRequest()
+	request.Context = node.contextInfo(node2.Status.Info.NodeID)
+	request.Key = key
+	request.KeyShift = node2.GetKeyShift(key)
+	request.ImaginaryNode = node2.GetImaginaryNode(key)
+	request.Sig, err = node.authenticate(host2, request.Context, func() []byte { return vpp2pdat.LookupRequestSigBytes(request) })
+	if err != nil {
+		t.Fatal("unable to authenticate request", err)
+	}
+	response, err := host2.Lookup(request)
+	if err != nil || response == nil || !response.Found {
+		t.Error("unable to lookup on a loaded host", err)
+	}
+}
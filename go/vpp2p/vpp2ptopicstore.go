@@ -0,0 +1,167 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sort"
+	"sync"
+	"time"
+)
+
+// subscriberEntry is a node subscribed to a topic, along with the
+// expiration date of its subscription.
+type subscriberEntry struct {
+	info    *vpp2papi.NodeInfo
+	expires time.Time
+}
+
+// nodeInfoList sorts node infos by ID.
+type nodeInfoList []*vpp2papi.NodeInfo
+
+func (l nodeInfoList) Len() int {
+	return len(l)
+}
+
+func (l nodeInfoList) Less(i, j int) bool {
+	return bytes.Compare(l[i].NodeID, l[j].NodeID) < 0
+}
+
+func (l nodeInfoList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+// topicStore keeps the subscribers of the topics held by a node,
+// indexed by topic key, then by subscriber node ID.
+type topicStore struct {
+	access sync.RWMutex
+	topics map[[vpp2pdat.NodeIDBufNbBytes]byte]map[[vpp2pdat.NodeIDBufNbBytes]byte]*subscriberEntry
+	clock  Clock
+}
+
+func newTopicStore(clock Clock) *topicStore {
+	return &topicStore{topics: make(map[[vpp2pdat.NodeIDBufNbBytes]byte]map[[vpp2pdat.NodeIDBufNbBytes]byte]*subscriberEntry), clock: clock}
+}
+
+// add subscribes a node to a topic, or renews its subscription
+// if it already exists.
+// It's thread-safe.
+func (ts *topicStore) add(key []byte, subscriber *vpp2papi.NodeInfo, lifetime time.Duration) {
+	keyBuf := vpp2pdat.NodeIDToBuf(key)
+	entry := subscriberEntry{info: subscriber, expires: ts.clock.Now().Add(lifetime)}
+
+	defer ts.access.Unlock()
+	ts.access.Lock()
+
+	subscribers := ts.topics[keyBuf]
+	if subscribers == nil {
+		subscribers = make(map[[vpp2pdat.NodeIDBufNbBytes]byte]*subscriberEntry)
+		ts.topics[keyBuf] = subscribers
+	}
+	subscribers[vpp2pdat.NodeIDToBuf(subscriber.NodeID)] = &entry
+}
+
+// remove unsubscribes a node from a topic, returns true if it
+// was subscribed.
+// It's thread-safe.
+func (ts *topicStore) remove(key []byte, subscriber *vpp2papi.NodeInfo) bool {
+	keyBuf := vpp2pdat.NodeIDToBuf(key)
+	subscriberBuf := vpp2pdat.NodeIDToBuf(subscriber.NodeID)
+
+	defer ts.access.Unlock()
+	ts.access.Lock()
+
+	subscribers := ts.topics[keyBuf]
+	if subscribers == nil {
+		return false
+	}
+	_, ok := subscribers[subscriberBuf]
+	delete(subscribers, subscriberBuf)
+	if len(subscribers) == 0 {
+		delete(ts.topics, keyBuf)
+	}
+
+	return ok
+}
+
+// list returns the subscribers of a topic, sorted by ID, expired
+// subscriptions are ignored.
+// It's thread-safe.
+func (ts *topicStore) list(key []byte) []*vpp2papi.NodeInfo {
+	now := ts.clock.Now()
+
+	defer ts.access.RUnlock()
+	ts.access.RLock()
+
+	subscribers := ts.topics[vpp2pdat.NodeIDToBuf(key)]
+	ret := make([]*vpp2papi.NodeInfo, 0, len(subscribers))
+	for _, v := range subscribers {
+		if now.After(v.expires) {
+			continue
+		}
+		ret = append(ret, v.info)
+	}
+	sort.Sort(nodeInfoList(ret))
+
+	return ret
+}
+
+// purge removes all expired subscriptions, returns the number
+// of removed subscriptions.
+// It's thread-safe.
+func (ts *topicStore) purge() int {
+	ret := 0
+	now := ts.clock.Now()
+
+	defer ts.access.Unlock()
+	ts.access.Lock()
+
+	for k, subscribers := range ts.topics {
+		for l, v := range subscribers {
+			if now.After(v.expires) {
+				delete(subscribers, l)
+				ret++
+			}
+		}
+		if len(subscribers) == 0 {
+			delete(ts.topics, k)
+		}
+	}
+
+	return ret
+}
+
+// len returns the number of subscriptions, including expired ones
+// which have not been purged yet.
+// It's thread-safe.
+func (ts *topicStore) len() int {
+	ret := 0
+
+	defer ts.access.RUnlock()
+	ts.access.RLock()
+
+	for _, subscribers := range ts.topics {
+		ret += len(subscribers)
+	}
+
+	return ret
+}
@@ -0,0 +1,59 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2p
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+	"time"
+)
+
+func TestTopicStore(t *testing.T) {
+	ts := newTopicStore(SystemClock())
+	key1 := vpsum.Checksum256([]byte("topic1"))
+	key2 := vpsum.Checksum256([]byte("topic2"))
+	sub1 := &vpp2papi.NodeInfo{NodeID: vpsum.Checksum256([]byte("sub1"))}
+	sub2 := &vpp2papi.NodeInfo{NodeID: vpsum.Checksum256([]byte("sub2"))}
+
+	ts.add(key1, sub1, time.Hour)
+	ts.add(key1, sub2, time.Hour)
+	ts.add(key2, sub1, -time.Second)
+	subscribers := ts.list(key1)
+	if len(subscribers) != 2 || bytes.Compare(subscribers[0].NodeID, subscribers[1].NodeID) >= 0 {
+		t.Error("bad list")
+	}
+	if len(ts.list(key2)) != 0 {
+		t.Error("got an expired subscription")
+	}
+	if ts.purge() != 1 || ts.len() != 2 {
+		t.Error("bad purge")
+	}
+	if !ts.remove(key1, sub1) {
+		t.Error("unable to remove subscription")
+	}
+	if ts.remove(key1, sub1) {
+		t.Error("removed a subscription twice")
+	}
+	if !ts.remove(key1, sub2) || ts.len() != 0 {
+		t.Error("store should be empty")
+	}
+}
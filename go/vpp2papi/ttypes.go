@@ -415,14 +415,14 @@ func (p *NodeInfo) String() string {
 }
 
 // NodePeers contains informations about node peers,
-// those it should contact.
+// those it should contact. D is not set until it is known.
 //
 // Attributes:
 //  - Successors
 //  - D
 type NodePeers struct {
 	Successors []*NodeInfo `thrift:"Successors,1" json:"Successors"`
-	D          *NodeInfo   `thrift:"D,2" json:"D"`
+	D          *NodeInfo   `thrift:"D,2" json:"D,omitempty"`
 }
 
 func NewNodePeers() *NodePeers {
@@ -551,14 +551,16 @@ func (p *NodePeers) writeField1(oprot thrift.TProtocol) (err error) {
 }
 
 func (p *NodePeers) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("D", thrift.STRUCT, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:D: ", p), err)
-	}
-	if err := p.D.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.D), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:D: ", p), err)
+	if p.IsSetD() {
+		if err := oprot.WriteFieldBegin("D", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:D: ", p), err)
+		}
+		if err := p.D.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.D), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:D: ", p), err)
+		}
 	}
 	return err
 }
@@ -570,7 +572,8 @@ func (p *NodePeers) String() string {
 	return fmt.Sprintf("NodePeers(%+v)", *p)
 }
 
-// NodeStatus contains details about a node.
+// NodeStatus contains details about a node. Predecessor
+// is not set until it is known.
 //
 // Attributes:
 //  - Info
@@ -579,7 +582,7 @@ func (p *NodePeers) String() string {
 type NodeStatus struct {
 	Info        *NodeInfo  `thrift:"Info,1" json:"Info"`
 	Peers       *NodePeers `thrift:"Peers,2" json:"Peers"`
-	Predecessor *NodeInfo  `thrift:"Predecessor,3" json:"Predecessor"`
+	Predecessor *NodeInfo  `thrift:"Predecessor,3" json:"Predecessor,omitempty"`
 }
 
 func NewNodeStatus() *NodeStatus {
@@ -738,14 +741,16 @@ func (p *NodeStatus) writeField2(oprot thrift.TProtocol) (err error) {
 }
 
 func (p *NodeStatus) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Predecessor", thrift.STRUCT, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Predecessor: ", p), err)
-	}
-	if err := p.Predecessor.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Predecessor), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Predecessor: ", p), err)
+	if p.IsSetPredecessor() {
+		if err := oprot.WriteFieldBegin("Predecessor", thrift.STRUCT, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Predecessor: ", p), err)
+		}
+		if err := p.Predecessor.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Predecessor), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Predecessor: ", p), err)
+		}
 	}
 	return err
 }
@@ -758,6 +763,8 @@ func (p *NodeStatus) String() string {
 }
 
 // RingConfig contains functional parameters of a ring.
+// MinNodeZeroes is the minimum number of zeroes required in the
+// hash of node signatures, 0 means any node ID is accepted.
 //
 // Attributes:
 //  - BruijnM
@@ -768,6 +775,7 @@ func (p *NodeStatus) String() string {
 //  - SyncDelay
 //  - DisconnectTimeout
 //  - DataLifetime
+//  - MinNodeZeroes
 type RingConfig struct {
 	BruijnM           int32 `thrift:"BruijnM,1" json:"BruijnM"`
 	BruijnN           int32 `thrift:"BruijnN,2" json:"BruijnN"`
@@ -777,6 +785,7 @@ type RingConfig struct {
 	SyncDelay         int32 `thrift:"SyncDelay,6" json:"SyncDelay"`
 	DisconnectTimeout int32 `thrift:"DisconnectTimeout,7" json:"DisconnectTimeout"`
 	DataLifetime      int32 `thrift:"DataLifetime,8" json:"DataLifetime"`
+	MinNodeZeroes     int32 `thrift:"MinNodeZeroes,9" json:"MinNodeZeroes"`
 }
 
 func NewRingConfig() *RingConfig {
@@ -814,6 +823,10 @@ func (p *RingConfig) GetDisconnectTimeout() int32 {
 func (p *RingConfig) GetDataLifetime() int32 {
 	return p.DataLifetime
 }
+
+func (p *RingConfig) GetMinNodeZeroes() int32 {
+	return p.MinNodeZeroes
+}
 func (p *RingConfig) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -860,6 +873,10 @@ func (p *RingConfig) Read(iprot thrift.TProtocol) error {
 			if err := p.readField8(iprot); err != nil {
 				return err
 			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -947,6 +964,15 @@ func (p *RingConfig) readField8(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *RingConfig) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.MinNodeZeroes = v
+	}
+	return nil
+}
+
 func (p *RingConfig) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("RingConfig"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -975,6 +1001,9 @@ func (p *RingConfig) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField8(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1088,6 +1117,19 @@ func (p *RingConfig) writeField8(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RingConfig) writeField9(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("MinNodeZeroes", thrift.I32, 9); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:MinNodeZeroes: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.MinNodeZeroes)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.MinNodeZeroes (9) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 9:MinNodeZeroes: ", p), err)
+	}
+	return err
+}
+
 func (p *RingConfig) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1442,18 +1484,33 @@ func (p *RingInfo) String() string {
 }
 
 // ContextInfo contains static informations about the program
-// calling a fonction, it gives context.
+// calling a fonction, it gives context. On rings with a password,
+// Challenge must have been obtained from the target node, and
+// RingAuth is the HMAC of the request, keyed by the password hash.
+// The Sig field of requests is the signature of the request by
+// the source host, it is required on signed rings, and whenever
+// the source host is able to sign. Timestamp is when the request
+// was made, in seconds since the epoch, and Nonce a random value
+// used only once, so that stale or replayed requests are refused.
 //
 // Attributes:
 //  - SourceHost
 //  - SourceRing
 //  - SourceNode
 //  - TargetNodeID
+//  - Challenge
+//  - RingAuth
+//  - Timestamp
+//  - Nonce
 type ContextInfo struct {
 	SourceHost   *HostInfo `thrift:"SourceHost,1" json:"SourceHost"`
 	SourceRing   *RingInfo `thrift:"SourceRing,2" json:"SourceRing"`
 	SourceNode   *NodeInfo `thrift:"SourceNode,3" json:"SourceNode"`
 	TargetNodeID []byte    `thrift:"TargetNodeID,4" json:"TargetNodeID"`
+	Challenge    []byte    `thrift:"Challenge,5" json:"Challenge"`
+	RingAuth     []byte    `thrift:"RingAuth,6" json:"RingAuth"`
+	Timestamp    int64     `thrift:"Timestamp,7" json:"Timestamp"`
+	Nonce        []byte    `thrift:"Nonce,8" json:"Nonce"`
 }
 
 func NewContextInfo() *ContextInfo {
@@ -1490,6 +1547,22 @@ func (p *ContextInfo) GetSourceNode() *NodeInfo {
 func (p *ContextInfo) GetTargetNodeID() []byte {
 	return p.TargetNodeID
 }
+
+func (p *ContextInfo) GetChallenge() []byte {
+	return p.Challenge
+}
+
+func (p *ContextInfo) GetRingAuth() []byte {
+	return p.RingAuth
+}
+
+func (p *ContextInfo) GetTimestamp() int64 {
+	return p.Timestamp
+}
+
+func (p *ContextInfo) GetNonce() []byte {
+	return p.Nonce
+}
 func (p *ContextInfo) IsSetSourceHost() bool {
 	return p.SourceHost != nil
 }
@@ -1532,6 +1605,22 @@ func (p *ContextInfo) Read(iprot thrift.TProtocol) error {
 			if err := p.readField4(iprot); err != nil {
 				return err
 			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1580,6 +1669,42 @@ func (p *ContextInfo) readField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *ContextInfo) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Challenge = v
+	}
+	return nil
+}
+
+func (p *ContextInfo) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.RingAuth = v
+	}
+	return nil
+}
+
+func (p *ContextInfo) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Timestamp = v
+	}
+	return nil
+}
+
+func (p *ContextInfo) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.Nonce = v
+	}
+	return nil
+}
+
 func (p *ContextInfo) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("ContextInfo"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -1596,6 +1721,18 @@ func (p *ContextInfo) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1657,6 +1794,58 @@ func (p *ContextInfo) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *ContextInfo) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Challenge", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Challenge: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Challenge); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Challenge (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Challenge: ", p), err)
+	}
+	return err
+}
+
+func (p *ContextInfo) writeField6(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("RingAuth", thrift.STRING, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:RingAuth: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.RingAuth); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.RingAuth (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:RingAuth: ", p), err)
+	}
+	return err
+}
+
+func (p *ContextInfo) writeField7(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Timestamp", thrift.I64, 7); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:Timestamp: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.Timestamp)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Timestamp (7) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 7:Timestamp: ", p), err)
+	}
+	return err
+}
+
+func (p *ContextInfo) writeField8(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Nonce", thrift.STRING, 8); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:Nonce: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Nonce); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Nonce (8) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 8:Nonce: ", p), err)
+	}
+	return err
+}
+
 func (p *ContextInfo) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1945,55 +2134,37 @@ func (p *HostStatus) String() string {
 	return fmt.Sprintf("HostStatus(%+v)", *p)
 }
 
-// Used to store Lookup-like requests.
+// Used to store Challenge requests.
 //
 // Attributes:
 //  - Context
-//  - Key
-//  - KeyShift
-//  - ImaginaryNode
 //  - Sig
-type LookupRequest struct {
-	Context       *ContextInfo `thrift:"Context,1" json:"Context"`
-	Key           []byte       `thrift:"Key,2" json:"Key"`
-	KeyShift      []byte       `thrift:"KeyShift,3" json:"KeyShift"`
-	ImaginaryNode []byte       `thrift:"ImaginaryNode,4" json:"ImaginaryNode"`
-	Sig           []byte       `thrift:"Sig,5" json:"Sig"`
+type ChallengeRequest struct {
+	Context *ContextInfo `thrift:"Context,1" json:"Context"`
+	Sig     []byte       `thrift:"Sig,2" json:"Sig"`
 }
 
-func NewLookupRequest() *LookupRequest {
-	return &LookupRequest{}
+func NewChallengeRequest() *ChallengeRequest {
+	return &ChallengeRequest{}
 }
 
-var LookupRequest_Context_DEFAULT *ContextInfo
+var ChallengeRequest_Context_DEFAULT *ContextInfo
 
-func (p *LookupRequest) GetContext() *ContextInfo {
+func (p *ChallengeRequest) GetContext() *ContextInfo {
 	if !p.IsSetContext() {
-		return LookupRequest_Context_DEFAULT
+		return ChallengeRequest_Context_DEFAULT
 	}
 	return p.Context
 }
 
-func (p *LookupRequest) GetKey() []byte {
-	return p.Key
-}
-
-func (p *LookupRequest) GetKeyShift() []byte {
-	return p.KeyShift
-}
-
-func (p *LookupRequest) GetImaginaryNode() []byte {
-	return p.ImaginaryNode
-}
-
-func (p *LookupRequest) GetSig() []byte {
+func (p *ChallengeRequest) GetSig() []byte {
 	return p.Sig
 }
-func (p *LookupRequest) IsSetContext() bool {
+func (p *ChallengeRequest) IsSetContext() bool {
 	return p.Context != nil
 }
 
-func (p *LookupRequest) Read(iprot thrift.TProtocol) error {
+func (p *ChallengeRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2015,18 +2186,6 @@ func (p *LookupRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
-		case 3:
-			if err := p.readField3(iprot); err != nil {
-				return err
-			}
-		case 4:
-			if err := p.readField4(iprot); err != nil {
-				return err
-			}
-		case 5:
-			if err := p.readField5(iprot); err != nil {
-				return err
-			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2042,7 +2201,7 @@ func (p *LookupRequest) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *LookupRequest) readField1(iprot thrift.TProtocol) error {
+func (p *ChallengeRequest) readField1(iprot thrift.TProtocol) error {
 	p.Context = &ContextInfo{}
 	if err := p.Context.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
@@ -2050,44 +2209,17 @@ func (p *LookupRequest) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *LookupRequest) readField2(iprot thrift.TProtocol) error {
+func (p *ChallengeRequest) readField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.Key = v
-	}
-	return nil
-}
-
-func (p *LookupRequest) readField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.KeyShift = v
-	}
-	return nil
-}
-
-func (p *LookupRequest) readField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 4: ", err)
-	} else {
-		p.ImaginaryNode = v
-	}
-	return nil
-}
-
-func (p *LookupRequest) readField5(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 5: ", err)
 	} else {
 		p.Sig = v
 	}
 	return nil
 }
 
-func (p *LookupRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("LookupRequest"); err != nil {
+func (p *ChallengeRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ChallengeRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2096,15 +2228,6 @@ func (p *LookupRequest) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField3(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField4(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField5(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2114,7 +2237,7 @@ func (p *LookupRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *LookupRequest) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *ChallengeRequest) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
 	}
@@ -2127,93 +2250,43 @@ func (p *LookupRequest) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *LookupRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Key", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Key: ", p), err)
+func (p *ChallengeRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Sig: ", p), err)
 	}
-	if err := oprot.WriteBinary(p.Key); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Key (2) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Key: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Sig: ", p), err)
 	}
 	return err
 }
 
-func (p *LookupRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("KeyShift", thrift.STRING, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:KeyShift: ", p), err)
-	}
-	if err := oprot.WriteBinary(p.KeyShift); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.KeyShift (3) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:KeyShift: ", p), err)
+func (p *ChallengeRequest) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return err
+	return fmt.Sprintf("ChallengeRequest(%+v)", *p)
 }
 
-func (p *LookupRequest) writeField4(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("ImaginaryNode", thrift.STRING, 4); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:ImaginaryNode: ", p), err)
-	}
-	if err := oprot.WriteBinary(p.ImaginaryNode); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.ImaginaryNode (4) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:ImaginaryNode: ", p), err)
-	}
-	return err
+// Used to store results when doing Challenge requests.
+// The challenge can be used only once, within the call timeout.
+//
+// Attributes:
+//  - Challenge
+type ChallengeResponse struct {
+	Challenge []byte `thrift:"Challenge,1" json:"Challenge"`
 }
 
-func (p *LookupRequest) writeField5(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 5); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Sig: ", p), err)
-	}
-	if err := oprot.WriteBinary(p.Sig); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Sig (5) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Sig: ", p), err)
-	}
-	return err
+func NewChallengeResponse() *ChallengeResponse {
+	return &ChallengeResponse{}
 }
 
-func (p *LookupRequest) String() string {
-	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("LookupRequest(%+v)", *p)
-}
-
-// Used to store results when doing Lookup-like requests.
-//
-// Attributes:
-//  - Found
-//  - NodesPath
-//  - HostsRefs
-type LookupResponse struct {
-	Found     bool                 `thrift:"Found,1" json:"Found"`
-	NodesPath []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
-	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
-}
-
-func NewLookupResponse() *LookupResponse {
-	return &LookupResponse{}
-}
-
-func (p *LookupResponse) GetFound() bool {
-	return p.Found
-}
-
-func (p *LookupResponse) GetNodesPath() []*NodeInfo {
-	return p.NodesPath
-}
-
-func (p *LookupResponse) GetHostsRefs() map[string]*HostInfo {
-	return p.HostsRefs
+func (p *ChallengeResponse) GetChallenge() []byte {
+	return p.Challenge
 }
-func (p *LookupResponse) Read(iprot thrift.TProtocol) error {
+func (p *ChallengeResponse) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2231,14 +2304,6 @@ func (p *LookupResponse) Read(iprot thrift.TProtocol) error {
 			if err := p.readField1(iprot); err != nil {
 				return err
 			}
-		case 2:
-			if err := p.readField2(iprot); err != nil {
-				return err
-			}
-		case 3:
-			if err := p.readField3(iprot); err != nil {
-				return err
-			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2254,74 +2319,22 @@ func (p *LookupResponse) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *LookupResponse) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *ChallengeResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Found = v
-	}
-	return nil
-}
-
-func (p *LookupResponse) readField2(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
-	}
-	tSlice := make([]*NodeInfo, 0, size)
-	p.NodesPath = tSlice
-	for i := 0; i < size; i++ {
-		_elem6 := &NodeInfo{}
-		if err := _elem6.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem6), err)
-		}
-		p.NodesPath = append(p.NodesPath, _elem6)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+		p.Challenge = v
 	}
 	return nil
 }
 
-func (p *LookupResponse) readField3(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
-	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
-	}
-	tMap := make(map[string]*HostInfo, size)
-	p.HostsRefs = tMap
-	for i := 0; i < size; i++ {
-		var _key7 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key7 = v
-		}
-		_val8 := &HostInfo{}
-		if err := _val8.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val8), err)
-		}
-		p.HostsRefs[_key7] = _val8
-	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
-	}
-	return nil
-}
-
-func (p *LookupResponse) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("LookupResponse"); err != nil {
+func (p *ChallengeResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ChallengeResponse"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField2(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField3(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2331,102 +2344,75 @@ func (p *LookupResponse) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *LookupResponse) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Found", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Found: ", p), err)
-	}
-	if err := oprot.WriteBool(bool(p.Found)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Found (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Found: ", p), err)
-	}
-	return err
-}
-
-func (p *LookupResponse) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.NodesPath {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
-	}
-	return err
-}
-
-func (p *LookupResponse) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
-	}
-	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
-		return thrift.PrependError("error writing map begin: ", err)
-	}
-	for k, v := range p.HostsRefs {
-		if err := oprot.WriteString(string(k)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-		}
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
+func (p *ChallengeResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Challenge", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Challenge: ", p), err)
 	}
-	if err := oprot.WriteMapEnd(); err != nil {
-		return thrift.PrependError("error writing map end: ", err)
+	if err := oprot.WriteBinary(p.Challenge); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Challenge (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Challenge: ", p), err)
 	}
 	return err
 }
 
-func (p *LookupResponse) String() string {
+func (p *ChallengeResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("LookupResponse(%+v)", *p)
+	return fmt.Sprintf("ChallengeResponse(%+v)", *p)
 }
 
-// Used to store  GetSuccessors requests.
+// Used to store Lookup-like requests.
 //
 // Attributes:
 //  - Context
+//  - Key
+//  - KeyShift
+//  - ImaginaryNode
 //  - Sig
-type GetSuccessorsRequest struct {
-	Context *ContextInfo `thrift:"Context,1" json:"Context"`
-	Sig     []byte       `thrift:"Sig,2" json:"Sig"`
+type LookupRequest struct {
+	Context       *ContextInfo `thrift:"Context,1" json:"Context"`
+	Key           []byte       `thrift:"Key,2" json:"Key"`
+	KeyShift      []byte       `thrift:"KeyShift,3" json:"KeyShift"`
+	ImaginaryNode []byte       `thrift:"ImaginaryNode,4" json:"ImaginaryNode"`
+	Sig           []byte       `thrift:"Sig,5" json:"Sig"`
 }
 
-func NewGetSuccessorsRequest() *GetSuccessorsRequest {
-	return &GetSuccessorsRequest{}
+func NewLookupRequest() *LookupRequest {
+	return &LookupRequest{}
 }
 
-var GetSuccessorsRequest_Context_DEFAULT *ContextInfo
+var LookupRequest_Context_DEFAULT *ContextInfo
 
-func (p *GetSuccessorsRequest) GetContext() *ContextInfo {
+func (p *LookupRequest) GetContext() *ContextInfo {
 	if !p.IsSetContext() {
-		return GetSuccessorsRequest_Context_DEFAULT
+		return LookupRequest_Context_DEFAULT
 	}
 	return p.Context
 }
 
-func (p *GetSuccessorsRequest) GetSig() []byte {
+func (p *LookupRequest) GetKey() []byte {
+	return p.Key
+}
+
+func (p *LookupRequest) GetKeyShift() []byte {
+	return p.KeyShift
+}
+
+func (p *LookupRequest) GetImaginaryNode() []byte {
+	return p.ImaginaryNode
+}
+
+func (p *LookupRequest) GetSig() []byte {
 	return p.Sig
 }
-func (p *GetSuccessorsRequest) IsSetContext() bool {
+func (p *LookupRequest) IsSetContext() bool {
 	return p.Context != nil
 }
 
-func (p *GetSuccessorsRequest) Read(iprot thrift.TProtocol) error {
+func (p *LookupRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2448,6 +2434,18 @@ func (p *GetSuccessorsRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2463,7 +2461,7 @@ func (p *GetSuccessorsRequest) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetSuccessorsRequest) readField1(iprot thrift.TProtocol) error {
+func (p *LookupRequest) readField1(iprot thrift.TProtocol) error {
 	p.Context = &ContextInfo{}
 	if err := p.Context.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
@@ -2471,17 +2469,44 @@ func (p *GetSuccessorsRequest) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetSuccessorsRequest) readField2(iprot thrift.TProtocol) error {
+func (p *LookupRequest) readField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *LookupRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.KeyShift = v
+	}
+	return nil
+}
+
+func (p *LookupRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.ImaginaryNode = v
+	}
+	return nil
+}
+
+func (p *LookupRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
 	} else {
 		p.Sig = v
 	}
 	return nil
 }
 
-func (p *GetSuccessorsRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetSuccessorsRequest"); err != nil {
+func (p *LookupRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LookupRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2490,6 +2515,15 @@ func (p *GetSuccessorsRequest) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2499,7 +2533,7 @@ func (p *GetSuccessorsRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetSuccessorsRequest) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *LookupRequest) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
 	}
@@ -2512,48 +2546,93 @@ func (p *GetSuccessorsRequest) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *GetSuccessorsRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Sig: ", p), err)
-	}
-	if err := oprot.WriteBinary(p.Sig); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Sig (2) field write error: ", p), err)
+func (p *LookupRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Key", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Key: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Key); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Sig: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Key: ", p), err)
 	}
 	return err
 }
 
-func (p *GetSuccessorsRequest) String() string {
+func (p *LookupRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("KeyShift", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:KeyShift: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.KeyShift); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.KeyShift (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:KeyShift: ", p), err)
+	}
+	return err
+}
+
+func (p *LookupRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("ImaginaryNode", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:ImaginaryNode: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.ImaginaryNode); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ImaginaryNode (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:ImaginaryNode: ", p), err)
+	}
+	return err
+}
+
+func (p *LookupRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *LookupRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetSuccessorsRequest(%+v)", *p)
+	return fmt.Sprintf("LookupRequest(%+v)", *p)
 }
 
-// Used to store results when doing GetSuccessors requests.
+// Used to store results when doing Lookup-like requests.
 //
 // Attributes:
-//  - SuccessorNodes
+//  - Found
+//  - NodesPath
 //  - HostsRefs
-type GetSuccessorsResponse struct {
-	SuccessorNodes []*NodeInfo          `thrift:"SuccessorNodes,1" json:"SuccessorNodes"`
-	HostsRefs      map[string]*HostInfo `thrift:"HostsRefs,2" json:"HostsRefs"`
+type LookupResponse struct {
+	Found     bool                 `thrift:"Found,1" json:"Found"`
+	NodesPath []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
 }
 
-func NewGetSuccessorsResponse() *GetSuccessorsResponse {
-	return &GetSuccessorsResponse{}
+func NewLookupResponse() *LookupResponse {
+	return &LookupResponse{}
 }
 
-func (p *GetSuccessorsResponse) GetSuccessorNodes() []*NodeInfo {
-	return p.SuccessorNodes
+func (p *LookupResponse) GetFound() bool {
+	return p.Found
 }
 
-func (p *GetSuccessorsResponse) GetHostsRefs() map[string]*HostInfo {
+func (p *LookupResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *LookupResponse) GetHostsRefs() map[string]*HostInfo {
 	return p.HostsRefs
 }
-func (p *GetSuccessorsResponse) Read(iprot thrift.TProtocol) error {
+func (p *LookupResponse) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2575,6 +2654,10 @@ func (p *GetSuccessorsResponse) Read(iprot thrift.TProtocol) error {
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2590,19 +2673,28 @@ func (p *GetSuccessorsResponse) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetSuccessorsResponse) readField1(iprot thrift.TProtocol) error {
+func (p *LookupResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Found = v
+	}
+	return nil
+}
+
+func (p *LookupResponse) readField2(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
 	tSlice := make([]*NodeInfo, 0, size)
-	p.SuccessorNodes = tSlice
+	p.NodesPath = tSlice
 	for i := 0; i < size; i++ {
-		_elem9 := &NodeInfo{}
-		if err := _elem9.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem9), err)
+		_elem6 := &NodeInfo{}
+		if err := _elem6.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem6), err)
 		}
-		p.SuccessorNodes = append(p.SuccessorNodes, _elem9)
+		p.NodesPath = append(p.NodesPath, _elem6)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -2610,7 +2702,7 @@ func (p *GetSuccessorsResponse) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetSuccessorsResponse) readField2(iprot thrift.TProtocol) error {
+func (p *LookupResponse) readField3(iprot thrift.TProtocol) error {
 	_, _, size, err := iprot.ReadMapBegin()
 	if err != nil {
 		return thrift.PrependError("error reading map begin: ", err)
@@ -2618,17 +2710,17 @@ func (p *GetSuccessorsResponse) readField2(iprot thrift.TProtocol) error {
 	tMap := make(map[string]*HostInfo, size)
 	p.HostsRefs = tMap
 	for i := 0; i < size; i++ {
-		var _key10 string
+		var _key7 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key10 = v
+			_key7 = v
 		}
-		_val11 := &HostInfo{}
-		if err := _val11.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val11), err)
+		_val8 := &HostInfo{}
+		if err := _val8.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val8), err)
 		}
-		p.HostsRefs[_key10] = _val11
+		p.HostsRefs[_key7] = _val8
 	}
 	if err := iprot.ReadMapEnd(); err != nil {
 		return thrift.PrependError("error reading map end: ", err)
@@ -2636,8 +2728,8 @@ func (p *GetSuccessorsResponse) readField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetSuccessorsResponse) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetSuccessorsResponse"); err != nil {
+func (p *LookupResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LookupResponse"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2646,6 +2738,9 @@ func (p *GetSuccessorsResponse) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2655,14 +2750,27 @@ func (p *GetSuccessorsResponse) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetSuccessorsResponse) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("SuccessorNodes", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:SuccessorNodes: ", p), err)
+func (p *LookupResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Found", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Found: ", p), err)
 	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.SuccessorNodes)); err != nil {
+	if err := oprot.WriteBool(bool(p.Found)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Found (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Found: ", p), err)
+	}
+	return err
+}
+
+func (p *LookupResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
 		return thrift.PrependError("error writing list begin: ", err)
 	}
-	for _, v := range p.SuccessorNodes {
+	for _, v := range p.NodesPath {
 		if err := v.Write(oprot); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
 		}
@@ -2671,14 +2779,14 @@ func (p *GetSuccessorsResponse) writeField1(oprot thrift.TProtocol) (err error)
 		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:SuccessorNodes: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
 	}
 	return err
 }
 
-func (p *GetSuccessorsResponse) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:HostsRefs: ", p), err)
+func (p *LookupResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
 	}
 	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
 		return thrift.PrependError("error writing map begin: ", err)
@@ -2695,49 +2803,47 @@ func (p *GetSuccessorsResponse) writeField2(oprot thrift.TProtocol) (err error)
 		return thrift.PrependError("error writing map end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:HostsRefs: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
 	}
 	return err
 }
 
-func (p *GetSuccessorsResponse) String() string {
+func (p *LookupResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetSuccessorsResponse(%+v)", *p)
+	return fmt.Sprintf("LookupResponse(%+v)", *p)
 }
 
-// Used to store  GetPredecessor requests.
+// LookupEntry is a key to look up, along with the state of the
+// De Bruijn walk, as in LookupRequest.
 //
 // Attributes:
-//  - Context
-//  - Sig
-type GetPredecessorRequest struct {
-	Context *ContextInfo `thrift:"Context,1" json:"Context"`
-	Sig     []byte       `thrift:"Sig,2" json:"Sig"`
+//  - Key
+//  - KeyShift
+//  - ImaginaryNode
+type LookupEntry struct {
+	Key           []byte `thrift:"Key,1" json:"Key"`
+	KeyShift      []byte `thrift:"KeyShift,2" json:"KeyShift"`
+	ImaginaryNode []byte `thrift:"ImaginaryNode,3" json:"ImaginaryNode"`
 }
 
-func NewGetPredecessorRequest() *GetPredecessorRequest {
-	return &GetPredecessorRequest{}
+func NewLookupEntry() *LookupEntry {
+	return &LookupEntry{}
 }
 
-var GetPredecessorRequest_Context_DEFAULT *ContextInfo
-
-func (p *GetPredecessorRequest) GetContext() *ContextInfo {
-	if !p.IsSetContext() {
-		return GetPredecessorRequest_Context_DEFAULT
-	}
-	return p.Context
+func (p *LookupEntry) GetKey() []byte {
+	return p.Key
 }
 
-func (p *GetPredecessorRequest) GetSig() []byte {
-	return p.Sig
-}
-func (p *GetPredecessorRequest) IsSetContext() bool {
-	return p.Context != nil
+func (p *LookupEntry) GetKeyShift() []byte {
+	return p.KeyShift
 }
 
-func (p *GetPredecessorRequest) Read(iprot thrift.TProtocol) error {
+func (p *LookupEntry) GetImaginaryNode() []byte {
+	return p.ImaginaryNode
+}
+func (p *LookupEntry) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2759,6 +2865,10 @@ func (p *GetPredecessorRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2774,25 +2884,35 @@ func (p *GetPredecessorRequest) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetPredecessorRequest) readField1(iprot thrift.TProtocol) error {
-	p.Context = &ContextInfo{}
-	if err := p.Context.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+func (p *LookupEntry) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
 	}
 	return nil
 }
 
-func (p *GetPredecessorRequest) readField2(iprot thrift.TProtocol) error {
+func (p *LookupEntry) readField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Sig = v
+		p.KeyShift = v
 	}
 	return nil
 }
 
-func (p *GetPredecessorRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetPredecessorRequest"); err != nil {
+func (p *LookupEntry) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.ImaginaryNode = v
+	}
+	return nil
+}
+
+func (p *LookupEntry) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LookupEntry"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2801,6 +2921,9 @@ func (p *GetPredecessorRequest) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField2(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -2810,70 +2933,74 @@ func (p *GetPredecessorRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetPredecessorRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+func (p *LookupEntry) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
 	}
-	if err := p.Context.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	if err := oprot.WriteBinary(p.Key); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
 	}
 	return err
 }
 
-func (p *GetPredecessorRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Sig: ", p), err)
+func (p *LookupEntry) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("KeyShift", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:KeyShift: ", p), err)
 	}
-	if err := oprot.WriteBinary(p.Sig); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Sig (2) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.KeyShift); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.KeyShift (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Sig: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:KeyShift: ", p), err)
 	}
 	return err
 }
 
-func (p *GetPredecessorRequest) String() string {
+func (p *LookupEntry) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("ImaginaryNode", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:ImaginaryNode: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.ImaginaryNode); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ImaginaryNode (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:ImaginaryNode: ", p), err)
+	}
+	return err
+}
+
+func (p *LookupEntry) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetPredecessorRequest(%+v)", *p)
+	return fmt.Sprintf("LookupEntry(%+v)", *p)
 }
 
-// Used to store results when doing GetPredecessor requests.
+// LookupResult is the result of the lookup of a key.
 //
 // Attributes:
-//  - PredecessorNode
-//  - HostsRefs
-type GetPredecessorResponse struct {
-	PredecessorNode *NodeInfo            `thrift:"PredecessorNode,1" json:"PredecessorNode"`
-	HostsRefs       map[string]*HostInfo `thrift:"HostsRefs,2" json:"HostsRefs"`
+//  - Found
+//  - NodesPath
+type LookupResult struct {
+	Found     bool        `thrift:"Found,1" json:"Found"`
+	NodesPath []*NodeInfo `thrift:"NodesPath,2" json:"NodesPath"`
 }
 
-func NewGetPredecessorResponse() *GetPredecessorResponse {
-	return &GetPredecessorResponse{}
+func NewLookupResult() *LookupResult {
+	return &LookupResult{}
 }
 
-var GetPredecessorResponse_PredecessorNode_DEFAULT *NodeInfo
+func (p *LookupResult) GetFound() bool {
+	return p.Found
+}
 
-func (p *GetPredecessorResponse) GetPredecessorNode() *NodeInfo {
-	if !p.IsSetPredecessorNode() {
-		return GetPredecessorResponse_PredecessorNode_DEFAULT
-	}
-	return p.PredecessorNode
-}
-
-func (p *GetPredecessorResponse) GetHostsRefs() map[string]*HostInfo {
-	return p.HostsRefs
-}
-func (p *GetPredecessorResponse) IsSetPredecessorNode() bool {
-	return p.PredecessorNode != nil
+func (p *LookupResult) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
 }
-
-func (p *GetPredecessorResponse) Read(iprot thrift.TProtocol) error {
+func (p *LookupResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -2910,42 +3037,37 @@ func (p *GetPredecessorResponse) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetPredecessorResponse) readField1(iprot thrift.TProtocol) error {
-	p.PredecessorNode = &NodeInfo{}
-	if err := p.PredecessorNode.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PredecessorNode), err)
+func (p *LookupResult) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Found = v
 	}
 	return nil
 }
 
-func (p *GetPredecessorResponse) readField2(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
+func (p *LookupResult) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
 	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tMap := make(map[string]*HostInfo, size)
-	p.HostsRefs = tMap
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
 	for i := 0; i < size; i++ {
-		var _key12 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key12 = v
-		}
-		_val13 := &HostInfo{}
-		if err := _val13.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val13), err)
+		_elem9 := &NodeInfo{}
+		if err := _elem9.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem9), err)
 		}
-		p.HostsRefs[_key12] = _val13
+		p.NodesPath = append(p.NodesPath, _elem9)
 	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *GetPredecessorResponse) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetPredecessorResponse"); err != nil {
+func (p *LookupResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LookupResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -2963,93 +3085,86 @@ func (p *GetPredecessorResponse) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *GetPredecessorResponse) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("PredecessorNode", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:PredecessorNode: ", p), err)
+func (p *LookupResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Found", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Found: ", p), err)
 	}
-	if err := p.PredecessorNode.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PredecessorNode), err)
+	if err := oprot.WriteBool(bool(p.Found)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Found (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:PredecessorNode: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Found: ", p), err)
 	}
 	return err
 }
 
-func (p *GetPredecessorResponse) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:HostsRefs: ", p), err)
+func (p *LookupResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
 	}
-	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
-		return thrift.PrependError("error writing map begin: ", err)
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
 	}
-	for k, v := range p.HostsRefs {
-		if err := oprot.WriteString(string(k)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-		}
+	for _, v := range p.NodesPath {
 		if err := v.Write(oprot); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
 		}
 	}
-	if err := oprot.WriteMapEnd(); err != nil {
-		return thrift.PrependError("error writing map end: ", err)
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:HostsRefs: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
 	}
 	return err
 }
 
-func (p *GetPredecessorResponse) String() string {
+func (p *LookupResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("GetPredecessorResponse(%+v)", *p)
+	return fmt.Sprintf("LookupResult(%+v)", *p)
 }
 
-// Used to store Sync requests.
+// Used to store LookupMany requests. Each entry is looked up as
+// in Lookup, but entries which have the same next hop are
+// forwarded together, in a single call.
 //
 // Attributes:
 //  - Context
-//  - KeyShift
-//  - ImaginaryNode
+//  - Entries
 //  - Sig
-type SyncRequest struct {
-	Context       *ContextInfo `thrift:"Context,1" json:"Context"`
-	KeyShift      []byte       `thrift:"KeyShift,2" json:"KeyShift"`
-	ImaginaryNode []byte       `thrift:"ImaginaryNode,3" json:"ImaginaryNode"`
-	Sig           []byte       `thrift:"Sig,4" json:"Sig"`
+type LookupManyRequest struct {
+	Context *ContextInfo   `thrift:"Context,1" json:"Context"`
+	Entries []*LookupEntry `thrift:"Entries,2" json:"Entries"`
+	Sig     []byte         `thrift:"Sig,3" json:"Sig"`
 }
 
-func NewSyncRequest() *SyncRequest {
-	return &SyncRequest{}
+func NewLookupManyRequest() *LookupManyRequest {
+	return &LookupManyRequest{}
 }
 
-var SyncRequest_Context_DEFAULT *ContextInfo
+var LookupManyRequest_Context_DEFAULT *ContextInfo
 
-func (p *SyncRequest) GetContext() *ContextInfo {
+func (p *LookupManyRequest) GetContext() *ContextInfo {
 	if !p.IsSetContext() {
-		return SyncRequest_Context_DEFAULT
+		return LookupManyRequest_Context_DEFAULT
 	}
 	return p.Context
 }
 
-func (p *SyncRequest) GetKeyShift() []byte {
-	return p.KeyShift
-}
-
-func (p *SyncRequest) GetImaginaryNode() []byte {
-	return p.ImaginaryNode
+func (p *LookupManyRequest) GetEntries() []*LookupEntry {
+	return p.Entries
 }
 
-func (p *SyncRequest) GetSig() []byte {
+func (p *LookupManyRequest) GetSig() []byte {
 	return p.Sig
 }
-func (p *SyncRequest) IsSetContext() bool {
+func (p *LookupManyRequest) IsSetContext() bool {
 	return p.Context != nil
 }
 
-func (p *SyncRequest) Read(iprot thrift.TProtocol) error {
+func (p *LookupManyRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -3075,10 +3190,6 @@ func (p *SyncRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.readField3(iprot); err != nil {
 				return err
 			}
-		case 4:
-			if err := p.readField4(iprot); err != nil {
-				return err
-			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -3094,7 +3205,7 @@ func (p *SyncRequest) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *SyncRequest) readField1(iprot thrift.TProtocol) error {
+func (p *LookupManyRequest) readField1(iprot thrift.TProtocol) error {
 	p.Context = &ContextInfo{}
 	if err := p.Context.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
@@ -3102,35 +3213,37 @@ func (p *SyncRequest) readField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *SyncRequest) readField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.KeyShift = v
+func (p *LookupManyRequest) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	return nil
-}
-
-func (p *SyncRequest) readField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.ImaginaryNode = v
+	tSlice := make([]*LookupEntry, 0, size)
+	p.Entries = tSlice
+	for i := 0; i < size; i++ {
+		_elem10 := &LookupEntry{}
+		if err := _elem10.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem10), err)
+		}
+		p.Entries = append(p.Entries, _elem10)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *SyncRequest) readField4(iprot thrift.TProtocol) error {
+func (p *LookupManyRequest) readField3(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 4: ", err)
+		return thrift.PrependError("error reading field 3: ", err)
 	} else {
 		p.Sig = v
 	}
 	return nil
 }
 
-func (p *SyncRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("SyncRequest"); err != nil {
+func (p *LookupManyRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LookupManyRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -3142,9 +3255,6 @@ func (p *SyncRequest) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField3(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField4(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -3154,7 +3264,7 @@ func (p *SyncRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *SyncRequest) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *LookupManyRequest) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
 	}
@@ -3167,101 +3277,70 @@ func (p *SyncRequest) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *SyncRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("KeyShift", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:KeyShift: ", p), err)
-	}
-	if err := oprot.WriteBinary(p.KeyShift); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.KeyShift (2) field write error: ", p), err)
+func (p *LookupManyRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Entries", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Entries: ", p), err)
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:KeyShift: ", p), err)
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Entries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
 	}
-	return err
-}
-
-func (p *SyncRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("ImaginaryNode", thrift.STRING, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:ImaginaryNode: ", p), err)
+	for _, v := range p.Entries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
 	}
-	if err := oprot.WriteBinary(p.ImaginaryNode); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.ImaginaryNode (3) field write error: ", p), err)
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:ImaginaryNode: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Entries: ", p), err)
 	}
 	return err
 }
 
-func (p *SyncRequest) writeField4(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 4); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Sig: ", p), err)
+func (p *LookupManyRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Sig: ", p), err)
 	}
 	if err := oprot.WriteBinary(p.Sig); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Sig (4) field write error: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (3) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Sig: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Sig: ", p), err)
 	}
 	return err
 }
 
-func (p *SyncRequest) String() string {
+func (p *LookupManyRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("SyncRequest(%+v)", *p)
+	return fmt.Sprintf("LookupManyRequest(%+v)", *p)
 }
 
-// Used to store results when doing Sync requests.
+// Used to store results when doing LookupMany requests. Results
+// are indexed by key, as an hexadecimal string.
 //
 // Attributes:
-//  - Found
-//  - NodesPath
-//  - SuccessorNodes
-//  - PredecessorNode
+//  - Results
 //  - HostsRefs
-type SyncResponse struct {
-	Found           bool                 `thrift:"Found,1" json:"Found"`
-	NodesPath       []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
-	SuccessorNodes  []*NodeInfo          `thrift:"SuccessorNodes,3" json:"SuccessorNodes"`
-	PredecessorNode *NodeInfo            `thrift:"PredecessorNode,4" json:"PredecessorNode"`
-	HostsRefs       map[string]*HostInfo `thrift:"HostsRefs,5" json:"HostsRefs"`
-}
-
-func NewSyncResponse() *SyncResponse {
-	return &SyncResponse{}
-}
-
-func (p *SyncResponse) GetFound() bool {
-	return p.Found
-}
-
-func (p *SyncResponse) GetNodesPath() []*NodeInfo {
-	return p.NodesPath
+type LookupManyResponse struct {
+	Results   map[string]*LookupResult `thrift:"Results,1" json:"Results"`
+	HostsRefs map[string]*HostInfo     `thrift:"HostsRefs,2" json:"HostsRefs"`
 }
 
-func (p *SyncResponse) GetSuccessorNodes() []*NodeInfo {
-	return p.SuccessorNodes
+func NewLookupManyResponse() *LookupManyResponse {
+	return &LookupManyResponse{}
 }
 
-var SyncResponse_PredecessorNode_DEFAULT *NodeInfo
-
-func (p *SyncResponse) GetPredecessorNode() *NodeInfo {
-	if !p.IsSetPredecessorNode() {
-		return SyncResponse_PredecessorNode_DEFAULT
-	}
-	return p.PredecessorNode
+func (p *LookupManyResponse) GetResults() map[string]*LookupResult {
+	return p.Results
 }
 
-func (p *SyncResponse) GetHostsRefs() map[string]*HostInfo {
+func (p *LookupManyResponse) GetHostsRefs() map[string]*HostInfo {
 	return p.HostsRefs
 }
-func (p *SyncResponse) IsSetPredecessorNode() bool {
-	return p.PredecessorNode != nil
-}
-
-func (p *SyncResponse) Read(iprot thrift.TProtocol) error {
+func (p *LookupManyResponse) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -3283,18 +3362,6 @@ func (p *SyncResponse) Read(iprot thrift.TProtocol) error {
 			if err := p.readField2(iprot); err != nil {
 				return err
 			}
-		case 3:
-			if err := p.readField3(iprot); err != nil {
-				return err
-			}
-		case 4:
-			if err := p.readField4(iprot); err != nil {
-				return err
-			}
-		case 5:
-			if err := p.readField5(iprot); err != nil {
-				return err
-			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -3310,108 +3377,6989 @@ func (p *SyncResponse) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *SyncResponse) readField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.Found = v
-	}
-	return nil
-}
-
-func (p *SyncResponse) readField2(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
+func (p *LookupManyResponse) readField1(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
 	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
+		return thrift.PrependError("error reading map begin: ", err)
 	}
-	tSlice := make([]*NodeInfo, 0, size)
-	p.NodesPath = tSlice
+	tMap := make(map[string]*LookupResult, size)
+	p.Results = tMap
 	for i := 0; i < size; i++ {
-		_elem14 := &NodeInfo{}
-		if err := _elem14.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem14), err)
+		var _key11 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key11 = v
 		}
-		p.NodesPath = append(p.NodesPath, _elem14)
+		_val12 := &LookupResult{}
+		if err := _val12.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val12), err)
+		}
+		p.Results[_key11] = _val12
 	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
 	}
 	return nil
 }
 
-func (p *SyncResponse) readField3(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
+func (p *LookupManyResponse) readField2(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
 	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
+		return thrift.PrependError("error reading map begin: ", err)
 	}
-	tSlice := make([]*NodeInfo, 0, size)
-	p.SuccessorNodes = tSlice
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
 	for i := 0; i < size; i++ {
-		_elem15 := &NodeInfo{}
-		if err := _elem15.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem15), err)
+		var _key13 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key13 = v
 		}
-		p.SuccessorNodes = append(p.SuccessorNodes, _elem15)
+		_val14 := &HostInfo{}
+		if err := _val14.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val14), err)
+		}
+		p.HostsRefs[_key13] = _val14
 	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
 	}
 	return nil
 }
 
-func (p *SyncResponse) readField4(iprot thrift.TProtocol) error {
-	p.PredecessorNode = &NodeInfo{}
+func (p *LookupManyResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LookupManyResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *LookupManyResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Results", thrift.MAP, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Results: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.Results)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Results {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Results: ", p), err)
+	}
+	return err
+}
+
+func (p *LookupManyResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *LookupManyResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("LookupManyResponse(%+v)", *p)
+}
+
+// Used to store  GetSuccessors requests.
+//
+// Attributes:
+//  - Context
+//  - Sig
+type GetSuccessorsRequest struct {
+	Context *ContextInfo `thrift:"Context,1" json:"Context"`
+	Sig     []byte       `thrift:"Sig,2" json:"Sig"`
+}
+
+func NewGetSuccessorsRequest() *GetSuccessorsRequest {
+	return &GetSuccessorsRequest{}
+}
+
+var GetSuccessorsRequest_Context_DEFAULT *ContextInfo
+
+func (p *GetSuccessorsRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return GetSuccessorsRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *GetSuccessorsRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *GetSuccessorsRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *GetSuccessorsRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *GetSuccessorsRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *GetSuccessorsRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *GetSuccessorsRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetSuccessorsRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *GetSuccessorsRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *GetSuccessorsRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *GetSuccessorsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetSuccessorsRequest(%+v)", *p)
+}
+
+// Used to store results when doing GetSuccessors requests.
+//
+// Attributes:
+//  - SuccessorNodes
+//  - HostsRefs
+type GetSuccessorsResponse struct {
+	SuccessorNodes []*NodeInfo          `thrift:"SuccessorNodes,1" json:"SuccessorNodes"`
+	HostsRefs      map[string]*HostInfo `thrift:"HostsRefs,2" json:"HostsRefs"`
+}
+
+func NewGetSuccessorsResponse() *GetSuccessorsResponse {
+	return &GetSuccessorsResponse{}
+}
+
+func (p *GetSuccessorsResponse) GetSuccessorNodes() []*NodeInfo {
+	return p.SuccessorNodes
+}
+
+func (p *GetSuccessorsResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *GetSuccessorsResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *GetSuccessorsResponse) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.SuccessorNodes = tSlice
+	for i := 0; i < size; i++ {
+		_elem15 := &NodeInfo{}
+		if err := _elem15.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem15), err)
+		}
+		p.SuccessorNodes = append(p.SuccessorNodes, _elem15)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *GetSuccessorsResponse) readField2(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key16 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key16 = v
+		}
+		_val17 := &HostInfo{}
+		if err := _val17.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val17), err)
+		}
+		p.HostsRefs[_key16] = _val17
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *GetSuccessorsResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetSuccessorsResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *GetSuccessorsResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("SuccessorNodes", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:SuccessorNodes: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.SuccessorNodes)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.SuccessorNodes {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:SuccessorNodes: ", p), err)
+	}
+	return err
+}
+
+func (p *GetSuccessorsResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *GetSuccessorsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetSuccessorsResponse(%+v)", *p)
+}
+
+// Used to store  GetPredecessor requests.
+//
+// Attributes:
+//  - Context
+//  - Sig
+type GetPredecessorRequest struct {
+	Context *ContextInfo `thrift:"Context,1" json:"Context"`
+	Sig     []byte       `thrift:"Sig,2" json:"Sig"`
+}
+
+func NewGetPredecessorRequest() *GetPredecessorRequest {
+	return &GetPredecessorRequest{}
+}
+
+var GetPredecessorRequest_Context_DEFAULT *ContextInfo
+
+func (p *GetPredecessorRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return GetPredecessorRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *GetPredecessorRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *GetPredecessorRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *GetPredecessorRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *GetPredecessorRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *GetPredecessorRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *GetPredecessorRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetPredecessorRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *GetPredecessorRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *GetPredecessorRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *GetPredecessorRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetPredecessorRequest(%+v)", *p)
+}
+
+// Used to store results when doing GetPredecessor requests.
+//
+// Attributes:
+//  - PredecessorNode
+//  - HostsRefs
+type GetPredecessorResponse struct {
+	PredecessorNode *NodeInfo            `thrift:"PredecessorNode,1" json:"PredecessorNode,omitempty"`
+	HostsRefs       map[string]*HostInfo `thrift:"HostsRefs,2" json:"HostsRefs"`
+}
+
+func NewGetPredecessorResponse() *GetPredecessorResponse {
+	return &GetPredecessorResponse{}
+}
+
+var GetPredecessorResponse_PredecessorNode_DEFAULT *NodeInfo
+
+func (p *GetPredecessorResponse) GetPredecessorNode() *NodeInfo {
+	if !p.IsSetPredecessorNode() {
+		return GetPredecessorResponse_PredecessorNode_DEFAULT
+	}
+	return p.PredecessorNode
+}
+
+func (p *GetPredecessorResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *GetPredecessorResponse) IsSetPredecessorNode() bool {
+	return p.PredecessorNode != nil
+}
+
+func (p *GetPredecessorResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *GetPredecessorResponse) readField1(iprot thrift.TProtocol) error {
+	p.PredecessorNode = &NodeInfo{}
+	if err := p.PredecessorNode.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PredecessorNode), err)
+	}
+	return nil
+}
+
+func (p *GetPredecessorResponse) readField2(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key18 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key18 = v
+		}
+		_val19 := &HostInfo{}
+		if err := _val19.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val19), err)
+		}
+		p.HostsRefs[_key18] = _val19
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *GetPredecessorResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetPredecessorResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *GetPredecessorResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPredecessorNode() {
+		if err := oprot.WriteFieldBegin("PredecessorNode", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:PredecessorNode: ", p), err)
+		}
+		if err := p.PredecessorNode.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PredecessorNode), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:PredecessorNode: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *GetPredecessorResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *GetPredecessorResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetPredecessorResponse(%+v)", *p)
+}
+
+// Used to store Sync requests.
+//
+// Attributes:
+//  - Context
+//  - KeyShift
+//  - ImaginaryNode
+//  - Sig
+type SyncRequest struct {
+	Context       *ContextInfo `thrift:"Context,1" json:"Context"`
+	KeyShift      []byte       `thrift:"KeyShift,2" json:"KeyShift"`
+	ImaginaryNode []byte       `thrift:"ImaginaryNode,3" json:"ImaginaryNode"`
+	Sig           []byte       `thrift:"Sig,4" json:"Sig"`
+}
+
+func NewSyncRequest() *SyncRequest {
+	return &SyncRequest{}
+}
+
+var SyncRequest_Context_DEFAULT *ContextInfo
+
+func (p *SyncRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return SyncRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *SyncRequest) GetKeyShift() []byte {
+	return p.KeyShift
+}
+
+func (p *SyncRequest) GetImaginaryNode() []byte {
+	return p.ImaginaryNode
+}
+
+func (p *SyncRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *SyncRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *SyncRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *SyncRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *SyncRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.KeyShift = v
+	}
+	return nil
+}
+
+func (p *SyncRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.ImaginaryNode = v
+	}
+	return nil
+}
+
+func (p *SyncRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *SyncRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SyncRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SyncRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *SyncRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("KeyShift", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:KeyShift: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.KeyShift); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.KeyShift (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:KeyShift: ", p), err)
+	}
+	return err
+}
+
+func (p *SyncRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("ImaginaryNode", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:ImaginaryNode: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.ImaginaryNode); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ImaginaryNode (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:ImaginaryNode: ", p), err)
+	}
+	return err
+}
+
+func (p *SyncRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *SyncRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SyncRequest(%+v)", *p)
+}
+
+// Used to store results when doing Sync requests.
+//
+// Attributes:
+//  - Found
+//  - NodesPath
+//  - SuccessorNodes
+//  - PredecessorNode
+//  - HostsRefs
+type SyncResponse struct {
+	Found           bool                 `thrift:"Found,1" json:"Found"`
+	NodesPath       []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	SuccessorNodes  []*NodeInfo          `thrift:"SuccessorNodes,3" json:"SuccessorNodes"`
+	PredecessorNode *NodeInfo            `thrift:"PredecessorNode,4" json:"PredecessorNode,omitempty"`
+	HostsRefs       map[string]*HostInfo `thrift:"HostsRefs,5" json:"HostsRefs"`
+}
+
+func NewSyncResponse() *SyncResponse {
+	return &SyncResponse{}
+}
+
+func (p *SyncResponse) GetFound() bool {
+	return p.Found
+}
+
+func (p *SyncResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *SyncResponse) GetSuccessorNodes() []*NodeInfo {
+	return p.SuccessorNodes
+}
+
+var SyncResponse_PredecessorNode_DEFAULT *NodeInfo
+
+func (p *SyncResponse) GetPredecessorNode() *NodeInfo {
+	if !p.IsSetPredecessorNode() {
+		return SyncResponse_PredecessorNode_DEFAULT
+	}
+	return p.PredecessorNode
+}
+
+func (p *SyncResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *SyncResponse) IsSetPredecessorNode() bool {
+	return p.PredecessorNode != nil
+}
+
+func (p *SyncResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *SyncResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Found = v
+	}
+	return nil
+}
+
+func (p *SyncResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem20 := &NodeInfo{}
+		if err := _elem20.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem20), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem20)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *SyncResponse) readField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.SuccessorNodes = tSlice
+	for i := 0; i < size; i++ {
+		_elem21 := &NodeInfo{}
+		if err := _elem21.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem21), err)
+		}
+		p.SuccessorNodes = append(p.SuccessorNodes, _elem21)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *SyncResponse) readField4(iprot thrift.TProtocol) error {
+	p.PredecessorNode = &NodeInfo{}
+	if err := p.PredecessorNode.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PredecessorNode), err)
+	}
+	return nil
+}
+
+func (p *SyncResponse) readField5(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key22 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key22 = v
+		}
+		_val23 := &HostInfo{}
+		if err := _val23.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val23), err)
+		}
+		p.HostsRefs[_key22] = _val23
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *SyncResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SyncResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SyncResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Found", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Found: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Found)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Found (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Found: ", p), err)
+	}
+	return err
+}
+
+func (p *SyncResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *SyncResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("SuccessorNodes", thrift.LIST, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:SuccessorNodes: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.SuccessorNodes)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.SuccessorNodes {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:SuccessorNodes: ", p), err)
+	}
+	return err
+}
+
+func (p *SyncResponse) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPredecessorNode() {
+		if err := oprot.WriteFieldBegin("PredecessorNode", thrift.STRUCT, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:PredecessorNode: ", p), err)
+		}
+		if err := p.PredecessorNode.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PredecessorNode), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:PredecessorNode: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *SyncResponse) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *SyncResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SyncResponse(%+v)", *p)
+}
+
+// Used to store Put requests. If Replica is false, the request is
+// routed to the node holding the key, which then replicates it
+// on its successors. If Replica is true, the value is stored
+// on the target node, as is.
+//
+// Attributes:
+//  - Context
+//  - Key
+//  - Value
+//  - Replica
+//  - Sig
+type PutRequest struct {
+	Context *ContextInfo `thrift:"Context,1" json:"Context"`
+	Key     []byte       `thrift:"Key,2" json:"Key"`
+	Value   []byte       `thrift:"Value,3" json:"Value"`
+	Replica bool         `thrift:"Replica,4" json:"Replica"`
+	Sig     []byte       `thrift:"Sig,5" json:"Sig"`
+}
+
+func NewPutRequest() *PutRequest {
+	return &PutRequest{}
+}
+
+var PutRequest_Context_DEFAULT *ContextInfo
+
+func (p *PutRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return PutRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *PutRequest) GetKey() []byte {
+	return p.Key
+}
+
+func (p *PutRequest) GetValue() []byte {
+	return p.Value
+}
+
+func (p *PutRequest) GetReplica() bool {
+	return p.Replica
+}
+
+func (p *PutRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *PutRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *PutRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *PutRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *PutRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *PutRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Value = v
+	}
+	return nil
+}
+
+func (p *PutRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Replica = v
+	}
+	return nil
+}
+
+func (p *PutRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *PutRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("PutRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *PutRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *PutRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Key", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Key: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Key); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *PutRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Value", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Value: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Value); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Value (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Value: ", p), err)
+	}
+	return err
+}
+
+func (p *PutRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Replica", thrift.BOOL, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Replica: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Replica)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Replica (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Replica: ", p), err)
+	}
+	return err
+}
+
+func (p *PutRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *PutRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PutRequest(%+v)", *p)
+}
+
+// Used to store results when doing Put requests.
+//
+// Attributes:
+//  - NbCopy
+//  - NodesPath
+//  - HostsRefs
+type PutResponse struct {
+	NbCopy    int32                `thrift:"NbCopy,1" json:"NbCopy"`
+	NodesPath []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
+}
+
+func NewPutResponse() *PutResponse {
+	return &PutResponse{}
+}
+
+func (p *PutResponse) GetNbCopy() int32 {
+	return p.NbCopy
+}
+
+func (p *PutResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *PutResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *PutResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *PutResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NbCopy = v
+	}
+	return nil
+}
+
+func (p *PutResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem24 := &NodeInfo{}
+		if err := _elem24.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem24), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem24)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *PutResponse) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key25 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key25 = v
+		}
+		_val26 := &HostInfo{}
+		if err := _val26.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val26), err)
+		}
+		p.HostsRefs[_key25] = _val26
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *PutResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("PutResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *PutResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NbCopy", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:NbCopy: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.NbCopy)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NbCopy (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:NbCopy: ", p), err)
+	}
+	return err
+}
+
+func (p *PutResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *PutResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *PutResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PutResponse(%+v)", *p)
+}
+
+// Used to store Get requests. If Replica is true, only the
+// target node local store is searched.
+//
+// Attributes:
+//  - Context
+//  - Key
+//  - Replica
+//  - Sig
+type GetRequest struct {
+	Context *ContextInfo `thrift:"Context,1" json:"Context"`
+	Key     []byte       `thrift:"Key,2" json:"Key"`
+	Replica bool         `thrift:"Replica,3" json:"Replica"`
+	Sig     []byte       `thrift:"Sig,4" json:"Sig"`
+}
+
+func NewGetRequest() *GetRequest {
+	return &GetRequest{}
+}
+
+var GetRequest_Context_DEFAULT *ContextInfo
+
+func (p *GetRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return GetRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *GetRequest) GetKey() []byte {
+	return p.Key
+}
+
+func (p *GetRequest) GetReplica() bool {
+	return p.Replica
+}
+
+func (p *GetRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *GetRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *GetRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *GetRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *GetRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *GetRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Replica = v
+	}
+	return nil
+}
+
+func (p *GetRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *GetRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *GetRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *GetRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Key", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Key: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Key); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *GetRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Replica", thrift.BOOL, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Replica: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Replica)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Replica (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Replica: ", p), err)
+	}
+	return err
+}
+
+func (p *GetRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *GetRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetRequest(%+v)", *p)
+}
+
+// Used to store results when doing Get requests.
+//
+// Attributes:
+//  - Found
+//  - Value
+//  - NodesPath
+//  - HostsRefs
+type GetResponse struct {
+	Found     bool                 `thrift:"Found,1" json:"Found"`
+	Value     []byte               `thrift:"Value,2" json:"Value"`
+	NodesPath []*NodeInfo          `thrift:"NodesPath,3" json:"NodesPath"`
+	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,4" json:"HostsRefs"`
+}
+
+func NewGetResponse() *GetResponse {
+	return &GetResponse{}
+}
+
+func (p *GetResponse) GetFound() bool {
+	return p.Found
+}
+
+func (p *GetResponse) GetValue() []byte {
+	return p.Value
+}
+
+func (p *GetResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *GetResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *GetResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *GetResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Found = v
+	}
+	return nil
+}
+
+func (p *GetResponse) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Value = v
+	}
+	return nil
+}
+
+func (p *GetResponse) readField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem27 := &NodeInfo{}
+		if err := _elem27.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem27), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem27)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *GetResponse) readField4(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key28 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key28 = v
+		}
+		_val29 := &HostInfo{}
+		if err := _val29.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val29), err)
+		}
+		p.HostsRefs[_key28] = _val29
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *GetResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *GetResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Found", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Found: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Found)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Found (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Found: ", p), err)
+	}
+	return err
+}
+
+func (p *GetResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Value", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Value: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Value); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Value (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Value: ", p), err)
+	}
+	return err
+}
+
+func (p *GetResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *GetResponse) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *GetResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetResponse(%+v)", *p)
+}
+
+// Used to store Delete requests. If Replica is true, the key
+// is only removed from the target node local store.
+//
+// Attributes:
+//  - Context
+//  - Key
+//  - Replica
+//  - Sig
+type DeleteRequest struct {
+	Context *ContextInfo `thrift:"Context,1" json:"Context"`
+	Key     []byte       `thrift:"Key,2" json:"Key"`
+	Replica bool         `thrift:"Replica,3" json:"Replica"`
+	Sig     []byte       `thrift:"Sig,4" json:"Sig"`
+}
+
+func NewDeleteRequest() *DeleteRequest {
+	return &DeleteRequest{}
+}
+
+var DeleteRequest_Context_DEFAULT *ContextInfo
+
+func (p *DeleteRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return DeleteRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *DeleteRequest) GetKey() []byte {
+	return p.Key
+}
+
+func (p *DeleteRequest) GetReplica() bool {
+	return p.Replica
+}
+
+func (p *DeleteRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *DeleteRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *DeleteRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DeleteRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *DeleteRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *DeleteRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Replica = v
+	}
+	return nil
+}
+
+func (p *DeleteRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *DeleteRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeleteRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DeleteRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Key", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Key: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Key); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Replica", thrift.BOOL, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Replica: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Replica)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Replica (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Replica: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeleteRequest(%+v)", *p)
+}
+
+// Used to store results when doing Delete requests.
+//
+// Attributes:
+//  - NbCopy
+//  - NodesPath
+//  - HostsRefs
+type DeleteResponse struct {
+	NbCopy    int32                `thrift:"NbCopy,1" json:"NbCopy"`
+	NodesPath []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
+}
+
+func NewDeleteResponse() *DeleteResponse {
+	return &DeleteResponse{}
+}
+
+func (p *DeleteResponse) GetNbCopy() int32 {
+	return p.NbCopy
+}
+
+func (p *DeleteResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *DeleteResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *DeleteResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DeleteResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NbCopy = v
+	}
+	return nil
+}
+
+func (p *DeleteResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem30 := &NodeInfo{}
+		if err := _elem30.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem30), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem30)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *DeleteResponse) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key31 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key31 = v
+		}
+		_val32 := &HostInfo{}
+		if err := _val32.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val32), err)
+		}
+		p.HostsRefs[_key31] = _val32
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *DeleteResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeleteResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DeleteResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NbCopy", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:NbCopy: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.NbCopy)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NbCopy (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:NbCopy: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeleteResponse(%+v)", *p)
+}
+
+// DataEntry is a stored key/value pair, TTL being the number
+// of seconds before it expires.
+//
+// Attributes:
+//  - Key
+//  - Value
+//  - TTL
+type DataEntry struct {
+	Key   []byte `thrift:"Key,1" json:"Key"`
+	Value []byte `thrift:"Value,2" json:"Value"`
+	TTL   int32  `thrift:"TTL,3" json:"TTL"`
+}
+
+func NewDataEntry() *DataEntry {
+	return &DataEntry{}
+}
+
+func (p *DataEntry) GetKey() []byte {
+	return p.Key
+}
+
+func (p *DataEntry) GetValue() []byte {
+	return p.Value
+}
+
+func (p *DataEntry) GetTTL() int32 {
+	return p.TTL
+}
+func (p *DataEntry) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DataEntry) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *DataEntry) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Value = v
+	}
+	return nil
+}
+
+func (p *DataEntry) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.TTL = v
+	}
+	return nil
+}
+
+func (p *DataEntry) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DataEntry"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DataEntry) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Key); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *DataEntry) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Value", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Value: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Value); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Value (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Value: ", p), err)
+	}
+	return err
+}
+
+func (p *DataEntry) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("TTL", thrift.I32, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:TTL: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.TTL)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.TTL (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:TTL: ", p), err)
+	}
+	return err
+}
+
+func (p *DataEntry) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DataEntry(%+v)", *p)
+}
+
+// DataDigest identifies the value stored for a key, Hash
+// being the checksum of the value.
+//
+// Attributes:
+//  - Key
+//  - Hash
+type DataDigest struct {
+	Key  []byte `thrift:"Key,1" json:"Key"`
+	Hash []byte `thrift:"Hash,2" json:"Hash"`
+}
+
+func NewDataDigest() *DataDigest {
+	return &DataDigest{}
+}
+
+func (p *DataDigest) GetKey() []byte {
+	return p.Key
+}
+
+func (p *DataDigest) GetHash() []byte {
+	return p.Hash
+}
+func (p *DataDigest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DataDigest) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Key = v
+	}
+	return nil
+}
+
+func (p *DataDigest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Hash = v
+	}
+	return nil
+}
+
+func (p *DataDigest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DataDigest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DataDigest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Key", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Key: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Key); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Key (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Key: ", p), err)
+	}
+	return err
+}
+
+func (p *DataDigest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Hash", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Hash: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Hash); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Hash (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Hash: ", p), err)
+	}
+	return err
+}
+
+func (p *DataDigest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DataDigest(%+v)", *p)
+}
+
+// MerkleNode is a node of the Merkle tree of a store. Prefix is
+// the path to the node, one byte per level, each byte being the
+// index of a child. Inner nodes give the hashes of their children,
+// leaves give the digests of the entries they contain.
+//
+// Attributes:
+//  - Prefix
+//  - Hash
+//  - Children
+//  - Digests
+type MerkleNode struct {
+	Prefix   []byte        `thrift:"Prefix,1" json:"Prefix"`
+	Hash     []byte        `thrift:"Hash,2" json:"Hash"`
+	Children [][]byte      `thrift:"Children,3" json:"Children"`
+	Digests  []*DataDigest `thrift:"Digests,4" json:"Digests"`
+}
+
+func NewMerkleNode() *MerkleNode {
+	return &MerkleNode{}
+}
+
+func (p *MerkleNode) GetPrefix() []byte {
+	return p.Prefix
+}
+
+func (p *MerkleNode) GetHash() []byte {
+	return p.Hash
+}
+
+func (p *MerkleNode) GetChildren() [][]byte {
+	return p.Children
+}
+
+func (p *MerkleNode) GetDigests() []*DataDigest {
+	return p.Digests
+}
+func (p *MerkleNode) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *MerkleNode) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Prefix = v
+	}
+	return nil
+}
+
+func (p *MerkleNode) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Hash = v
+	}
+	return nil
+}
+
+func (p *MerkleNode) readField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([][]byte, 0, size)
+	p.Children = tSlice
+	for i := 0; i < size; i++ {
+		var _elem33 []byte
+		if v, err := iprot.ReadBinary(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem33 = v
+		}
+		p.Children = append(p.Children, _elem33)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleNode) readField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*DataDigest, 0, size)
+	p.Digests = tSlice
+	for i := 0; i < size; i++ {
+		_elem34 := &DataDigest{}
+		if err := _elem34.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem34), err)
+		}
+		p.Digests = append(p.Digests, _elem34)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleNode) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("MerkleNode"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleNode) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Prefix", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Prefix: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Prefix); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Prefix (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Prefix: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleNode) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Hash", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Hash: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Hash); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Hash (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Hash: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleNode) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Children", thrift.LIST, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Children: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.Children)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Children {
+		if err := oprot.WriteBinary(v); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Children: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleNode) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Digests", thrift.LIST, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Digests: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Digests)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Digests {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Digests: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleNode) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("MerkleNode(%+v)", *p)
+}
+
+// Used to store Merkle requests. The target node stores Entries,
+// as replicas, then builds the Merkle tree of the keys it holds
+// within (RangeStart,RangeEnd], and returns the nodes at Prefixes,
+// along with the entries for WantedKeys. Nodes use this to repair
+// their replicas, transferring only the entries which differ.
+//
+// Attributes:
+//  - Context
+//  - RangeStart
+//  - RangeEnd
+//  - Prefixes
+//  - Entries
+//  - WantedKeys
+//  - Sig
+type MerkleRequest struct {
+	Context    *ContextInfo `thrift:"Context,1" json:"Context"`
+	RangeStart []byte       `thrift:"RangeStart,2" json:"RangeStart"`
+	RangeEnd   []byte       `thrift:"RangeEnd,3" json:"RangeEnd"`
+	Prefixes   [][]byte     `thrift:"Prefixes,4" json:"Prefixes"`
+	Entries    []*DataEntry `thrift:"Entries,5" json:"Entries"`
+	WantedKeys [][]byte     `thrift:"WantedKeys,6" json:"WantedKeys"`
+	Sig        []byte       `thrift:"Sig,7" json:"Sig"`
+}
+
+func NewMerkleRequest() *MerkleRequest {
+	return &MerkleRequest{}
+}
+
+var MerkleRequest_Context_DEFAULT *ContextInfo
+
+func (p *MerkleRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return MerkleRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *MerkleRequest) GetRangeStart() []byte {
+	return p.RangeStart
+}
+
+func (p *MerkleRequest) GetRangeEnd() []byte {
+	return p.RangeEnd
+}
+
+func (p *MerkleRequest) GetPrefixes() [][]byte {
+	return p.Prefixes
+}
+
+func (p *MerkleRequest) GetEntries() []*DataEntry {
+	return p.Entries
+}
+
+func (p *MerkleRequest) GetWantedKeys() [][]byte {
+	return p.WantedKeys
+}
+
+func (p *MerkleRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *MerkleRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *MerkleRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *MerkleRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *MerkleRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.RangeStart = v
+	}
+	return nil
+}
+
+func (p *MerkleRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.RangeEnd = v
+	}
+	return nil
+}
+
+func (p *MerkleRequest) readField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([][]byte, 0, size)
+	p.Prefixes = tSlice
+	for i := 0; i < size; i++ {
+		var _elem35 []byte
+		if v, err := iprot.ReadBinary(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem35 = v
+		}
+		p.Prefixes = append(p.Prefixes, _elem35)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleRequest) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*DataEntry, 0, size)
+	p.Entries = tSlice
+	for i := 0; i < size; i++ {
+		_elem36 := &DataEntry{}
+		if err := _elem36.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem36), err)
+		}
+		p.Entries = append(p.Entries, _elem36)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleRequest) readField6(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([][]byte, 0, size)
+	p.WantedKeys = tSlice
+	for i := 0; i < size; i++ {
+		var _elem37 []byte
+		if v, err := iprot.ReadBinary(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem37 = v
+		}
+		p.WantedKeys = append(p.WantedKeys, _elem37)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleRequest) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *MerkleRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("MerkleRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("RangeStart", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:RangeStart: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.RangeStart); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.RangeStart (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:RangeStart: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("RangeEnd", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:RangeEnd: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.RangeEnd); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.RangeEnd (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:RangeEnd: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Prefixes", thrift.LIST, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Prefixes: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.Prefixes)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Prefixes {
+		if err := oprot.WriteBinary(v); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Prefixes: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Entries", thrift.LIST, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Entries: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Entries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Entries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Entries: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("WantedKeys", thrift.LIST, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:WantedKeys: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.WantedKeys)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.WantedKeys {
+		if err := oprot.WriteBinary(v); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:WantedKeys: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 7); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (7) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 7:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("MerkleRequest(%+v)", *p)
+}
+
+// Used to store results when doing Merkle requests.
+//
+// Attributes:
+//  - Nodes
+//  - Entries
+type MerkleResponse struct {
+	Nodes   []*MerkleNode `thrift:"Nodes,1" json:"Nodes"`
+	Entries []*DataEntry  `thrift:"Entries,2" json:"Entries"`
+}
+
+func NewMerkleResponse() *MerkleResponse {
+	return &MerkleResponse{}
+}
+
+func (p *MerkleResponse) GetNodes() []*MerkleNode {
+	return p.Nodes
+}
+
+func (p *MerkleResponse) GetEntries() []*DataEntry {
+	return p.Entries
+}
+func (p *MerkleResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *MerkleResponse) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*MerkleNode, 0, size)
+	p.Nodes = tSlice
+	for i := 0; i < size; i++ {
+		_elem38 := &MerkleNode{}
+		if err := _elem38.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem38), err)
+		}
+		p.Nodes = append(p.Nodes, _elem38)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*DataEntry, 0, size)
+	p.Entries = tSlice
+	for i := 0; i < size; i++ {
+		_elem39 := &DataEntry{}
+		if err := _elem39.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem39), err)
+		}
+		p.Entries = append(p.Entries, _elem39)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("MerkleResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *MerkleResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Nodes", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Nodes: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Nodes)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Nodes {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Nodes: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Entries", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Entries: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Entries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Entries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Entries: ", p), err)
+	}
+	return err
+}
+
+func (p *MerkleResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("MerkleResponse(%+v)", *p)
+}
+
+// Used to store Subscribe requests. The topic is hashed into a key,
+// and the node holding that key keeps the list of subscribers. If
+// Replica is false, the request is routed to that node, which then
+// replicates the subscription on its successors. If Replica is true,
+// the subscription is stored on the target node, as is. Subscriptions
+// expire after DataLifetime, just like data, subscribers renew them
+// by subscribing again.
+//
+// Attributes:
+//  - Context
+//  - Topic
+//  - Subscriber
+//  - Replica
+//  - Sig
+type SubscribeRequest struct {
+	Context    *ContextInfo `thrift:"Context,1" json:"Context"`
+	Topic      string       `thrift:"Topic,2" json:"Topic"`
+	Subscriber *NodeInfo    `thrift:"Subscriber,3" json:"Subscriber"`
+	Replica    bool         `thrift:"Replica,4" json:"Replica"`
+	Sig        []byte       `thrift:"Sig,5" json:"Sig"`
+}
+
+func NewSubscribeRequest() *SubscribeRequest {
+	return &SubscribeRequest{}
+}
+
+var SubscribeRequest_Context_DEFAULT *ContextInfo
+
+func (p *SubscribeRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return SubscribeRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *SubscribeRequest) GetTopic() string {
+	return p.Topic
+}
+
+var SubscribeRequest_Subscriber_DEFAULT *NodeInfo
+
+func (p *SubscribeRequest) GetSubscriber() *NodeInfo {
+	if !p.IsSetSubscriber() {
+		return SubscribeRequest_Subscriber_DEFAULT
+	}
+	return p.Subscriber
+}
+
+func (p *SubscribeRequest) GetReplica() bool {
+	return p.Replica
+}
+
+func (p *SubscribeRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *SubscribeRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *SubscribeRequest) IsSetSubscriber() bool {
+	return p.Subscriber != nil
+}
+
+func (p *SubscribeRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *SubscribeRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *SubscribeRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Topic = v
+	}
+	return nil
+}
+
+func (p *SubscribeRequest) readField3(iprot thrift.TProtocol) error {
+	p.Subscriber = &NodeInfo{}
+	if err := p.Subscriber.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Subscriber), err)
+	}
+	return nil
+}
+
+func (p *SubscribeRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Replica = v
+	}
+	return nil
+}
+
+func (p *SubscribeRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *SubscribeRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SubscribeRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SubscribeRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *SubscribeRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Topic", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Topic: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Topic)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Topic (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Topic: ", p), err)
+	}
+	return err
+}
+
+func (p *SubscribeRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Subscriber", thrift.STRUCT, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Subscriber: ", p), err)
+	}
+	if err := p.Subscriber.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Subscriber), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Subscriber: ", p), err)
+	}
+	return err
+}
+
+func (p *SubscribeRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Replica", thrift.BOOL, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Replica: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Replica)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Replica (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Replica: ", p), err)
+	}
+	return err
+}
+
+func (p *SubscribeRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *SubscribeRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SubscribeRequest(%+v)", *p)
+}
+
+// Used to store results when doing Subscribe requests.
+//
+// Attributes:
+//  - NbCopy
+//  - NodesPath
+//  - HostsRefs
+type SubscribeResponse struct {
+	NbCopy    int32                `thrift:"NbCopy,1" json:"NbCopy"`
+	NodesPath []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
+}
+
+func NewSubscribeResponse() *SubscribeResponse {
+	return &SubscribeResponse{}
+}
+
+func (p *SubscribeResponse) GetNbCopy() int32 {
+	return p.NbCopy
+}
+
+func (p *SubscribeResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *SubscribeResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *SubscribeResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *SubscribeResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NbCopy = v
+	}
+	return nil
+}
+
+func (p *SubscribeResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem40 := &NodeInfo{}
+		if err := _elem40.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem40), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem40)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *SubscribeResponse) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key41 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key41 = v
+		}
+		_val42 := &HostInfo{}
+		if err := _val42.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val42), err)
+		}
+		p.HostsRefs[_key41] = _val42
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *SubscribeResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SubscribeResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SubscribeResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NbCopy", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:NbCopy: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.NbCopy)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NbCopy (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:NbCopy: ", p), err)
+	}
+	return err
+}
+
+func (p *SubscribeResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *SubscribeResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *SubscribeResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SubscribeResponse(%+v)", *p)
+}
+
+// Used to store Unsubscribe requests. If Replica is true, the
+// subscription is only removed from the target node.
+//
+// Attributes:
+//  - Context
+//  - Topic
+//  - Subscriber
+//  - Replica
+//  - Sig
+type UnsubscribeRequest struct {
+	Context    *ContextInfo `thrift:"Context,1" json:"Context"`
+	Topic      string       `thrift:"Topic,2" json:"Topic"`
+	Subscriber *NodeInfo    `thrift:"Subscriber,3" json:"Subscriber"`
+	Replica    bool         `thrift:"Replica,4" json:"Replica"`
+	Sig        []byte       `thrift:"Sig,5" json:"Sig"`
+}
+
+func NewUnsubscribeRequest() *UnsubscribeRequest {
+	return &UnsubscribeRequest{}
+}
+
+var UnsubscribeRequest_Context_DEFAULT *ContextInfo
+
+func (p *UnsubscribeRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return UnsubscribeRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *UnsubscribeRequest) GetTopic() string {
+	return p.Topic
+}
+
+var UnsubscribeRequest_Subscriber_DEFAULT *NodeInfo
+
+func (p *UnsubscribeRequest) GetSubscriber() *NodeInfo {
+	if !p.IsSetSubscriber() {
+		return UnsubscribeRequest_Subscriber_DEFAULT
+	}
+	return p.Subscriber
+}
+
+func (p *UnsubscribeRequest) GetReplica() bool {
+	return p.Replica
+}
+
+func (p *UnsubscribeRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *UnsubscribeRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *UnsubscribeRequest) IsSetSubscriber() bool {
+	return p.Subscriber != nil
+}
+
+func (p *UnsubscribeRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *UnsubscribeRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *UnsubscribeRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Topic = v
+	}
+	return nil
+}
+
+func (p *UnsubscribeRequest) readField3(iprot thrift.TProtocol) error {
+	p.Subscriber = &NodeInfo{}
+	if err := p.Subscriber.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Subscriber), err)
+	}
+	return nil
+}
+
+func (p *UnsubscribeRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Replica = v
+	}
+	return nil
+}
+
+func (p *UnsubscribeRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *UnsubscribeRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("UnsubscribeRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *UnsubscribeRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *UnsubscribeRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Topic", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Topic: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Topic)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Topic (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Topic: ", p), err)
+	}
+	return err
+}
+
+func (p *UnsubscribeRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Subscriber", thrift.STRUCT, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Subscriber: ", p), err)
+	}
+	if err := p.Subscriber.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Subscriber), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Subscriber: ", p), err)
+	}
+	return err
+}
+
+func (p *UnsubscribeRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Replica", thrift.BOOL, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Replica: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Replica)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Replica (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Replica: ", p), err)
+	}
+	return err
+}
+
+func (p *UnsubscribeRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *UnsubscribeRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UnsubscribeRequest(%+v)", *p)
+}
+
+// Used to store results when doing Unsubscribe requests.
+//
+// Attributes:
+//  - NbCopy
+//  - NodesPath
+//  - HostsRefs
+type UnsubscribeResponse struct {
+	NbCopy    int32                `thrift:"NbCopy,1" json:"NbCopy"`
+	NodesPath []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
+}
+
+func NewUnsubscribeResponse() *UnsubscribeResponse {
+	return &UnsubscribeResponse{}
+}
+
+func (p *UnsubscribeResponse) GetNbCopy() int32 {
+	return p.NbCopy
+}
+
+func (p *UnsubscribeResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *UnsubscribeResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *UnsubscribeResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *UnsubscribeResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NbCopy = v
+	}
+	return nil
+}
+
+func (p *UnsubscribeResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem43 := &NodeInfo{}
+		if err := _elem43.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem43), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem43)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *UnsubscribeResponse) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key44 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key44 = v
+		}
+		_val45 := &HostInfo{}
+		if err := _val45.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val45), err)
+		}
+		p.HostsRefs[_key44] = _val45
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *UnsubscribeResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("UnsubscribeResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *UnsubscribeResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NbCopy", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:NbCopy: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.NbCopy)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NbCopy (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:NbCopy: ", p), err)
+	}
+	return err
+}
+
+func (p *UnsubscribeResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *UnsubscribeResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *UnsubscribeResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("UnsubscribeResponse(%+v)", *p)
+}
+
+// Used to store Publish requests. If Deliver is false, the request
+// is routed to the node holding the topic key, which sends the
+// message to all subscribers, with Deliver set to true. If Deliver
+// is true, the message is handed to the target node, if it is
+// subscribed to the topic.
+//
+// Attributes:
+//  - Context
+//  - Topic
+//  - Message
+//  - Deliver
+//  - Sig
+type PublishRequest struct {
+	Context *ContextInfo `thrift:"Context,1" json:"Context"`
+	Topic   string       `thrift:"Topic,2" json:"Topic"`
+	Message []byte       `thrift:"Message,3" json:"Message"`
+	Deliver bool         `thrift:"Deliver,4" json:"Deliver"`
+	Sig     []byte       `thrift:"Sig,5" json:"Sig"`
+}
+
+func NewPublishRequest() *PublishRequest {
+	return &PublishRequest{}
+}
+
+var PublishRequest_Context_DEFAULT *ContextInfo
+
+func (p *PublishRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return PublishRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *PublishRequest) GetTopic() string {
+	return p.Topic
+}
+
+func (p *PublishRequest) GetMessage() []byte {
+	return p.Message
+}
+
+func (p *PublishRequest) GetDeliver() bool {
+	return p.Deliver
+}
+
+func (p *PublishRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *PublishRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *PublishRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *PublishRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *PublishRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Topic = v
+	}
+	return nil
+}
+
+func (p *PublishRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Message = v
+	}
+	return nil
+}
+
+func (p *PublishRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Deliver = v
+	}
+	return nil
+}
+
+func (p *PublishRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *PublishRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("PublishRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *PublishRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *PublishRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Topic", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Topic: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Topic)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Topic (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Topic: ", p), err)
+	}
+	return err
+}
+
+func (p *PublishRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Message", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Message: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Message); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Message (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Message: ", p), err)
+	}
+	return err
+}
+
+func (p *PublishRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Deliver", thrift.BOOL, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Deliver: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Deliver)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Deliver (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Deliver: ", p), err)
+	}
+	return err
+}
+
+func (p *PublishRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *PublishRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PublishRequest(%+v)", *p)
+}
+
+// Used to store results when doing Publish requests.
+//
+// Attributes:
+//  - NbDelivered
+//  - NodesPath
+//  - HostsRefs
+type PublishResponse struct {
+	NbDelivered int32                `thrift:"NbDelivered,1" json:"NbDelivered"`
+	NodesPath   []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	HostsRefs   map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
+}
+
+func NewPublishResponse() *PublishResponse {
+	return &PublishResponse{}
+}
+
+func (p *PublishResponse) GetNbDelivered() int32 {
+	return p.NbDelivered
+}
+
+func (p *PublishResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *PublishResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *PublishResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *PublishResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NbDelivered = v
+	}
+	return nil
+}
+
+func (p *PublishResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem46 := &NodeInfo{}
+		if err := _elem46.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem46), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem46)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *PublishResponse) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key47 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key47 = v
+		}
+		_val48 := &HostInfo{}
+		if err := _val48.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val48), err)
+		}
+		p.HostsRefs[_key47] = _val48
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *PublishResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("PublishResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *PublishResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NbDelivered", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:NbDelivered: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.NbDelivered)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NbDelivered (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:NbDelivered: ", p), err)
+	}
+	return err
+}
+
+func (p *PublishResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *PublishResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *PublishResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PublishResponse(%+v)", *p)
+}
+
+// Used to store Leave requests. The source node tells its
+// neighbours it is leaving the ring, giving its successors and
+// predecessor so that they can splice the ring.
+//
+// Attributes:
+//  - Context
+//  - SuccessorNodes
+//  - PredecessorNode
+//  - Sig
+type LeaveRequest struct {
+	Context         *ContextInfo `thrift:"Context,1" json:"Context"`
+	SuccessorNodes  []*NodeInfo  `thrift:"SuccessorNodes,2" json:"SuccessorNodes"`
+	PredecessorNode *NodeInfo    `thrift:"PredecessorNode,3" json:"PredecessorNode,omitempty"`
+	Sig             []byte       `thrift:"Sig,4" json:"Sig"`
+}
+
+func NewLeaveRequest() *LeaveRequest {
+	return &LeaveRequest{}
+}
+
+var LeaveRequest_Context_DEFAULT *ContextInfo
+
+func (p *LeaveRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return LeaveRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *LeaveRequest) GetSuccessorNodes() []*NodeInfo {
+	return p.SuccessorNodes
+}
+
+var LeaveRequest_PredecessorNode_DEFAULT *NodeInfo
+
+func (p *LeaveRequest) GetPredecessorNode() *NodeInfo {
+	if !p.IsSetPredecessorNode() {
+		return LeaveRequest_PredecessorNode_DEFAULT
+	}
+	return p.PredecessorNode
+}
+
+func (p *LeaveRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *LeaveRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *LeaveRequest) IsSetPredecessorNode() bool {
+	return p.PredecessorNode != nil
+}
+
+func (p *LeaveRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *LeaveRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *LeaveRequest) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.SuccessorNodes = tSlice
+	for i := 0; i < size; i++ {
+		_elem49 := &NodeInfo{}
+		if err := _elem49.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem49), err)
+		}
+		p.SuccessorNodes = append(p.SuccessorNodes, _elem49)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *LeaveRequest) readField3(iprot thrift.TProtocol) error {
+	p.PredecessorNode = &NodeInfo{}
 	if err := p.PredecessorNode.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PredecessorNode), err)
 	}
-	return nil
+	return nil
+}
+
+func (p *LeaveRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *LeaveRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LeaveRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *LeaveRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *LeaveRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("SuccessorNodes", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:SuccessorNodes: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.SuccessorNodes)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.SuccessorNodes {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:SuccessorNodes: ", p), err)
+	}
+	return err
+}
+
+func (p *LeaveRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPredecessorNode() {
+		if err := oprot.WriteFieldBegin("PredecessorNode", thrift.STRUCT, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:PredecessorNode: ", p), err)
+		}
+		if err := p.PredecessorNode.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PredecessorNode), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:PredecessorNode: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *LeaveRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *LeaveRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("LeaveRequest(%+v)", *p)
+}
+
+// Used to store results when doing Leave requests.
+//
+// Attributes:
+//  - Done
+type LeaveResponse struct {
+	Done bool `thrift:"Done,1" json:"Done"`
+}
+
+func NewLeaveResponse() *LeaveResponse {
+	return &LeaveResponse{}
+}
+
+func (p *LeaveResponse) GetDone() bool {
+	return p.Done
+}
+func (p *LeaveResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *LeaveResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Done = v
+	}
+	return nil
+}
+
+func (p *LeaveResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LeaveResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *LeaveResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Done", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Done: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Done)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Done (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Done: ", p), err)
+	}
+	return err
+}
+
+func (p *LeaveResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("LeaveResponse(%+v)", *p)
+}
+
+// Used to store AnnounceRing requests. The announced ring is stored
+// in the ring directory, on the node holding the directory key,
+// and on its successors. If Replica is true, the ring is stored
+// on the target node, as is.
+//
+// Attributes:
+//  - Context
+//  - Ring
+//  - Replica
+//  - Sig
+type AnnounceRingRequest struct {
+	Context *ContextInfo `thrift:"Context,1" json:"Context"`
+	Ring    *RingInfo    `thrift:"Ring,2" json:"Ring"`
+	Replica bool         `thrift:"Replica,3" json:"Replica"`
+	Sig     []byte       `thrift:"Sig,4" json:"Sig"`
+}
+
+func NewAnnounceRingRequest() *AnnounceRingRequest {
+	return &AnnounceRingRequest{}
+}
+
+var AnnounceRingRequest_Context_DEFAULT *ContextInfo
+
+func (p *AnnounceRingRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return AnnounceRingRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+var AnnounceRingRequest_Ring_DEFAULT *RingInfo
+
+func (p *AnnounceRingRequest) GetRing() *RingInfo {
+	if !p.IsSetRing() {
+		return AnnounceRingRequest_Ring_DEFAULT
+	}
+	return p.Ring
+}
+
+func (p *AnnounceRingRequest) GetReplica() bool {
+	return p.Replica
+}
+
+func (p *AnnounceRingRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *AnnounceRingRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *AnnounceRingRequest) IsSetRing() bool {
+	return p.Ring != nil
+}
+
+func (p *AnnounceRingRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *AnnounceRingRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *AnnounceRingRequest) readField2(iprot thrift.TProtocol) error {
+	p.Ring = &RingInfo{}
+	if err := p.Ring.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ring), err)
+	}
+	return nil
+}
+
+func (p *AnnounceRingRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Replica = v
+	}
+	return nil
+}
+
+func (p *AnnounceRingRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *AnnounceRingRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AnnounceRingRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AnnounceRingRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *AnnounceRingRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Ring", thrift.STRUCT, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Ring: ", p), err)
+	}
+	if err := p.Ring.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ring), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Ring: ", p), err)
+	}
+	return err
+}
+
+func (p *AnnounceRingRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Replica", thrift.BOOL, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Replica: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Replica)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Replica (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Replica: ", p), err)
+	}
+	return err
+}
+
+func (p *AnnounceRingRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *AnnounceRingRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AnnounceRingRequest(%+v)", *p)
+}
+
+// Used to store results when doing AnnounceRing requests.
+//
+// Attributes:
+//  - NbCopy
+//  - NodesPath
+//  - HostsRefs
+type AnnounceRingResponse struct {
+	NbCopy    int32                `thrift:"NbCopy,1" json:"NbCopy"`
+	NodesPath []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
+}
+
+func NewAnnounceRingResponse() *AnnounceRingResponse {
+	return &AnnounceRingResponse{}
+}
+
+func (p *AnnounceRingResponse) GetNbCopy() int32 {
+	return p.NbCopy
+}
+
+func (p *AnnounceRingResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *AnnounceRingResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *AnnounceRingResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *AnnounceRingResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NbCopy = v
+	}
+	return nil
+}
+
+func (p *AnnounceRingResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem50 := &NodeInfo{}
+		if err := _elem50.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem50), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem50)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *AnnounceRingResponse) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key51 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key51 = v
+		}
+		_val52 := &HostInfo{}
+		if err := _val52.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val52), err)
+		}
+		p.HostsRefs[_key51] = _val52
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *AnnounceRingResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AnnounceRingResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AnnounceRingResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NbCopy", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:NbCopy: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.NbCopy)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.NbCopy (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:NbCopy: ", p), err)
+	}
+	return err
+}
+
+func (p *AnnounceRingResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *AnnounceRingResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *AnnounceRingResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AnnounceRingResponse(%+v)", *p)
+}
+
+// Used to store ListRings requests. Rings are filtered by AppID,
+// if not empty, and by title prefix. If Replica is true, only the
+// target node local directory is searched.
+//
+// Attributes:
+//  - Context
+//  - AppID
+//  - TitlePrefix
+//  - Replica
+//  - Sig
+type ListRingsRequest struct {
+	Context     *ContextInfo `thrift:"Context,1" json:"Context"`
+	AppID       []byte       `thrift:"AppID,2" json:"AppID"`
+	TitlePrefix string       `thrift:"TitlePrefix,3" json:"TitlePrefix"`
+	Replica     bool         `thrift:"Replica,4" json:"Replica"`
+	Sig         []byte       `thrift:"Sig,5" json:"Sig"`
+}
+
+func NewListRingsRequest() *ListRingsRequest {
+	return &ListRingsRequest{}
+}
+
+var ListRingsRequest_Context_DEFAULT *ContextInfo
+
+func (p *ListRingsRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return ListRingsRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *ListRingsRequest) GetAppID() []byte {
+	return p.AppID
+}
+
+func (p *ListRingsRequest) GetTitlePrefix() string {
+	return p.TitlePrefix
+}
+
+func (p *ListRingsRequest) GetReplica() bool {
+	return p.Replica
+}
+
+func (p *ListRingsRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *ListRingsRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *ListRingsRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ListRingsRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *ListRingsRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.AppID = v
+	}
+	return nil
+}
+
+func (p *ListRingsRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.TitlePrefix = v
+	}
+	return nil
+}
+
+func (p *ListRingsRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Replica = v
+	}
+	return nil
+}
+
+func (p *ListRingsRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *ListRingsRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListRingsRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ListRingsRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *ListRingsRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("AppID", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:AppID: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.AppID); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.AppID (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:AppID: ", p), err)
+	}
+	return err
+}
+
+func (p *ListRingsRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("TitlePrefix", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:TitlePrefix: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.TitlePrefix)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.TitlePrefix (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:TitlePrefix: ", p), err)
+	}
+	return err
+}
+
+func (p *ListRingsRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Replica", thrift.BOOL, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Replica: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Replica)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Replica (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Replica: ", p), err)
+	}
+	return err
+}
+
+func (p *ListRingsRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *ListRingsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListRingsRequest(%+v)", *p)
+}
+
+// Used to store results when doing ListRings requests.
+//
+// Attributes:
+//  - Rings
+//  - NodesPath
+//  - HostsRefs
+type ListRingsResponse struct {
+	Rings     []*RingInfo          `thrift:"Rings,1" json:"Rings"`
+	NodesPath []*NodeInfo          `thrift:"NodesPath,2" json:"NodesPath"`
+	HostsRefs map[string]*HostInfo `thrift:"HostsRefs,3" json:"HostsRefs"`
+}
+
+func NewListRingsResponse() *ListRingsResponse {
+	return &ListRingsResponse{}
+}
+
+func (p *ListRingsResponse) GetRings() []*RingInfo {
+	return p.Rings
+}
+
+func (p *ListRingsResponse) GetNodesPath() []*NodeInfo {
+	return p.NodesPath
+}
+
+func (p *ListRingsResponse) GetHostsRefs() map[string]*HostInfo {
+	return p.HostsRefs
+}
+func (p *ListRingsResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ListRingsResponse) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*RingInfo, 0, size)
+	p.Rings = tSlice
+	for i := 0; i < size; i++ {
+		_elem53 := &RingInfo{}
+		if err := _elem53.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem53), err)
+		}
+		p.Rings = append(p.Rings, _elem53)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *ListRingsResponse) readField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*NodeInfo, 0, size)
+	p.NodesPath = tSlice
+	for i := 0; i < size; i++ {
+		_elem54 := &NodeInfo{}
+		if err := _elem54.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem54), err)
+		}
+		p.NodesPath = append(p.NodesPath, _elem54)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *ListRingsResponse) readField3(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string]*HostInfo, size)
+	p.HostsRefs = tMap
+	for i := 0; i < size; i++ {
+		var _key55 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key55 = v
+		}
+		_val56 := &HostInfo{}
+		if err := _val56.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val56), err)
+		}
+		p.HostsRefs[_key55] = _val56
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *ListRingsResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListRingsResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ListRingsResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Rings", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Rings: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Rings)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Rings {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Rings: ", p), err)
+	}
+	return err
+}
+
+func (p *ListRingsResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.NodesPath {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	}
+	return err
+}
+
+func (p *ListRingsResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:HostsRefs: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.HostsRefs {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:HostsRefs: ", p), err)
+	}
+	return err
+}
+
+func (p *ListRingsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListRingsResponse(%+v)", *p)
+}
+
+// Used to store SecureMessage requests. The message is sent to the
+// target node, Payload being encrypted with the symmetric session key
+// identified by SessionID, which only the source and target hosts know.
+// The first message of a session carries the session key in SessionKey,
+// encrypted with the public key of the target host. Later messages
+// leave SessionKey empty, unless the target host forgot the session.
+//
+// Attributes:
+//  - Context
+//  - SessionID
+//  - SessionKey
+//  - Payload
+//  - Sig
+type SecureMessageRequest struct {
+	Context    *ContextInfo `thrift:"Context,1" json:"Context"`
+	SessionID  []byte       `thrift:"SessionID,2" json:"SessionID"`
+	SessionKey []byte       `thrift:"SessionKey,3" json:"SessionKey"`
+	Payload    []byte       `thrift:"Payload,4" json:"Payload"`
+	Sig        []byte       `thrift:"Sig,5" json:"Sig"`
+}
+
+func NewSecureMessageRequest() *SecureMessageRequest {
+	return &SecureMessageRequest{}
+}
+
+var SecureMessageRequest_Context_DEFAULT *ContextInfo
+
+func (p *SecureMessageRequest) GetContext() *ContextInfo {
+	if !p.IsSetContext() {
+		return SecureMessageRequest_Context_DEFAULT
+	}
+	return p.Context
+}
+
+func (p *SecureMessageRequest) GetSessionID() []byte {
+	return p.SessionID
+}
+
+func (p *SecureMessageRequest) GetSessionKey() []byte {
+	return p.SessionKey
+}
+
+func (p *SecureMessageRequest) GetPayload() []byte {
+	return p.Payload
+}
+
+func (p *SecureMessageRequest) GetSig() []byte {
+	return p.Sig
+}
+func (p *SecureMessageRequest) IsSetContext() bool {
+	return p.Context != nil
+}
+
+func (p *SecureMessageRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *SecureMessageRequest) readField1(iprot thrift.TProtocol) error {
+	p.Context = &ContextInfo{}
+	if err := p.Context.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Context), err)
+	}
+	return nil
+}
+
+func (p *SecureMessageRequest) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.SessionID = v
+	}
+	return nil
+}
+
+func (p *SecureMessageRequest) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.SessionKey = v
+	}
+	return nil
+}
+
+func (p *SecureMessageRequest) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Payload = v
+	}
+	return nil
+}
+
+func (p *SecureMessageRequest) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Sig = v
+	}
+	return nil
+}
+
+func (p *SecureMessageRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SecureMessageRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SecureMessageRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Context", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Context: ", p), err)
+	}
+	if err := p.Context.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Context), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Context: ", p), err)
+	}
+	return err
+}
+
+func (p *SecureMessageRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("SessionID", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:SessionID: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.SessionID); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.SessionID (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:SessionID: ", p), err)
+	}
+	return err
+}
+
+func (p *SecureMessageRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("SessionKey", thrift.STRING, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:SessionKey: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.SessionKey); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.SessionKey (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:SessionKey: ", p), err)
+	}
+	return err
+}
+
+func (p *SecureMessageRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Payload", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:Payload: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Payload); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Payload (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:Payload: ", p), err)
+	}
+	return err
+}
+
+func (p *SecureMessageRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Sig", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Sig: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Sig); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Sig (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Sig: ", p), err)
+	}
+	return err
+}
+
+func (p *SecureMessageRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SecureMessageRequest(%+v)", *p)
+}
+
+// Used to store results when doing SecureMessage requests. If
+// UnknownSession is true, the message has not been read, and must
+// be sent again, along with the session key.
+//
+// Attributes:
+//  - Delivered
+//  - UnknownSession
+type SecureMessageResponse struct {
+	Delivered      bool `thrift:"Delivered,1" json:"Delivered"`
+	UnknownSession bool `thrift:"UnknownSession,2" json:"UnknownSession"`
+}
+
+func NewSecureMessageResponse() *SecureMessageResponse {
+	return &SecureMessageResponse{}
+}
+
+func (p *SecureMessageResponse) GetDelivered() bool {
+	return p.Delivered
+}
+
+func (p *SecureMessageResponse) GetUnknownSession() bool {
+	return p.UnknownSession
+}
+func (p *SecureMessageResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *SecureMessageResponse) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Delivered = v
+	}
+	return nil
+}
+
+func (p *SecureMessageResponse) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.UnknownSession = v
+	}
+	return nil
+}
+
+func (p *SecureMessageResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SecureMessageResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *SecureMessageResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Delivered", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Delivered: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Delivered)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Delivered (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Delivered: ", p), err)
+	}
+	return err
+}
+
+func (p *SecureMessageResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("UnknownSession", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:UnknownSession: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.UnknownSession)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.UnknownSession (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:UnknownSession: ", p), err)
+	}
+	return err
+}
+
+func (p *SecureMessageResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SecureMessageResponse(%+v)", *p)
+}
+
+// HandshakeInfo describes what a program is able to speak. Peers
+// with incompatible packages are refused. Peers use the highest
+// protocol version within both [MinProtocol,MaxProtocol] ranges,
+// and only the capabilities they both have. Version is informative.
+//
+// Attributes:
+//  - Package
+//  - Version
+//  - MinProtocol
+//  - MaxProtocol
+//  - Capabilities
+type HandshakeInfo struct {
+	Package      *vpcommonapi.Package `thrift:"Package,1" json:"Package"`
+	Version      *vpcommonapi.Version `thrift:"Version,2" json:"Version"`
+	MinProtocol  int32                `thrift:"MinProtocol,3" json:"MinProtocol"`
+	MaxProtocol  int32                `thrift:"MaxProtocol,4" json:"MaxProtocol"`
+	Capabilities []string             `thrift:"Capabilities,5" json:"Capabilities"`
+}
+
+func NewHandshakeInfo() *HandshakeInfo {
+	return &HandshakeInfo{}
+}
+
+var HandshakeInfo_Package_DEFAULT *vpcommonapi.Package
+
+func (p *HandshakeInfo) GetPackage() *vpcommonapi.Package {
+	if !p.IsSetPackage() {
+		return HandshakeInfo_Package_DEFAULT
+	}
+	return p.Package
+}
+
+var HandshakeInfo_Version_DEFAULT *vpcommonapi.Version
+
+func (p *HandshakeInfo) GetVersion() *vpcommonapi.Version {
+	if !p.IsSetVersion() {
+		return HandshakeInfo_Version_DEFAULT
+	}
+	return p.Version
+}
+
+func (p *HandshakeInfo) GetMinProtocol() int32 {
+	return p.MinProtocol
+}
+
+func (p *HandshakeInfo) GetMaxProtocol() int32 {
+	return p.MaxProtocol
+}
+
+func (p *HandshakeInfo) GetCapabilities() []string {
+	return p.Capabilities
+}
+func (p *HandshakeInfo) IsSetPackage() bool {
+	return p.Package != nil
+}
+
+func (p *HandshakeInfo) IsSetVersion() bool {
+	return p.Version != nil
+}
+
+func (p *HandshakeInfo) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *HandshakeInfo) readField1(iprot thrift.TProtocol) error {
+	p.Package = &vpcommonapi.Package{}
+	if err := p.Package.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Package), err)
+	}
+	return nil
+}
+
+func (p *HandshakeInfo) readField2(iprot thrift.TProtocol) error {
+	p.Version = &vpcommonapi.Version{}
+	if err := p.Version.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Version), err)
+	}
+	return nil
+}
+
+func (p *HandshakeInfo) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.MinProtocol = v
+	}
+	return nil
+}
+
+func (p *HandshakeInfo) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.MaxProtocol = v
+	}
+	return nil
+}
+
+func (p *HandshakeInfo) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.Capabilities = tSlice
+	for i := 0; i < size; i++ {
+		var _elem57 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem57 = v
+		}
+		p.Capabilities = append(p.Capabilities, _elem57)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *HandshakeInfo) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("HandshakeInfo"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HandshakeInfo) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Package", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Package: ", p), err)
+	}
+	if err := p.Package.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Package), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Package: ", p), err)
+	}
+	return err
+}
+
+func (p *HandshakeInfo) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Version", thrift.STRUCT, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Version: ", p), err)
+	}
+	if err := p.Version.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Version), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Version: ", p), err)
+	}
+	return err
+}
+
+func (p *HandshakeInfo) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("MinProtocol", thrift.I32, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:MinProtocol: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.MinProtocol)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.MinProtocol (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:MinProtocol: ", p), err)
+	}
+	return err
+}
+
+func (p *HandshakeInfo) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("MaxProtocol", thrift.I32, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:MaxProtocol: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.MaxProtocol)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.MaxProtocol (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:MaxProtocol: ", p), err)
+	}
+	return err
+}
+
+func (p *HandshakeInfo) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Capabilities", thrift.LIST, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:Capabilities: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.Capabilities)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Capabilities {
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:Capabilities: ", p), err)
+	}
+	return err
 }
 
-func (p *SyncResponse) readField5(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
-	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
+func (p *HandshakeInfo) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	tMap := make(map[string]*HostInfo, size)
-	p.HostsRefs = tMap
-	for i := 0; i < size; i++ {
-		var _key16 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key16 = v
+	return fmt.Sprintf("HandshakeInfo(%+v)", *p)
+}
+
+// Used to store Handshake requests.
+//
+// Attributes:
+//  - Info
+type HandshakeRequest struct {
+	Info *HandshakeInfo `thrift:"Info,1" json:"Info"`
+}
+
+func NewHandshakeRequest() *HandshakeRequest {
+	return &HandshakeRequest{}
+}
+
+var HandshakeRequest_Info_DEFAULT *HandshakeInfo
+
+func (p *HandshakeRequest) GetInfo() *HandshakeInfo {
+	if !p.IsSetInfo() {
+		return HandshakeRequest_Info_DEFAULT
+	}
+	return p.Info
+}
+func (p *HandshakeRequest) IsSetInfo() bool {
+	return p.Info != nil
+}
+
+func (p *HandshakeRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
 		}
-		_val17 := &HostInfo{}
-		if err := _val17.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val17), err)
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-		p.HostsRefs[_key16] = _val17
 	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
 	return nil
 }
 
-func (p *SyncResponse) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("SyncResponse"); err != nil {
+func (p *HandshakeRequest) readField1(iprot thrift.TProtocol) error {
+	p.Info = &HandshakeInfo{}
+	if err := p.Info.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Info), err)
+	}
+	return nil
+}
+
+func (p *HandshakeRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("HandshakeRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
 		return err
 	}
-	if err := p.writeField2(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField3(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField4(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField5(oprot); err != nil {
-		return err
-	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -3421,101 +10369,215 @@ func (p *SyncResponse) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *SyncResponse) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("Found", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Found: ", p), err)
+func (p *HandshakeRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Info", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Info: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.Found)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.Found (1) field write error: ", p), err)
+	if err := p.Info.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Info), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Found: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Info: ", p), err)
 	}
 	return err
 }
 
-func (p *SyncResponse) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("NodesPath", thrift.LIST, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:NodesPath: ", p), err)
+func (p *HandshakeRequest) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.NodesPath)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
+	return fmt.Sprintf("HandshakeRequest(%+v)", *p)
+}
+
+// Used to store results when doing Handshake requests. A target
+// host which refuses to talk with the caller fails the call instead.
+//
+// Attributes:
+//  - Info
+//  - Protocol
+//  - Capabilities
+type HandshakeResponse struct {
+	Info         *HandshakeInfo `thrift:"Info,1" json:"Info"`
+	Protocol     int32          `thrift:"Protocol,2" json:"Protocol"`
+	Capabilities []string       `thrift:"Capabilities,3" json:"Capabilities"`
+}
+
+func NewHandshakeResponse() *HandshakeResponse {
+	return &HandshakeResponse{}
+}
+
+var HandshakeResponse_Info_DEFAULT *HandshakeInfo
+
+func (p *HandshakeResponse) GetInfo() *HandshakeInfo {
+	if !p.IsSetInfo() {
+		return HandshakeResponse_Info_DEFAULT
 	}
-	for _, v := range p.NodesPath {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+	return p.Info
+}
+
+func (p *HandshakeResponse) GetProtocol() int32 {
+	return p.Protocol
+}
+
+func (p *HandshakeResponse) GetCapabilities() []string {
+	return p.Capabilities
+}
+func (p *HandshakeResponse) IsSetInfo() bool {
+	return p.Info != nil
+}
+
+func (p *HandshakeResponse) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
 	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:NodesPath: ", p), err)
+	return nil
+}
+
+func (p *HandshakeResponse) readField1(iprot thrift.TProtocol) error {
+	p.Info = &HandshakeInfo{}
+	if err := p.Info.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Info), err)
 	}
-	return err
+	return nil
 }
 
-func (p *SyncResponse) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("SuccessorNodes", thrift.LIST, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:SuccessorNodes: ", p), err)
+func (p *HandshakeResponse) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Protocol = v
 	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.SuccessorNodes)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
+	return nil
+}
+
+func (p *HandshakeResponse) readField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	for _, v := range p.SuccessorNodes {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+	tSlice := make([]string, 0, size)
+	p.Capabilities = tSlice
+	for i := 0; i < size; i++ {
+		var _elem58 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem58 = v
 		}
+		p.Capabilities = append(p.Capabilities, _elem58)
 	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *HandshakeResponse) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("HandshakeResponse"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *HandshakeResponse) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Info", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:Info: ", p), err)
+	}
+	if err := p.Info.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Info), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:SuccessorNodes: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:Info: ", p), err)
 	}
 	return err
 }
 
-func (p *SyncResponse) writeField4(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("PredecessorNode", thrift.STRUCT, 4); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:PredecessorNode: ", p), err)
+func (p *HandshakeResponse) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Protocol", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:Protocol: ", p), err)
 	}
-	if err := p.PredecessorNode.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PredecessorNode), err)
+	if err := oprot.WriteI32(int32(p.Protocol)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.Protocol (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:PredecessorNode: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:Protocol: ", p), err)
 	}
 	return err
 }
 
-func (p *SyncResponse) writeField5(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("HostsRefs", thrift.MAP, 5); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:HostsRefs: ", p), err)
+func (p *HandshakeResponse) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("Capabilities", thrift.LIST, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:Capabilities: ", p), err)
 	}
-	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.HostsRefs)); err != nil {
-		return thrift.PrependError("error writing map begin: ", err)
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.Capabilities)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
 	}
-	for k, v := range p.HostsRefs {
-		if err := oprot.WriteString(string(k)); err != nil {
+	for _, v := range p.Capabilities {
+		if err := oprot.WriteString(string(v)); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
 		}
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
 	}
-	if err := oprot.WriteMapEnd(); err != nil {
-		return thrift.PrependError("error writing map end: ", err)
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:HostsRefs: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:Capabilities: ", p), err)
 	}
 	return err
 }
 
-func (p *SyncResponse) String() string {
+func (p *HandshakeResponse) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("SyncResponse(%+v)", *p)
+	return fmt.Sprintf("HandshakeResponse(%+v)", *p)
 }
@@ -25,9 +25,15 @@ type VpP2pApi interface {
 	Status() (r *HostStatus, err error)
 	// Parameters:
 	//  - Request
+	Challenge(request *ChallengeRequest) (r *ChallengeResponse, err error)
+	// Parameters:
+	//  - Request
 	Lookup(request *LookupRequest) (r *LookupResponse, err error)
 	// Parameters:
 	//  - Request
+	LookupMany(request *LookupManyRequest) (r *LookupManyResponse, err error)
+	// Parameters:
+	//  - Request
 	GetSuccessors(request *GetSuccessorsRequest) (r *GetSuccessorsResponse, err error)
 	// Parameters:
 	//  - Request
@@ -35,6 +41,42 @@ type VpP2pApi interface {
 	// Parameters:
 	//  - Request
 	Sync(request *SyncRequest) (r *SyncResponse, err error)
+	// Parameters:
+	//  - Request
+	Put(request *PutRequest) (r *PutResponse, err error)
+	// Parameters:
+	//  - Request
+	Get(request *GetRequest) (r *GetResponse, err error)
+	// Parameters:
+	//  - Request
+	Delete(request *DeleteRequest) (r *DeleteResponse, err error)
+	// Parameters:
+	//  - Request
+	Merkle(request *MerkleRequest) (r *MerkleResponse, err error)
+	// Parameters:
+	//  - Request
+	Leave(request *LeaveRequest) (r *LeaveResponse, err error)
+	// Parameters:
+	//  - Request
+	AnnounceRing(request *AnnounceRingRequest) (r *AnnounceRingResponse, err error)
+	// Parameters:
+	//  - Request
+	ListRings(request *ListRingsRequest) (r *ListRingsResponse, err error)
+	// Parameters:
+	//  - Request
+	Subscribe(request *SubscribeRequest) (r *SubscribeResponse, err error)
+	// Parameters:
+	//  - Request
+	Unsubscribe(request *UnsubscribeRequest) (r *UnsubscribeResponse, err error)
+	// Parameters:
+	//  - Request
+	Publish(request *PublishRequest) (r *PublishResponse, err error)
+	// Parameters:
+	//  - Request
+	Handshake(request *HandshakeRequest) (r *HandshakeResponse, err error)
+	// Parameters:
+	//  - Request
+	SecureMessage(request *SecureMessageRequest) (r *SecureMessageResponse, err error)
 }
 
 //VpP2pApi is used to communicate between 2 Vapor nodes
@@ -97,16 +139,16 @@ func (p *VpP2pApiClient) recvStatus() (value *HostStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error18 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error19 error
-		error19, err = error18.Read(iprot)
+		error59 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error60 error
+		error60, err = error59.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error19
+		err = error60
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -124,6 +166,83 @@ func (p *VpP2pApiClient) recvStatus() (value *HostStatus, err error) {
 	return
 }
 
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Challenge(request *ChallengeRequest) (r *ChallengeResponse, err error) {
+	if err = p.sendChallenge(request); err != nil {
+		return
+	}
+	return p.recvChallenge()
+}
+
+func (p *VpP2pApiClient) sendChallenge(request *ChallengeRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Challenge", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiChallengeArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvChallenge() (value *ChallengeResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "Challenge" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Challenge failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Challenge failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error61 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error62 error
+		error62, err = error61.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error62
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Challenge failed: invalid message type")
+		return
+	}
+	result := VpP2pApiChallengeResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
 // Parameters:
 //  - Request
 func (p *VpP2pApiClient) Lookup(request *LookupRequest) (r *LookupResponse, err error) {
@@ -174,16 +293,16 @@ func (p *VpP2pApiClient) recvLookup() (value *LookupResponse, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error20 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error21 error
-		error21, err = error20.Read(iprot)
+		error63 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error64 error
+		error64, err = error63.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error21
+		err = error64
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -201,6 +320,83 @@ func (p *VpP2pApiClient) recvLookup() (value *LookupResponse, err error) {
 	return
 }
 
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) LookupMany(request *LookupManyRequest) (r *LookupManyResponse, err error) {
+	if err = p.sendLookupMany(request); err != nil {
+		return
+	}
+	return p.recvLookupMany()
+}
+
+func (p *VpP2pApiClient) sendLookupMany(request *LookupManyRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("LookupMany", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiLookupManyArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvLookupMany() (value *LookupManyResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "LookupMany" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "LookupMany failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "LookupMany failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error65 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error66 error
+		error66, err = error65.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error66
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "LookupMany failed: invalid message type")
+		return
+	}
+	result := VpP2pApiLookupManyResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
 // Parameters:
 //  - Request
 func (p *VpP2pApiClient) GetSuccessors(request *GetSuccessorsRequest) (r *GetSuccessorsResponse, err error) {
@@ -251,16 +447,16 @@ func (p *VpP2pApiClient) recvGetSuccessors() (value *GetSuccessorsResponse, err
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error22 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error23 error
-		error23, err = error22.Read(iprot)
+		error67 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error68 error
+		error68, err = error67.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error23
+		err = error68
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -328,16 +524,16 @@ func (p *VpP2pApiClient) recvGetPredecessor() (value *GetPredecessorResponse, er
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error24 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error25 error
-		error25, err = error24.Read(iprot)
+		error69 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error70 error
+		error70, err = error69.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error25
+		err = error70
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -405,16 +601,16 @@ func (p *VpP2pApiClient) recvSync() (value *SyncResponse, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error26 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error27 error
-		error27, err = error26.Read(iprot)
+		error71 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error72 error
+		error72, err = error71.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error27
+		err = error72
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -432,270 +628,4649 @@ func (p *VpP2pApiClient) recvSync() (value *SyncResponse, err error) {
 	return
 }
 
-type VpP2pApiProcessor struct {
-	*vpcommonapi.VpCommonApiProcessor
-}
-
-func NewVpP2pApiProcessor(handler VpP2pApi) *VpP2pApiProcessor {
-	self28 := &VpP2pApiProcessor{vpcommonapi.NewVpCommonApiProcessor(handler)}
-	self28.AddToProcessorMap("Status", &vpP2pApiProcessorStatus{handler: handler})
-	self28.AddToProcessorMap("Lookup", &vpP2pApiProcessorLookup{handler: handler})
-	self28.AddToProcessorMap("GetSuccessors", &vpP2pApiProcessorGetSuccessors{handler: handler})
-	self28.AddToProcessorMap("GetPredecessor", &vpP2pApiProcessorGetPredecessor{handler: handler})
-	self28.AddToProcessorMap("Sync", &vpP2pApiProcessorSync{handler: handler})
-	return self28
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Put(request *PutRequest) (r *PutResponse, err error) {
+	if err = p.sendPut(request); err != nil {
+		return
+	}
+	return p.recvPut()
 }
 
-type vpP2pApiProcessorStatus struct {
-	handler VpP2pApi
+func (p *VpP2pApiClient) sendPut(request *PutRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Put", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiPutArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
 }
 
-func (p *vpP2pApiProcessorStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := VpP2pApiStatusArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("Status", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *VpP2pApiClient) recvPut() (value *PutResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
 	}
-
-	iprot.ReadMessageEnd()
-	result := VpP2pApiStatusResult{}
-	var retval *HostStatus
-	var err2 error
-	if retval, err2 = p.handler.Status(); err2 != nil {
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Status: "+err2.Error())
-		oprot.WriteMessageBegin("Status", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return true, err2
-	} else {
-		result.Success = retval
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageBegin("Status", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if method != "Put" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Put failed: wrong method name")
+		return
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Put failed: out of sequence response")
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if mTypeId == thrift.EXCEPTION {
+		error73 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error74 error
+		error74, err = error73.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error74
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Put failed: invalid message type")
+		return
 	}
-	if err != nil {
+	result := VpP2pApiPutResult{}
+	if err = result.Read(iprot); err != nil {
 		return
 	}
-	return true, err
-}
-
-type vpP2pApiProcessorLookup struct {
-	handler VpP2pApi
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
 }
 
-func (p *vpP2pApiProcessorLookup) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := VpP2pApiLookupArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("Lookup", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Get(request *GetRequest) (r *GetResponse, err error) {
+	if err = p.sendGet(request); err != nil {
+		return
 	}
+	return p.recvGet()
+}
 
-	iprot.ReadMessageEnd()
-	result := VpP2pApiLookupResult{}
-	var retval *LookupResponse
-	var err2 error
-	if retval, err2 = p.handler.Lookup(args.Request); err2 != nil {
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Lookup: "+err2.Error())
-		oprot.WriteMessageBegin("Lookup", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return true, err2
-	} else {
-		result.Success = retval
+func (p *VpP2pApiClient) sendGet(request *GetRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
 	}
-	if err2 = oprot.WriteMessageBegin("Lookup", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Get", thrift.CALL, p.SeqId); err != nil {
+		return
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	args := VpP2pApiGetArgs{
+		Request: request,
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err = args.Write(oprot); err != nil {
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
 	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvGet() (value *GetResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	return true, err
+	if method != "Get" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Get failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Get failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error75 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error76 error
+		error76, err = error75.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error76
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Get failed: invalid message type")
+		return
+	}
+	result := VpP2pApiGetResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
 }
 
-type vpP2pApiProcessorGetSuccessors struct {
-	handler VpP2pApi
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Delete(request *DeleteRequest) (r *DeleteResponse, err error) {
+	if err = p.sendDelete(request); err != nil {
+		return
+	}
+	return p.recvDelete()
 }
 
-func (p *vpP2pApiProcessorGetSuccessors) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := VpP2pApiGetSuccessorsArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("GetSuccessors", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *VpP2pApiClient) sendDelete(request *DeleteRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
 	}
-
-	iprot.ReadMessageEnd()
-	result := VpP2pApiGetSuccessorsResult{}
-	var retval *GetSuccessorsResponse
-	var err2 error
-	if retval, err2 = p.handler.GetSuccessors(args.Request); err2 != nil {
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetSuccessors: "+err2.Error())
-		oprot.WriteMessageBegin("GetSuccessors", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return true, err2
-	} else {
-		result.Success = retval
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Delete", thrift.CALL, p.SeqId); err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageBegin("GetSuccessors", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	args := VpP2pApiDeleteArgs{
+		Request: request,
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err = args.Write(oprot); err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvDelete() (value *DeleteResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
 	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	return true, err
+	if method != "Delete" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Delete failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Delete failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error77 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error78 error
+		error78, err = error77.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error78
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Delete failed: invalid message type")
+		return
+	}
+	result := VpP2pApiDeleteResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
 }
 
-type vpP2pApiProcessorGetPredecessor struct {
-	handler VpP2pApi
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Merkle(request *MerkleRequest) (r *MerkleResponse, err error) {
+	if err = p.sendMerkle(request); err != nil {
+		return
+	}
+	return p.recvMerkle()
 }
 
-func (p *vpP2pApiProcessorGetPredecessor) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := VpP2pApiGetPredecessorArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("GetPredecessor", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *VpP2pApiClient) sendMerkle(request *MerkleRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
 	}
-
-	iprot.ReadMessageEnd()
-	result := VpP2pApiGetPredecessorResult{}
-	var retval *GetPredecessorResponse
-	var err2 error
-	if retval, err2 = p.handler.GetPredecessor(args.Request); err2 != nil {
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetPredecessor: "+err2.Error())
-		oprot.WriteMessageBegin("GetPredecessor", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return true, err2
-	} else {
-		result.Success = retval
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Merkle", thrift.CALL, p.SeqId); err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageBegin("GetPredecessor", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	args := VpP2pApiMerkleArgs{
+		Request: request,
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err = args.Write(oprot); err != nil {
+		return
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvMerkle() (value *MerkleResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
 	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	return true, err
+	if method != "Merkle" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Merkle failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Merkle failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error79 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error80 error
+		error80, err = error79.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error80
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Merkle failed: invalid message type")
+		return
+	}
+	result := VpP2pApiMerkleResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
 }
 
-type vpP2pApiProcessorSync struct {
-	handler VpP2pApi
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Leave(request *LeaveRequest) (r *LeaveResponse, err error) {
+	if err = p.sendLeave(request); err != nil {
+		return
+	}
+	return p.recvLeave()
 }
 
-func (p *vpP2pApiProcessorSync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := VpP2pApiSyncArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("Sync", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *VpP2pApiClient) sendLeave(request *LeaveRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Leave", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiLeaveArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvLeave() (value *LeaveResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "Leave" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Leave failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Leave failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error81 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error82 error
+		error82, err = error81.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error82
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Leave failed: invalid message type")
+		return
+	}
+	result := VpP2pApiLeaveResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) AnnounceRing(request *AnnounceRingRequest) (r *AnnounceRingResponse, err error) {
+	if err = p.sendAnnounceRing(request); err != nil {
+		return
+	}
+	return p.recvAnnounceRing()
+}
+
+func (p *VpP2pApiClient) sendAnnounceRing(request *AnnounceRingRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("AnnounceRing", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiAnnounceRingArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvAnnounceRing() (value *AnnounceRingResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "AnnounceRing" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "AnnounceRing failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "AnnounceRing failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error83 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error84 error
+		error84, err = error83.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error84
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "AnnounceRing failed: invalid message type")
+		return
+	}
+	result := VpP2pApiAnnounceRingResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) ListRings(request *ListRingsRequest) (r *ListRingsResponse, err error) {
+	if err = p.sendListRings(request); err != nil {
+		return
+	}
+	return p.recvListRings()
+}
+
+func (p *VpP2pApiClient) sendListRings(request *ListRingsRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("ListRings", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiListRingsArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvListRings() (value *ListRingsResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "ListRings" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListRings failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListRings failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error85 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error86 error
+		error86, err = error85.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error86
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListRings failed: invalid message type")
+		return
+	}
+	result := VpP2pApiListRingsResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Subscribe(request *SubscribeRequest) (r *SubscribeResponse, err error) {
+	if err = p.sendSubscribe(request); err != nil {
+		return
+	}
+	return p.recvSubscribe()
+}
+
+func (p *VpP2pApiClient) sendSubscribe(request *SubscribeRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Subscribe", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiSubscribeArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvSubscribe() (value *SubscribeResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "Subscribe" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Subscribe failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Subscribe failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error87 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error88 error
+		error88, err = error87.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error88
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Subscribe failed: invalid message type")
+		return
+	}
+	result := VpP2pApiSubscribeResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Unsubscribe(request *UnsubscribeRequest) (r *UnsubscribeResponse, err error) {
+	if err = p.sendUnsubscribe(request); err != nil {
+		return
+	}
+	return p.recvUnsubscribe()
+}
+
+func (p *VpP2pApiClient) sendUnsubscribe(request *UnsubscribeRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Unsubscribe", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiUnsubscribeArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvUnsubscribe() (value *UnsubscribeResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "Unsubscribe" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Unsubscribe failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Unsubscribe failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error89 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error90 error
+		error90, err = error89.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error90
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Unsubscribe failed: invalid message type")
+		return
+	}
+	result := VpP2pApiUnsubscribeResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Publish(request *PublishRequest) (r *PublishResponse, err error) {
+	if err = p.sendPublish(request); err != nil {
+		return
+	}
+	return p.recvPublish()
+}
+
+func (p *VpP2pApiClient) sendPublish(request *PublishRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Publish", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiPublishArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvPublish() (value *PublishResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "Publish" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Publish failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Publish failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error91 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error92 error
+		error92, err = error91.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error92
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Publish failed: invalid message type")
+		return
+	}
+	result := VpP2pApiPublishResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) Handshake(request *HandshakeRequest) (r *HandshakeResponse, err error) {
+	if err = p.sendHandshake(request); err != nil {
+		return
+	}
+	return p.recvHandshake()
+}
+
+func (p *VpP2pApiClient) sendHandshake(request *HandshakeRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("Handshake", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiHandshakeArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvHandshake() (value *HandshakeResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "Handshake" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "Handshake failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "Handshake failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error93 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error94 error
+		error94, err = error93.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error94
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "Handshake failed: invalid message type")
+		return
+	}
+	result := VpP2pApiHandshakeResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Request
+func (p *VpP2pApiClient) SecureMessage(request *SecureMessageRequest) (r *SecureMessageResponse, err error) {
+	if err = p.sendSecureMessage(request); err != nil {
+		return
+	}
+	return p.recvSecureMessage()
+}
+
+func (p *VpP2pApiClient) sendSecureMessage(request *SecureMessageRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("SecureMessage", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := VpP2pApiSecureMessageArgs{
+		Request: request,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *VpP2pApiClient) recvSecureMessage() (value *SecureMessageResponse, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "SecureMessage" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SecureMessage failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SecureMessage failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error95 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error96 error
+		error96, err = error95.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error96
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SecureMessage failed: invalid message type")
+		return
+	}
+	result := VpP2pApiSecureMessageResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+type VpP2pApiProcessor struct {
+	*vpcommonapi.VpCommonApiProcessor
+}
+
+func NewVpP2pApiProcessor(handler VpP2pApi) *VpP2pApiProcessor {
+	self97 := &VpP2pApiProcessor{vpcommonapi.NewVpCommonApiProcessor(handler)}
+	self97.AddToProcessorMap("Status", &vpP2pApiProcessorStatus{handler: handler})
+	self97.AddToProcessorMap("Challenge", &vpP2pApiProcessorChallenge{handler: handler})
+	self97.AddToProcessorMap("Lookup", &vpP2pApiProcessorLookup{handler: handler})
+	self97.AddToProcessorMap("LookupMany", &vpP2pApiProcessorLookupMany{handler: handler})
+	self97.AddToProcessorMap("GetSuccessors", &vpP2pApiProcessorGetSuccessors{handler: handler})
+	self97.AddToProcessorMap("GetPredecessor", &vpP2pApiProcessorGetPredecessor{handler: handler})
+	self97.AddToProcessorMap("Sync", &vpP2pApiProcessorSync{handler: handler})
+	self97.AddToProcessorMap("Put", &vpP2pApiProcessorPut{handler: handler})
+	self97.AddToProcessorMap("Get", &vpP2pApiProcessorGet{handler: handler})
+	self97.AddToProcessorMap("Delete", &vpP2pApiProcessorDelete{handler: handler})
+	self97.AddToProcessorMap("Merkle", &vpP2pApiProcessorMerkle{handler: handler})
+	self97.AddToProcessorMap("Leave", &vpP2pApiProcessorLeave{handler: handler})
+	self97.AddToProcessorMap("AnnounceRing", &vpP2pApiProcessorAnnounceRing{handler: handler})
+	self97.AddToProcessorMap("ListRings", &vpP2pApiProcessorListRings{handler: handler})
+	self97.AddToProcessorMap("Subscribe", &vpP2pApiProcessorSubscribe{handler: handler})
+	self97.AddToProcessorMap("Unsubscribe", &vpP2pApiProcessorUnsubscribe{handler: handler})
+	self97.AddToProcessorMap("Publish", &vpP2pApiProcessorPublish{handler: handler})
+	self97.AddToProcessorMap("Handshake", &vpP2pApiProcessorHandshake{handler: handler})
+	self97.AddToProcessorMap("SecureMessage", &vpP2pApiProcessorSecureMessage{handler: handler})
+	return self97
+}
+
+type vpP2pApiProcessorStatus struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorStatus) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiStatusArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Status", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiStatusResult{}
+	var retval *HostStatus
+	var err2 error
+	if retval, err2 = p.handler.Status(); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Status: "+err2.Error())
+		oprot.WriteMessageBegin("Status", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Status", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorChallenge struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorChallenge) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiChallengeArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Challenge", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiChallengeResult{}
+	var retval *ChallengeResponse
+	var err2 error
+	if retval, err2 = p.handler.Challenge(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Challenge: "+err2.Error())
+		oprot.WriteMessageBegin("Challenge", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Challenge", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorLookup struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorLookup) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiLookupArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Lookup", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiLookupResult{}
+	var retval *LookupResponse
+	var err2 error
+	if retval, err2 = p.handler.Lookup(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Lookup: "+err2.Error())
+		oprot.WriteMessageBegin("Lookup", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Lookup", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorLookupMany struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorLookupMany) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiLookupManyArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("LookupMany", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiLookupManyResult{}
+	var retval *LookupManyResponse
+	var err2 error
+	if retval, err2 = p.handler.LookupMany(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing LookupMany: "+err2.Error())
+		oprot.WriteMessageBegin("LookupMany", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("LookupMany", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorGetSuccessors struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorGetSuccessors) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiGetSuccessorsArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetSuccessors", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiGetSuccessorsResult{}
+	var retval *GetSuccessorsResponse
+	var err2 error
+	if retval, err2 = p.handler.GetSuccessors(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetSuccessors: "+err2.Error())
+		oprot.WriteMessageBegin("GetSuccessors", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetSuccessors", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorGetPredecessor struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorGetPredecessor) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiGetPredecessorArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetPredecessor", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiGetPredecessorResult{}
+	var retval *GetPredecessorResponse
+	var err2 error
+	if retval, err2 = p.handler.GetPredecessor(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetPredecessor: "+err2.Error())
+		oprot.WriteMessageBegin("GetPredecessor", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetPredecessor", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorSync struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorSync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiSyncArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Sync", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiSyncResult{}
+	var retval *SyncResponse
+	var err2 error
+	if retval, err2 = p.handler.Sync(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Sync: "+err2.Error())
+		oprot.WriteMessageBegin("Sync", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Sync", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorPut struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorPut) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiPutArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Put", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiPutResult{}
+	var retval *PutResponse
+	var err2 error
+	if retval, err2 = p.handler.Put(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Put: "+err2.Error())
+		oprot.WriteMessageBegin("Put", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Put", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorGet struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorGet) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiGetArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Get", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiGetResult{}
+	var retval *GetResponse
+	var err2 error
+	if retval, err2 = p.handler.Get(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Get: "+err2.Error())
+		oprot.WriteMessageBegin("Get", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Get", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorDelete struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorDelete) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiDeleteArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Delete", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiDeleteResult{}
+	var retval *DeleteResponse
+	var err2 error
+	if retval, err2 = p.handler.Delete(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Delete: "+err2.Error())
+		oprot.WriteMessageBegin("Delete", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Delete", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorMerkle struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorMerkle) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiMerkleArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Merkle", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiMerkleResult{}
+	var retval *MerkleResponse
+	var err2 error
+	if retval, err2 = p.handler.Merkle(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Merkle: "+err2.Error())
+		oprot.WriteMessageBegin("Merkle", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Merkle", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorLeave struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorLeave) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiLeaveArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Leave", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiLeaveResult{}
+	var retval *LeaveResponse
+	var err2 error
+	if retval, err2 = p.handler.Leave(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Leave: "+err2.Error())
+		oprot.WriteMessageBegin("Leave", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Leave", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorAnnounceRing struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorAnnounceRing) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiAnnounceRingArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("AnnounceRing", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiAnnounceRingResult{}
+	var retval *AnnounceRingResponse
+	var err2 error
+	if retval, err2 = p.handler.AnnounceRing(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing AnnounceRing: "+err2.Error())
+		oprot.WriteMessageBegin("AnnounceRing", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("AnnounceRing", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorListRings struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorListRings) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiListRingsArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("ListRings", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiListRingsResult{}
+	var retval *ListRingsResponse
+	var err2 error
+	if retval, err2 = p.handler.ListRings(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListRings: "+err2.Error())
+		oprot.WriteMessageBegin("ListRings", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("ListRings", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorSubscribe struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorSubscribe) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiSubscribeArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Subscribe", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiSubscribeResult{}
+	var retval *SubscribeResponse
+	var err2 error
+	if retval, err2 = p.handler.Subscribe(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Subscribe: "+err2.Error())
+		oprot.WriteMessageBegin("Subscribe", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Subscribe", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorUnsubscribe struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorUnsubscribe) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiUnsubscribeArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Unsubscribe", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiUnsubscribeResult{}
+	var retval *UnsubscribeResponse
+	var err2 error
+	if retval, err2 = p.handler.Unsubscribe(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Unsubscribe: "+err2.Error())
+		oprot.WriteMessageBegin("Unsubscribe", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Unsubscribe", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorPublish struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorPublish) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiPublishArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Publish", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiPublishResult{}
+	var retval *PublishResponse
+	var err2 error
+	if retval, err2 = p.handler.Publish(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Publish: "+err2.Error())
+		oprot.WriteMessageBegin("Publish", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Publish", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorHandshake struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorHandshake) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiHandshakeArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("Handshake", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiHandshakeResult{}
+	var retval *HandshakeResponse
+	var err2 error
+	if retval, err2 = p.handler.Handshake(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Handshake: "+err2.Error())
+		oprot.WriteMessageBegin("Handshake", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("Handshake", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+type vpP2pApiProcessorSecureMessage struct {
+	handler VpP2pApi
+}
+
+func (p *vpP2pApiProcessorSecureMessage) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := VpP2pApiSecureMessageArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("SecureMessage", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := VpP2pApiSecureMessageResult{}
+	var retval *SecureMessageResponse
+	var err2 error
+	if retval, err2 = p.handler.SecureMessage(args.Request); err2 != nil {
+		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SecureMessage: "+err2.Error())
+		oprot.WriteMessageBegin("SecureMessage", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return true, err2
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("SecureMessage", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+type VpP2pApiStatusArgs struct {
+}
+
+func NewVpP2pApiStatusArgs() *VpP2pApiStatusArgs {
+	return &VpP2pApiStatusArgs{}
+}
+
+func (p *VpP2pApiStatusArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiStatusArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Status_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiStatusArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiStatusArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiStatusResult struct {
+	Success *HostStatus `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiStatusResult() *VpP2pApiStatusResult {
+	return &VpP2pApiStatusResult{}
+}
+
+var VpP2pApiStatusResult_Success_DEFAULT *HostStatus
+
+func (p *VpP2pApiStatusResult) GetSuccess() *HostStatus {
+	if !p.IsSetSuccess() {
+		return VpP2pApiStatusResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiStatusResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiStatusResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiStatusResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &HostStatus{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiStatusResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Status_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiStatusResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiStatusResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiChallengeArgs struct {
+	Request *ChallengeRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiChallengeArgs() *VpP2pApiChallengeArgs {
+	return &VpP2pApiChallengeArgs{}
+}
+
+var VpP2pApiChallengeArgs_Request_DEFAULT *ChallengeRequest
+
+func (p *VpP2pApiChallengeArgs) GetRequest() *ChallengeRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiChallengeArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiChallengeArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiChallengeArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiChallengeArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &ChallengeRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiChallengeArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Challenge_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiChallengeArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiChallengeArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiChallengeArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiChallengeResult struct {
+	Success *ChallengeResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiChallengeResult() *VpP2pApiChallengeResult {
+	return &VpP2pApiChallengeResult{}
+}
+
+var VpP2pApiChallengeResult_Success_DEFAULT *ChallengeResponse
+
+func (p *VpP2pApiChallengeResult) GetSuccess() *ChallengeResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiChallengeResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiChallengeResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiChallengeResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiChallengeResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &ChallengeResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiChallengeResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Challenge_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiChallengeResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiChallengeResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiChallengeResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiLookupArgs struct {
+	Request *LookupRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiLookupArgs() *VpP2pApiLookupArgs {
+	return &VpP2pApiLookupArgs{}
+}
+
+var VpP2pApiLookupArgs_Request_DEFAULT *LookupRequest
+
+func (p *VpP2pApiLookupArgs) GetRequest() *LookupRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiLookupArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiLookupArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiLookupArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &LookupRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Lookup_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiLookupArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiLookupArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiLookupResult struct {
+	Success *LookupResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiLookupResult() *VpP2pApiLookupResult {
+	return &VpP2pApiLookupResult{}
+}
+
+var VpP2pApiLookupResult_Success_DEFAULT *LookupResponse
+
+func (p *VpP2pApiLookupResult) GetSuccess() *LookupResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiLookupResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiLookupResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiLookupResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &LookupResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Lookup_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiLookupResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiLookupResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiLookupManyArgs struct {
+	Request *LookupManyRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiLookupManyArgs() *VpP2pApiLookupManyArgs {
+	return &VpP2pApiLookupManyArgs{}
+}
+
+var VpP2pApiLookupManyArgs_Request_DEFAULT *LookupManyRequest
+
+func (p *VpP2pApiLookupManyArgs) GetRequest() *LookupManyRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiLookupManyArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiLookupManyArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiLookupManyArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupManyArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &LookupManyRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupManyArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LookupMany_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupManyArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiLookupManyArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiLookupManyArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiLookupManyResult struct {
+	Success *LookupManyResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiLookupManyResult() *VpP2pApiLookupManyResult {
+	return &VpP2pApiLookupManyResult{}
+}
+
+var VpP2pApiLookupManyResult_Success_DEFAULT *LookupManyResponse
+
+func (p *VpP2pApiLookupManyResult) GetSuccess() *LookupManyResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiLookupManyResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiLookupManyResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiLookupManyResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupManyResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &LookupManyResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupManyResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("LookupMany_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLookupManyResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiLookupManyResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiLookupManyResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiGetSuccessorsArgs struct {
+	Request *GetSuccessorsRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiGetSuccessorsArgs() *VpP2pApiGetSuccessorsArgs {
+	return &VpP2pApiGetSuccessorsArgs{}
+}
+
+var VpP2pApiGetSuccessorsArgs_Request_DEFAULT *GetSuccessorsRequest
+
+func (p *VpP2pApiGetSuccessorsArgs) GetRequest() *GetSuccessorsRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiGetSuccessorsArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiGetSuccessorsArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiGetSuccessorsArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetSuccessorsArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &GetSuccessorsRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetSuccessorsArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetSuccessors_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetSuccessorsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiGetSuccessorsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiGetSuccessorsArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiGetSuccessorsResult struct {
+	Success *GetSuccessorsResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiGetSuccessorsResult() *VpP2pApiGetSuccessorsResult {
+	return &VpP2pApiGetSuccessorsResult{}
+}
+
+var VpP2pApiGetSuccessorsResult_Success_DEFAULT *GetSuccessorsResponse
+
+func (p *VpP2pApiGetSuccessorsResult) GetSuccess() *GetSuccessorsResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiGetSuccessorsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiGetSuccessorsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiGetSuccessorsResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetSuccessorsResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &GetSuccessorsResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetSuccessorsResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetSuccessors_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetSuccessorsResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiGetSuccessorsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiGetSuccessorsResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiGetPredecessorArgs struct {
+	Request *GetPredecessorRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiGetPredecessorArgs() *VpP2pApiGetPredecessorArgs {
+	return &VpP2pApiGetPredecessorArgs{}
+}
+
+var VpP2pApiGetPredecessorArgs_Request_DEFAULT *GetPredecessorRequest
+
+func (p *VpP2pApiGetPredecessorArgs) GetRequest() *GetPredecessorRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiGetPredecessorArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiGetPredecessorArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiGetPredecessorArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetPredecessorArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &GetPredecessorRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetPredecessorArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetPredecessor_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetPredecessorArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiGetPredecessorArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiGetPredecessorArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiGetPredecessorResult struct {
+	Success *GetPredecessorResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiGetPredecessorResult() *VpP2pApiGetPredecessorResult {
+	return &VpP2pApiGetPredecessorResult{}
+}
+
+var VpP2pApiGetPredecessorResult_Success_DEFAULT *GetPredecessorResponse
+
+func (p *VpP2pApiGetPredecessorResult) GetSuccess() *GetPredecessorResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiGetPredecessorResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiGetPredecessorResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiGetPredecessorResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetPredecessorResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &GetPredecessorResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetPredecessorResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetPredecessor_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetPredecessorResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiGetPredecessorResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiGetPredecessorResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiSyncArgs struct {
+	Request *SyncRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiSyncArgs() *VpP2pApiSyncArgs {
+	return &VpP2pApiSyncArgs{}
+}
+
+var VpP2pApiSyncArgs_Request_DEFAULT *SyncRequest
+
+func (p *VpP2pApiSyncArgs) GetRequest() *SyncRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiSyncArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiSyncArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiSyncArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiSyncArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &SyncRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiSyncArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Sync_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiSyncArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiSyncArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiSyncArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiSyncResult struct {
+	Success *SyncResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiSyncResult() *VpP2pApiSyncResult {
+	return &VpP2pApiSyncResult{}
+}
+
+var VpP2pApiSyncResult_Success_DEFAULT *SyncResponse
+
+func (p *VpP2pApiSyncResult) GetSuccess() *SyncResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiSyncResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiSyncResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiSyncResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiSyncResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &SyncResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiSyncResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Sync_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiSyncResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiSyncResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiSyncResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiPutArgs struct {
+	Request *PutRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiPutArgs() *VpP2pApiPutArgs {
+	return &VpP2pApiPutArgs{}
+}
+
+var VpP2pApiPutArgs_Request_DEFAULT *PutRequest
+
+func (p *VpP2pApiPutArgs) GetRequest() *PutRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiPutArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiPutArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiPutArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiPutArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &PutRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiPutArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Put_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiPutArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiPutArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiPutArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiPutResult struct {
+	Success *PutResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiPutResult() *VpP2pApiPutResult {
+	return &VpP2pApiPutResult{}
+}
+
+var VpP2pApiPutResult_Success_DEFAULT *PutResponse
+
+func (p *VpP2pApiPutResult) GetSuccess() *PutResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiPutResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiPutResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiPutResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiPutResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &PutResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiPutResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Put_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiPutResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiPutResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiPutResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiGetArgs struct {
+	Request *GetRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiGetArgs() *VpP2pApiGetArgs {
+	return &VpP2pApiGetArgs{}
+}
+
+var VpP2pApiGetArgs_Request_DEFAULT *GetRequest
+
+func (p *VpP2pApiGetArgs) GetRequest() *GetRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiGetArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiGetArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiGetArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &GetRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Get_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiGetArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiGetArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiGetResult struct {
+	Success *GetResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiGetResult() *VpP2pApiGetResult {
+	return &VpP2pApiGetResult{}
+}
+
+var VpP2pApiGetResult_Success_DEFAULT *GetResponse
+
+func (p *VpP2pApiGetResult) GetSuccess() *GetResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiGetResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiGetResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiGetResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &GetResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Get_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiGetResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiGetResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiGetResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiDeleteArgs struct {
+	Request *DeleteRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiDeleteArgs() *VpP2pApiDeleteArgs {
+	return &VpP2pApiDeleteArgs{}
+}
+
+var VpP2pApiDeleteArgs_Request_DEFAULT *DeleteRequest
+
+func (p *VpP2pApiDeleteArgs) GetRequest() *DeleteRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiDeleteArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiDeleteArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiDeleteArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiDeleteArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &DeleteRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiDeleteArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Delete_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiDeleteArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiDeleteArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiDeleteArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiDeleteResult struct {
+	Success *DeleteResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiDeleteResult() *VpP2pApiDeleteResult {
+	return &VpP2pApiDeleteResult{}
+}
+
+var VpP2pApiDeleteResult_Success_DEFAULT *DeleteResponse
+
+func (p *VpP2pApiDeleteResult) GetSuccess() *DeleteResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiDeleteResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiDeleteResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiDeleteResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiDeleteResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &DeleteResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiDeleteResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Delete_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiDeleteResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiDeleteResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiDeleteResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiMerkleArgs struct {
+	Request *MerkleRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiMerkleArgs() *VpP2pApiMerkleArgs {
+	return &VpP2pApiMerkleArgs{}
+}
+
+var VpP2pApiMerkleArgs_Request_DEFAULT *MerkleRequest
+
+func (p *VpP2pApiMerkleArgs) GetRequest() *MerkleRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiMerkleArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiMerkleArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiMerkleArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiMerkleArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &MerkleRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiMerkleArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Merkle_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiMerkleArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiMerkleArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiMerkleArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiMerkleResult struct {
+	Success *MerkleResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiMerkleResult() *VpP2pApiMerkleResult {
+	return &VpP2pApiMerkleResult{}
+}
+
+var VpP2pApiMerkleResult_Success_DEFAULT *MerkleResponse
+
+func (p *VpP2pApiMerkleResult) GetSuccess() *MerkleResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiMerkleResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiMerkleResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiMerkleResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiMerkleResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &MerkleResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiMerkleResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Merkle_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiMerkleResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiMerkleResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiMerkleResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiLeaveArgs struct {
+	Request *LeaveRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiLeaveArgs() *VpP2pApiLeaveArgs {
+	return &VpP2pApiLeaveArgs{}
+}
+
+var VpP2pApiLeaveArgs_Request_DEFAULT *LeaveRequest
+
+func (p *VpP2pApiLeaveArgs) GetRequest() *LeaveRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiLeaveArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiLeaveArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiLeaveArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLeaveArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &LeaveRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLeaveArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Leave_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLeaveArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiLeaveArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiLeaveArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiLeaveResult struct {
+	Success *LeaveResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiLeaveResult() *VpP2pApiLeaveResult {
+	return &VpP2pApiLeaveResult{}
+}
+
+var VpP2pApiLeaveResult_Success_DEFAULT *LeaveResponse
+
+func (p *VpP2pApiLeaveResult) GetSuccess() *LeaveResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiLeaveResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiLeaveResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiLeaveResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLeaveResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &LeaveResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLeaveResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Leave_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiLeaveResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiLeaveResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiLeaveResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiAnnounceRingArgs struct {
+	Request *AnnounceRingRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiAnnounceRingArgs() *VpP2pApiAnnounceRingArgs {
+	return &VpP2pApiAnnounceRingArgs{}
+}
+
+var VpP2pApiAnnounceRingArgs_Request_DEFAULT *AnnounceRingRequest
+
+func (p *VpP2pApiAnnounceRingArgs) GetRequest() *AnnounceRingRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiAnnounceRingArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiAnnounceRingArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiAnnounceRingArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiAnnounceRingArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &AnnounceRingRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiAnnounceRingArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AnnounceRing_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiAnnounceRingArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiAnnounceRingArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiAnnounceRingArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiAnnounceRingResult struct {
+	Success *AnnounceRingResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiAnnounceRingResult() *VpP2pApiAnnounceRingResult {
+	return &VpP2pApiAnnounceRingResult{}
+}
+
+var VpP2pApiAnnounceRingResult_Success_DEFAULT *AnnounceRingResponse
+
+func (p *VpP2pApiAnnounceRingResult) GetSuccess() *AnnounceRingResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiAnnounceRingResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiAnnounceRingResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiAnnounceRingResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiAnnounceRingResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &AnnounceRingResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiAnnounceRingResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AnnounceRing_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiAnnounceRingResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *VpP2pApiAnnounceRingResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiAnnounceRingResult(%+v)", *p)
+}
+
+// Attributes:
+//  - Request
+type VpP2pApiListRingsArgs struct {
+	Request *ListRingsRequest `thrift:"request,1" json:"request"`
+}
+
+func NewVpP2pApiListRingsArgs() *VpP2pApiListRingsArgs {
+	return &VpP2pApiListRingsArgs{}
+}
+
+var VpP2pApiListRingsArgs_Request_DEFAULT *ListRingsRequest
+
+func (p *VpP2pApiListRingsArgs) GetRequest() *ListRingsRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiListRingsArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiListRingsArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiListRingsArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiListRingsArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &ListRingsRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiListRingsArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListRings_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiListRingsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiListRingsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiListRingsArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+type VpP2pApiListRingsResult struct {
+	Success *ListRingsResponse `thrift:"success,0" json:"success,omitempty"`
+}
+
+func NewVpP2pApiListRingsResult() *VpP2pApiListRingsResult {
+	return &VpP2pApiListRingsResult{}
+}
+
+var VpP2pApiListRingsResult_Success_DEFAULT *ListRingsResponse
+
+func (p *VpP2pApiListRingsResult) GetSuccess() *ListRingsResponse {
+	if !p.IsSetSuccess() {
+		return VpP2pApiListRingsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+func (p *VpP2pApiListRingsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *VpP2pApiListRingsResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := VpP2pApiSyncResult{}
-	var retval *SyncResponse
-	var err2 error
-	if retval, err2 = p.handler.Sync(args.Request); err2 != nil {
-		x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing Sync: "+err2.Error())
-		oprot.WriteMessageBegin("Sync", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return true, err2
-	} else {
-		result.Success = retval
+func (p *VpP2pApiListRingsResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &ListRingsResponse{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
-	if err2 = oprot.WriteMessageBegin("Sync", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *VpP2pApiListRingsResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ListRings_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := p.writeField0(oprot); err != nil {
+		return err
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err != nil {
-		return
+	return nil
+}
+
+func (p *VpP2pApiListRingsResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	return true, err
+	return err
 }
 
-// HELPER FUNCTIONS AND STRUCTURES
+func (p *VpP2pApiListRingsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VpP2pApiListRingsResult(%+v)", *p)
+}
 
-type VpP2pApiStatusArgs struct {
+// Attributes:
+//  - Request
+type VpP2pApiSubscribeArgs struct {
+	Request *SubscribeRequest `thrift:"request,1" json:"request"`
 }
 
-func NewVpP2pApiStatusArgs() *VpP2pApiStatusArgs {
-	return &VpP2pApiStatusArgs{}
+func NewVpP2pApiSubscribeArgs() *VpP2pApiSubscribeArgs {
+	return &VpP2pApiSubscribeArgs{}
 }
 
-func (p *VpP2pApiStatusArgs) Read(iprot thrift.TProtocol) error {
+var VpP2pApiSubscribeArgs_Request_DEFAULT *SubscribeRequest
+
+func (p *VpP2pApiSubscribeArgs) GetRequest() *SubscribeRequest {
+	if !p.IsSetRequest() {
+		return VpP2pApiSubscribeArgs_Request_DEFAULT
+	}
+	return p.Request
+}
+func (p *VpP2pApiSubscribeArgs) IsSetRequest() bool {
+	return p.Request != nil
+}
+
+func (p *VpP2pApiSubscribeArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -708,8 +5283,15 @@ func (p *VpP2pApiStatusArgs) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -721,10 +5303,21 @@ func (p *VpP2pApiStatusArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiStatusArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Status_args"); err != nil {
+func (p *VpP2pApiSubscribeArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &SubscribeRequest{}
+	if err := p.Request.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
+	}
+	return nil
+}
+
+func (p *VpP2pApiSubscribeArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Subscribe_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -734,36 +5327,49 @@ func (p *VpP2pApiStatusArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiStatusArgs) String() string {
+func (p *VpP2pApiSubscribeArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
+	}
+	if err := p.Request.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err)
+	}
+	return err
+}
+
+func (p *VpP2pApiSubscribeArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiStatusArgs(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiSubscribeArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-type VpP2pApiStatusResult struct {
-	Success *HostStatus `thrift:"success,0" json:"success,omitempty"`
+type VpP2pApiSubscribeResult struct {
+	Success *SubscribeResponse `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewVpP2pApiStatusResult() *VpP2pApiStatusResult {
-	return &VpP2pApiStatusResult{}
+func NewVpP2pApiSubscribeResult() *VpP2pApiSubscribeResult {
+	return &VpP2pApiSubscribeResult{}
 }
 
-var VpP2pApiStatusResult_Success_DEFAULT *HostStatus
+var VpP2pApiSubscribeResult_Success_DEFAULT *SubscribeResponse
 
-func (p *VpP2pApiStatusResult) GetSuccess() *HostStatus {
+func (p *VpP2pApiSubscribeResult) GetSuccess() *SubscribeResponse {
 	if !p.IsSetSuccess() {
-		return VpP2pApiStatusResult_Success_DEFAULT
+		return VpP2pApiSubscribeResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *VpP2pApiStatusResult) IsSetSuccess() bool {
+func (p *VpP2pApiSubscribeResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *VpP2pApiStatusResult) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiSubscribeResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -796,16 +5402,16 @@ func (p *VpP2pApiStatusResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiStatusResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &HostStatus{}
+func (p *VpP2pApiSubscribeResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &SubscribeResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiStatusResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Status_result"); err != nil {
+func (p *VpP2pApiSubscribeResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Subscribe_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -820,7 +5426,7 @@ func (p *VpP2pApiStatusResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiSubscribeResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -835,36 +5441,36 @@ func (p *VpP2pApiStatusResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *VpP2pApiStatusResult) String() string {
+func (p *VpP2pApiSubscribeResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiStatusResult(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiSubscribeResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Request
-type VpP2pApiLookupArgs struct {
-	Request *LookupRequest `thrift:"request,1" json:"request"`
+type VpP2pApiUnsubscribeArgs struct {
+	Request *UnsubscribeRequest `thrift:"request,1" json:"request"`
 }
 
-func NewVpP2pApiLookupArgs() *VpP2pApiLookupArgs {
-	return &VpP2pApiLookupArgs{}
+func NewVpP2pApiUnsubscribeArgs() *VpP2pApiUnsubscribeArgs {
+	return &VpP2pApiUnsubscribeArgs{}
 }
 
-var VpP2pApiLookupArgs_Request_DEFAULT *LookupRequest
+var VpP2pApiUnsubscribeArgs_Request_DEFAULT *UnsubscribeRequest
 
-func (p *VpP2pApiLookupArgs) GetRequest() *LookupRequest {
+func (p *VpP2pApiUnsubscribeArgs) GetRequest() *UnsubscribeRequest {
 	if !p.IsSetRequest() {
-		return VpP2pApiLookupArgs_Request_DEFAULT
+		return VpP2pApiUnsubscribeArgs_Request_DEFAULT
 	}
 	return p.Request
 }
-func (p *VpP2pApiLookupArgs) IsSetRequest() bool {
+func (p *VpP2pApiUnsubscribeArgs) IsSetRequest() bool {
 	return p.Request != nil
 }
 
-func (p *VpP2pApiLookupArgs) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiUnsubscribeArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -897,16 +5503,16 @@ func (p *VpP2pApiLookupArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiLookupArgs) readField1(iprot thrift.TProtocol) error {
-	p.Request = &LookupRequest{}
+func (p *VpP2pApiUnsubscribeArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &UnsubscribeRequest{}
 	if err := p.Request.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiLookupArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Lookup_args"); err != nil {
+func (p *VpP2pApiUnsubscribeArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Unsubscribe_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -921,7 +5527,7 @@ func (p *VpP2pApiLookupArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiLookupArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiUnsubscribeArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
 	}
@@ -934,36 +5540,36 @@ func (p *VpP2pApiLookupArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *VpP2pApiLookupArgs) String() string {
+func (p *VpP2pApiUnsubscribeArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiLookupArgs(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiUnsubscribeArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-type VpP2pApiLookupResult struct {
-	Success *LookupResponse `thrift:"success,0" json:"success,omitempty"`
+type VpP2pApiUnsubscribeResult struct {
+	Success *UnsubscribeResponse `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewVpP2pApiLookupResult() *VpP2pApiLookupResult {
-	return &VpP2pApiLookupResult{}
+func NewVpP2pApiUnsubscribeResult() *VpP2pApiUnsubscribeResult {
+	return &VpP2pApiUnsubscribeResult{}
 }
 
-var VpP2pApiLookupResult_Success_DEFAULT *LookupResponse
+var VpP2pApiUnsubscribeResult_Success_DEFAULT *UnsubscribeResponse
 
-func (p *VpP2pApiLookupResult) GetSuccess() *LookupResponse {
+func (p *VpP2pApiUnsubscribeResult) GetSuccess() *UnsubscribeResponse {
 	if !p.IsSetSuccess() {
-		return VpP2pApiLookupResult_Success_DEFAULT
+		return VpP2pApiUnsubscribeResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *VpP2pApiLookupResult) IsSetSuccess() bool {
+func (p *VpP2pApiUnsubscribeResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *VpP2pApiLookupResult) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiUnsubscribeResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -996,16 +5602,16 @@ func (p *VpP2pApiLookupResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiLookupResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &LookupResponse{}
+func (p *VpP2pApiUnsubscribeResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &UnsubscribeResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiLookupResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Lookup_result"); err != nil {
+func (p *VpP2pApiUnsubscribeResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Unsubscribe_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1020,7 +5626,7 @@ func (p *VpP2pApiLookupResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiLookupResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiUnsubscribeResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1035,36 +5641,36 @@ func (p *VpP2pApiLookupResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *VpP2pApiLookupResult) String() string {
+func (p *VpP2pApiUnsubscribeResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiLookupResult(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiUnsubscribeResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Request
-type VpP2pApiGetSuccessorsArgs struct {
-	Request *GetSuccessorsRequest `thrift:"request,1" json:"request"`
+type VpP2pApiPublishArgs struct {
+	Request *PublishRequest `thrift:"request,1" json:"request"`
 }
 
-func NewVpP2pApiGetSuccessorsArgs() *VpP2pApiGetSuccessorsArgs {
-	return &VpP2pApiGetSuccessorsArgs{}
+func NewVpP2pApiPublishArgs() *VpP2pApiPublishArgs {
+	return &VpP2pApiPublishArgs{}
 }
 
-var VpP2pApiGetSuccessorsArgs_Request_DEFAULT *GetSuccessorsRequest
+var VpP2pApiPublishArgs_Request_DEFAULT *PublishRequest
 
-func (p *VpP2pApiGetSuccessorsArgs) GetRequest() *GetSuccessorsRequest {
+func (p *VpP2pApiPublishArgs) GetRequest() *PublishRequest {
 	if !p.IsSetRequest() {
-		return VpP2pApiGetSuccessorsArgs_Request_DEFAULT
+		return VpP2pApiPublishArgs_Request_DEFAULT
 	}
 	return p.Request
 }
-func (p *VpP2pApiGetSuccessorsArgs) IsSetRequest() bool {
+func (p *VpP2pApiPublishArgs) IsSetRequest() bool {
 	return p.Request != nil
 }
 
-func (p *VpP2pApiGetSuccessorsArgs) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiPublishArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1097,16 +5703,16 @@ func (p *VpP2pApiGetSuccessorsArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiGetSuccessorsArgs) readField1(iprot thrift.TProtocol) error {
-	p.Request = &GetSuccessorsRequest{}
+func (p *VpP2pApiPublishArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &PublishRequest{}
 	if err := p.Request.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiGetSuccessorsArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetSuccessors_args"); err != nil {
+func (p *VpP2pApiPublishArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Publish_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1121,7 +5727,7 @@ func (p *VpP2pApiGetSuccessorsArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiGetSuccessorsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiPublishArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
 	}
@@ -1134,36 +5740,36 @@ func (p *VpP2pApiGetSuccessorsArgs) writeField1(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *VpP2pApiGetSuccessorsArgs) String() string {
+func (p *VpP2pApiPublishArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiGetSuccessorsArgs(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiPublishArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-type VpP2pApiGetSuccessorsResult struct {
-	Success *GetSuccessorsResponse `thrift:"success,0" json:"success,omitempty"`
+type VpP2pApiPublishResult struct {
+	Success *PublishResponse `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewVpP2pApiGetSuccessorsResult() *VpP2pApiGetSuccessorsResult {
-	return &VpP2pApiGetSuccessorsResult{}
+func NewVpP2pApiPublishResult() *VpP2pApiPublishResult {
+	return &VpP2pApiPublishResult{}
 }
 
-var VpP2pApiGetSuccessorsResult_Success_DEFAULT *GetSuccessorsResponse
+var VpP2pApiPublishResult_Success_DEFAULT *PublishResponse
 
-func (p *VpP2pApiGetSuccessorsResult) GetSuccess() *GetSuccessorsResponse {
+func (p *VpP2pApiPublishResult) GetSuccess() *PublishResponse {
 	if !p.IsSetSuccess() {
-		return VpP2pApiGetSuccessorsResult_Success_DEFAULT
+		return VpP2pApiPublishResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *VpP2pApiGetSuccessorsResult) IsSetSuccess() bool {
+func (p *VpP2pApiPublishResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *VpP2pApiGetSuccessorsResult) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiPublishResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1196,16 +5802,16 @@ func (p *VpP2pApiGetSuccessorsResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiGetSuccessorsResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &GetSuccessorsResponse{}
+func (p *VpP2pApiPublishResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &PublishResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiGetSuccessorsResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetSuccessors_result"); err != nil {
+func (p *VpP2pApiPublishResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Publish_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1220,7 +5826,7 @@ func (p *VpP2pApiGetSuccessorsResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiGetSuccessorsResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiPublishResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1235,36 +5841,36 @@ func (p *VpP2pApiGetSuccessorsResult) writeField0(oprot thrift.TProtocol) (err e
 	return err
 }
 
-func (p *VpP2pApiGetSuccessorsResult) String() string {
+func (p *VpP2pApiPublishResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiGetSuccessorsResult(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiPublishResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Request
-type VpP2pApiGetPredecessorArgs struct {
-	Request *GetPredecessorRequest `thrift:"request,1" json:"request"`
+type VpP2pApiHandshakeArgs struct {
+	Request *HandshakeRequest `thrift:"request,1" json:"request"`
 }
 
-func NewVpP2pApiGetPredecessorArgs() *VpP2pApiGetPredecessorArgs {
-	return &VpP2pApiGetPredecessorArgs{}
+func NewVpP2pApiHandshakeArgs() *VpP2pApiHandshakeArgs {
+	return &VpP2pApiHandshakeArgs{}
 }
 
-var VpP2pApiGetPredecessorArgs_Request_DEFAULT *GetPredecessorRequest
+var VpP2pApiHandshakeArgs_Request_DEFAULT *HandshakeRequest
 
-func (p *VpP2pApiGetPredecessorArgs) GetRequest() *GetPredecessorRequest {
+func (p *VpP2pApiHandshakeArgs) GetRequest() *HandshakeRequest {
 	if !p.IsSetRequest() {
-		return VpP2pApiGetPredecessorArgs_Request_DEFAULT
+		return VpP2pApiHandshakeArgs_Request_DEFAULT
 	}
 	return p.Request
 }
-func (p *VpP2pApiGetPredecessorArgs) IsSetRequest() bool {
+func (p *VpP2pApiHandshakeArgs) IsSetRequest() bool {
 	return p.Request != nil
 }
 
-func (p *VpP2pApiGetPredecessorArgs) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiHandshakeArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1297,16 +5903,16 @@ func (p *VpP2pApiGetPredecessorArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiGetPredecessorArgs) readField1(iprot thrift.TProtocol) error {
-	p.Request = &GetPredecessorRequest{}
+func (p *VpP2pApiHandshakeArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &HandshakeRequest{}
 	if err := p.Request.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiGetPredecessorArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetPredecessor_args"); err != nil {
+func (p *VpP2pApiHandshakeArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Handshake_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1321,7 +5927,7 @@ func (p *VpP2pApiGetPredecessorArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiGetPredecessorArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiHandshakeArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
 	}
@@ -1334,36 +5940,36 @@ func (p *VpP2pApiGetPredecessorArgs) writeField1(oprot thrift.TProtocol) (err er
 	return err
 }
 
-func (p *VpP2pApiGetPredecessorArgs) String() string {
+func (p *VpP2pApiHandshakeArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiGetPredecessorArgs(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiHandshakeArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-type VpP2pApiGetPredecessorResult struct {
-	Success *GetPredecessorResponse `thrift:"success,0" json:"success,omitempty"`
+type VpP2pApiHandshakeResult struct {
+	Success *HandshakeResponse `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewVpP2pApiGetPredecessorResult() *VpP2pApiGetPredecessorResult {
-	return &VpP2pApiGetPredecessorResult{}
+func NewVpP2pApiHandshakeResult() *VpP2pApiHandshakeResult {
+	return &VpP2pApiHandshakeResult{}
 }
 
-var VpP2pApiGetPredecessorResult_Success_DEFAULT *GetPredecessorResponse
+var VpP2pApiHandshakeResult_Success_DEFAULT *HandshakeResponse
 
-func (p *VpP2pApiGetPredecessorResult) GetSuccess() *GetPredecessorResponse {
+func (p *VpP2pApiHandshakeResult) GetSuccess() *HandshakeResponse {
 	if !p.IsSetSuccess() {
-		return VpP2pApiGetPredecessorResult_Success_DEFAULT
+		return VpP2pApiHandshakeResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *VpP2pApiGetPredecessorResult) IsSetSuccess() bool {
+func (p *VpP2pApiHandshakeResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *VpP2pApiGetPredecessorResult) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiHandshakeResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1396,16 +6002,16 @@ func (p *VpP2pApiGetPredecessorResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiGetPredecessorResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &GetPredecessorResponse{}
+func (p *VpP2pApiHandshakeResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &HandshakeResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiGetPredecessorResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("GetPredecessor_result"); err != nil {
+func (p *VpP2pApiHandshakeResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Handshake_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1420,7 +6026,7 @@ func (p *VpP2pApiGetPredecessorResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiGetPredecessorResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiHandshakeResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1435,36 +6041,36 @@ func (p *VpP2pApiGetPredecessorResult) writeField0(oprot thrift.TProtocol) (err
 	return err
 }
 
-func (p *VpP2pApiGetPredecessorResult) String() string {
+func (p *VpP2pApiHandshakeResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiGetPredecessorResult(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiHandshakeResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Request
-type VpP2pApiSyncArgs struct {
-	Request *SyncRequest `thrift:"request,1" json:"request"`
+type VpP2pApiSecureMessageArgs struct {
+	Request *SecureMessageRequest `thrift:"request,1" json:"request"`
 }
 
-func NewVpP2pApiSyncArgs() *VpP2pApiSyncArgs {
-	return &VpP2pApiSyncArgs{}
+func NewVpP2pApiSecureMessageArgs() *VpP2pApiSecureMessageArgs {
+	return &VpP2pApiSecureMessageArgs{}
 }
 
-var VpP2pApiSyncArgs_Request_DEFAULT *SyncRequest
+var VpP2pApiSecureMessageArgs_Request_DEFAULT *SecureMessageRequest
 
-func (p *VpP2pApiSyncArgs) GetRequest() *SyncRequest {
+func (p *VpP2pApiSecureMessageArgs) GetRequest() *SecureMessageRequest {
 	if !p.IsSetRequest() {
-		return VpP2pApiSyncArgs_Request_DEFAULT
+		return VpP2pApiSecureMessageArgs_Request_DEFAULT
 	}
 	return p.Request
 }
-func (p *VpP2pApiSyncArgs) IsSetRequest() bool {
+func (p *VpP2pApiSecureMessageArgs) IsSetRequest() bool {
 	return p.Request != nil
 }
 
-func (p *VpP2pApiSyncArgs) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiSecureMessageArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1497,16 +6103,16 @@ func (p *VpP2pApiSyncArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiSyncArgs) readField1(iprot thrift.TProtocol) error {
-	p.Request = &SyncRequest{}
+func (p *VpP2pApiSecureMessageArgs) readField1(iprot thrift.TProtocol) error {
+	p.Request = &SecureMessageRequest{}
 	if err := p.Request.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiSyncArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Sync_args"); err != nil {
+func (p *VpP2pApiSecureMessageArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SecureMessage_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField1(oprot); err != nil {
@@ -1521,7 +6127,7 @@ func (p *VpP2pApiSyncArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiSyncArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiSecureMessageArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err)
 	}
@@ -1534,36 +6140,36 @@ func (p *VpP2pApiSyncArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *VpP2pApiSyncArgs) String() string {
+func (p *VpP2pApiSecureMessageArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiSyncArgs(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiSecureMessageArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
-type VpP2pApiSyncResult struct {
-	Success *SyncResponse `thrift:"success,0" json:"success,omitempty"`
+type VpP2pApiSecureMessageResult struct {
+	Success *SecureMessageResponse `thrift:"success,0" json:"success,omitempty"`
 }
 
-func NewVpP2pApiSyncResult() *VpP2pApiSyncResult {
-	return &VpP2pApiSyncResult{}
+func NewVpP2pApiSecureMessageResult() *VpP2pApiSecureMessageResult {
+	return &VpP2pApiSecureMessageResult{}
 }
 
-var VpP2pApiSyncResult_Success_DEFAULT *SyncResponse
+var VpP2pApiSecureMessageResult_Success_DEFAULT *SecureMessageResponse
 
-func (p *VpP2pApiSyncResult) GetSuccess() *SyncResponse {
+func (p *VpP2pApiSecureMessageResult) GetSuccess() *SecureMessageResponse {
 	if !p.IsSetSuccess() {
-		return VpP2pApiSyncResult_Success_DEFAULT
+		return VpP2pApiSecureMessageResult_Success_DEFAULT
 	}
 	return p.Success
 }
-func (p *VpP2pApiSyncResult) IsSetSuccess() bool {
+func (p *VpP2pApiSecureMessageResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *VpP2pApiSyncResult) Read(iprot thrift.TProtocol) error {
+func (p *VpP2pApiSecureMessageResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -1596,16 +6202,16 @@ func (p *VpP2pApiSyncResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiSyncResult) readField0(iprot thrift.TProtocol) error {
-	p.Success = &SyncResponse{}
+func (p *VpP2pApiSecureMessageResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &SecureMessageResponse{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *VpP2pApiSyncResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Sync_result"); err != nil {
+func (p *VpP2pApiSecureMessageResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("SecureMessage_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if err := p.writeField0(oprot); err != nil {
@@ -1620,7 +6226,7 @@ func (p *VpP2pApiSyncResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *VpP2pApiSyncResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *VpP2pApiSecureMessageResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -1635,9 +6241,9 @@ func (p *VpP2pApiSyncResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *VpP2pApiSyncResult) String() string {
+func (p *VpP2pApiSecureMessageResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("VpP2pApiSyncResult(%+v)", *p)
+	return fmt.Sprintf("VpP2pApiSecureMessageResult(%+v)", *p)
 }
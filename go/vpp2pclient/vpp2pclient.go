@@ -21,10 +21,24 @@ func Usage() {
 	flag.PrintDefaults()
 	fmt.Fprintln(os.Stderr, "\nFunctions:")
 	fmt.Fprintln(os.Stderr, "  HostStatus Status()")
+	fmt.Fprintln(os.Stderr, "  ChallengeResponse Challenge(ChallengeRequest request)")
 	fmt.Fprintln(os.Stderr, "  LookupResponse Lookup(LookupRequest request)")
+	fmt.Fprintln(os.Stderr, "  LookupManyResponse LookupMany(LookupManyRequest request)")
 	fmt.Fprintln(os.Stderr, "  GetSuccessorsResponse GetSuccessors(GetSuccessorsRequest request)")
 	fmt.Fprintln(os.Stderr, "  GetPredecessorResponse GetPredecessor(GetPredecessorRequest request)")
 	fmt.Fprintln(os.Stderr, "  SyncResponse Sync(SyncRequest request)")
+	fmt.Fprintln(os.Stderr, "  PutResponse Put(PutRequest request)")
+	fmt.Fprintln(os.Stderr, "  GetResponse Get(GetRequest request)")
+	fmt.Fprintln(os.Stderr, "  DeleteResponse Delete(DeleteRequest request)")
+	fmt.Fprintln(os.Stderr, "  MerkleResponse Merkle(MerkleRequest request)")
+	fmt.Fprintln(os.Stderr, "  LeaveResponse Leave(LeaveRequest request)")
+	fmt.Fprintln(os.Stderr, "  AnnounceRingResponse AnnounceRing(AnnounceRingRequest request)")
+	fmt.Fprintln(os.Stderr, "  ListRingsResponse ListRings(ListRingsRequest request)")
+	fmt.Fprintln(os.Stderr, "  SubscribeResponse Subscribe(SubscribeRequest request)")
+	fmt.Fprintln(os.Stderr, "  UnsubscribeResponse Unsubscribe(UnsubscribeRequest request)")
+	fmt.Fprintln(os.Stderr, "  PublishResponse Publish(PublishRequest request)")
+	fmt.Fprintln(os.Stderr, "  HandshakeResponse Handshake(HandshakeRequest request)")
+	fmt.Fprintln(os.Stderr, "  SecureMessageResponse SecureMessage(SecureMessageRequest request)")
 	fmt.Fprintln(os.Stderr, "  void ping()")
 	fmt.Fprintln(os.Stderr, "  Version getVersion()")
 	fmt.Fprintln(os.Stderr, "  Package getPackage()")
@@ -131,24 +145,49 @@ func main() {
 		fmt.Print(client.Status())
 		fmt.Print("\n")
 		break
+	case "Challenge":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Challenge requires 1 args")
+			flag.Usage()
+		}
+		arg98 := flag.Arg(1)
+		mbTrans99 := thrift.NewTMemoryBufferLen(len(arg98))
+		defer mbTrans99.Close()
+		_, err100 := mbTrans99.WriteString(arg98)
+		if err100 != nil {
+			Usage()
+			return
+		}
+		factory101 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt102 := factory101.GetProtocol(mbTrans99)
+		argvalue0 := vpp2papi.NewChallengeRequest()
+		err103 := argvalue0.Read(jsProt102)
+		if err103 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Challenge(value0))
+		fmt.Print("\n")
+		break
 	case "Lookup":
 		if flag.NArg()-1 != 1 {
 			fmt.Fprintln(os.Stderr, "Lookup requires 1 args")
 			flag.Usage()
 		}
-		arg29 := flag.Arg(1)
-		mbTrans30 := thrift.NewTMemoryBufferLen(len(arg29))
-		defer mbTrans30.Close()
-		_, err31 := mbTrans30.WriteString(arg29)
-		if err31 != nil {
+		arg104 := flag.Arg(1)
+		mbTrans105 := thrift.NewTMemoryBufferLen(len(arg104))
+		defer mbTrans105.Close()
+		_, err106 := mbTrans105.WriteString(arg104)
+		if err106 != nil {
 			Usage()
 			return
 		}
-		factory32 := thrift.NewTSimpleJSONProtocolFactory()
-		jsProt33 := factory32.GetProtocol(mbTrans30)
+		factory107 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt108 := factory107.GetProtocol(mbTrans105)
 		argvalue0 := vpp2papi.NewLookupRequest()
-		err34 := argvalue0.Read(jsProt33)
-		if err34 != nil {
+		err109 := argvalue0.Read(jsProt108)
+		if err109 != nil {
 			Usage()
 			return
 		}
@@ -156,24 +195,49 @@ func main() {
 		fmt.Print(client.Lookup(value0))
 		fmt.Print("\n")
 		break
+	case "LookupMany":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "LookupMany requires 1 args")
+			flag.Usage()
+		}
+		arg110 := flag.Arg(1)
+		mbTrans111 := thrift.NewTMemoryBufferLen(len(arg110))
+		defer mbTrans111.Close()
+		_, err112 := mbTrans111.WriteString(arg110)
+		if err112 != nil {
+			Usage()
+			return
+		}
+		factory113 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt114 := factory113.GetProtocol(mbTrans111)
+		argvalue0 := vpp2papi.NewLookupManyRequest()
+		err115 := argvalue0.Read(jsProt114)
+		if err115 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.LookupMany(value0))
+		fmt.Print("\n")
+		break
 	case "GetSuccessors":
 		if flag.NArg()-1 != 1 {
 			fmt.Fprintln(os.Stderr, "GetSuccessors requires 1 args")
 			flag.Usage()
 		}
-		arg35 := flag.Arg(1)
-		mbTrans36 := thrift.NewTMemoryBufferLen(len(arg35))
-		defer mbTrans36.Close()
-		_, err37 := mbTrans36.WriteString(arg35)
-		if err37 != nil {
+		arg116 := flag.Arg(1)
+		mbTrans117 := thrift.NewTMemoryBufferLen(len(arg116))
+		defer mbTrans117.Close()
+		_, err118 := mbTrans117.WriteString(arg116)
+		if err118 != nil {
 			Usage()
 			return
 		}
-		factory38 := thrift.NewTSimpleJSONProtocolFactory()
-		jsProt39 := factory38.GetProtocol(mbTrans36)
+		factory119 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt120 := factory119.GetProtocol(mbTrans117)
 		argvalue0 := vpp2papi.NewGetSuccessorsRequest()
-		err40 := argvalue0.Read(jsProt39)
-		if err40 != nil {
+		err121 := argvalue0.Read(jsProt120)
+		if err121 != nil {
 			Usage()
 			return
 		}
@@ -186,19 +250,19 @@ func main() {
 			fmt.Fprintln(os.Stderr, "GetPredecessor requires 1 args")
 			flag.Usage()
 		}
-		arg41 := flag.Arg(1)
-		mbTrans42 := thrift.NewTMemoryBufferLen(len(arg41))
-		defer mbTrans42.Close()
-		_, err43 := mbTrans42.WriteString(arg41)
-		if err43 != nil {
+		arg122 := flag.Arg(1)
+		mbTrans123 := thrift.NewTMemoryBufferLen(len(arg122))
+		defer mbTrans123.Close()
+		_, err124 := mbTrans123.WriteString(arg122)
+		if err124 != nil {
 			Usage()
 			return
 		}
-		factory44 := thrift.NewTSimpleJSONProtocolFactory()
-		jsProt45 := factory44.GetProtocol(mbTrans42)
+		factory125 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt126 := factory125.GetProtocol(mbTrans123)
 		argvalue0 := vpp2papi.NewGetPredecessorRequest()
-		err46 := argvalue0.Read(jsProt45)
-		if err46 != nil {
+		err127 := argvalue0.Read(jsProt126)
+		if err127 != nil {
 			Usage()
 			return
 		}
@@ -211,19 +275,19 @@ func main() {
 			fmt.Fprintln(os.Stderr, "Sync requires 1 args")
 			flag.Usage()
 		}
-		arg47 := flag.Arg(1)
-		mbTrans48 := thrift.NewTMemoryBufferLen(len(arg47))
-		defer mbTrans48.Close()
-		_, err49 := mbTrans48.WriteString(arg47)
-		if err49 != nil {
+		arg128 := flag.Arg(1)
+		mbTrans129 := thrift.NewTMemoryBufferLen(len(arg128))
+		defer mbTrans129.Close()
+		_, err130 := mbTrans129.WriteString(arg128)
+		if err130 != nil {
 			Usage()
 			return
 		}
-		factory50 := thrift.NewTSimpleJSONProtocolFactory()
-		jsProt51 := factory50.GetProtocol(mbTrans48)
+		factory131 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt132 := factory131.GetProtocol(mbTrans129)
 		argvalue0 := vpp2papi.NewSyncRequest()
-		err52 := argvalue0.Read(jsProt51)
-		if err52 != nil {
+		err133 := argvalue0.Read(jsProt132)
+		if err133 != nil {
 			Usage()
 			return
 		}
@@ -231,6 +295,306 @@ func main() {
 		fmt.Print(client.Sync(value0))
 		fmt.Print("\n")
 		break
+	case "Put":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Put requires 1 args")
+			flag.Usage()
+		}
+		arg134 := flag.Arg(1)
+		mbTrans135 := thrift.NewTMemoryBufferLen(len(arg134))
+		defer mbTrans135.Close()
+		_, err136 := mbTrans135.WriteString(arg134)
+		if err136 != nil {
+			Usage()
+			return
+		}
+		factory137 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt138 := factory137.GetProtocol(mbTrans135)
+		argvalue0 := vpp2papi.NewPutRequest()
+		err139 := argvalue0.Read(jsProt138)
+		if err139 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Put(value0))
+		fmt.Print("\n")
+		break
+	case "Get":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Get requires 1 args")
+			flag.Usage()
+		}
+		arg140 := flag.Arg(1)
+		mbTrans141 := thrift.NewTMemoryBufferLen(len(arg140))
+		defer mbTrans141.Close()
+		_, err142 := mbTrans141.WriteString(arg140)
+		if err142 != nil {
+			Usage()
+			return
+		}
+		factory143 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt144 := factory143.GetProtocol(mbTrans141)
+		argvalue0 := vpp2papi.NewGetRequest()
+		err145 := argvalue0.Read(jsProt144)
+		if err145 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Get(value0))
+		fmt.Print("\n")
+		break
+	case "Delete":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Delete requires 1 args")
+			flag.Usage()
+		}
+		arg146 := flag.Arg(1)
+		mbTrans147 := thrift.NewTMemoryBufferLen(len(arg146))
+		defer mbTrans147.Close()
+		_, err148 := mbTrans147.WriteString(arg146)
+		if err148 != nil {
+			Usage()
+			return
+		}
+		factory149 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt150 := factory149.GetProtocol(mbTrans147)
+		argvalue0 := vpp2papi.NewDeleteRequest()
+		err151 := argvalue0.Read(jsProt150)
+		if err151 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Delete(value0))
+		fmt.Print("\n")
+		break
+	case "Merkle":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Merkle requires 1 args")
+			flag.Usage()
+		}
+		arg152 := flag.Arg(1)
+		mbTrans153 := thrift.NewTMemoryBufferLen(len(arg152))
+		defer mbTrans153.Close()
+		_, err154 := mbTrans153.WriteString(arg152)
+		if err154 != nil {
+			Usage()
+			return
+		}
+		factory155 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt156 := factory155.GetProtocol(mbTrans153)
+		argvalue0 := vpp2papi.NewMerkleRequest()
+		err157 := argvalue0.Read(jsProt156)
+		if err157 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Merkle(value0))
+		fmt.Print("\n")
+		break
+	case "Leave":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Leave requires 1 args")
+			flag.Usage()
+		}
+		arg158 := flag.Arg(1)
+		mbTrans159 := thrift.NewTMemoryBufferLen(len(arg158))
+		defer mbTrans159.Close()
+		_, err160 := mbTrans159.WriteString(arg158)
+		if err160 != nil {
+			Usage()
+			return
+		}
+		factory161 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt162 := factory161.GetProtocol(mbTrans159)
+		argvalue0 := vpp2papi.NewLeaveRequest()
+		err163 := argvalue0.Read(jsProt162)
+		if err163 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Leave(value0))
+		fmt.Print("\n")
+		break
+	case "AnnounceRing":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "AnnounceRing requires 1 args")
+			flag.Usage()
+		}
+		arg164 := flag.Arg(1)
+		mbTrans165 := thrift.NewTMemoryBufferLen(len(arg164))
+		defer mbTrans165.Close()
+		_, err166 := mbTrans165.WriteString(arg164)
+		if err166 != nil {
+			Usage()
+			return
+		}
+		factory167 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt168 := factory167.GetProtocol(mbTrans165)
+		argvalue0 := vpp2papi.NewAnnounceRingRequest()
+		err169 := argvalue0.Read(jsProt168)
+		if err169 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.AnnounceRing(value0))
+		fmt.Print("\n")
+		break
+	case "ListRings":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "ListRings requires 1 args")
+			flag.Usage()
+		}
+		arg170 := flag.Arg(1)
+		mbTrans171 := thrift.NewTMemoryBufferLen(len(arg170))
+		defer mbTrans171.Close()
+		_, err172 := mbTrans171.WriteString(arg170)
+		if err172 != nil {
+			Usage()
+			return
+		}
+		factory173 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt174 := factory173.GetProtocol(mbTrans171)
+		argvalue0 := vpp2papi.NewListRingsRequest()
+		err175 := argvalue0.Read(jsProt174)
+		if err175 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.ListRings(value0))
+		fmt.Print("\n")
+		break
+	case "Subscribe":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Subscribe requires 1 args")
+			flag.Usage()
+		}
+		arg176 := flag.Arg(1)
+		mbTrans177 := thrift.NewTMemoryBufferLen(len(arg176))
+		defer mbTrans177.Close()
+		_, err178 := mbTrans177.WriteString(arg176)
+		if err178 != nil {
+			Usage()
+			return
+		}
+		factory179 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt180 := factory179.GetProtocol(mbTrans177)
+		argvalue0 := vpp2papi.NewSubscribeRequest()
+		err181 := argvalue0.Read(jsProt180)
+		if err181 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Subscribe(value0))
+		fmt.Print("\n")
+		break
+	case "Unsubscribe":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Unsubscribe requires 1 args")
+			flag.Usage()
+		}
+		arg182 := flag.Arg(1)
+		mbTrans183 := thrift.NewTMemoryBufferLen(len(arg182))
+		defer mbTrans183.Close()
+		_, err184 := mbTrans183.WriteString(arg182)
+		if err184 != nil {
+			Usage()
+			return
+		}
+		factory185 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt186 := factory185.GetProtocol(mbTrans183)
+		argvalue0 := vpp2papi.NewUnsubscribeRequest()
+		err187 := argvalue0.Read(jsProt186)
+		if err187 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Unsubscribe(value0))
+		fmt.Print("\n")
+		break
+	case "Publish":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Publish requires 1 args")
+			flag.Usage()
+		}
+		arg188 := flag.Arg(1)
+		mbTrans189 := thrift.NewTMemoryBufferLen(len(arg188))
+		defer mbTrans189.Close()
+		_, err190 := mbTrans189.WriteString(arg188)
+		if err190 != nil {
+			Usage()
+			return
+		}
+		factory191 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt192 := factory191.GetProtocol(mbTrans189)
+		argvalue0 := vpp2papi.NewPublishRequest()
+		err193 := argvalue0.Read(jsProt192)
+		if err193 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Publish(value0))
+		fmt.Print("\n")
+		break
+	case "Handshake":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "Handshake requires 1 args")
+			flag.Usage()
+		}
+		arg194 := flag.Arg(1)
+		mbTrans195 := thrift.NewTMemoryBufferLen(len(arg194))
+		defer mbTrans195.Close()
+		_, err196 := mbTrans195.WriteString(arg194)
+		if err196 != nil {
+			Usage()
+			return
+		}
+		factory197 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt198 := factory197.GetProtocol(mbTrans195)
+		argvalue0 := vpp2papi.NewHandshakeRequest()
+		err199 := argvalue0.Read(jsProt198)
+		if err199 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.Handshake(value0))
+		fmt.Print("\n")
+		break
+	case "SecureMessage":
+		if flag.NArg()-1 != 1 {
+			fmt.Fprintln(os.Stderr, "SecureMessage requires 1 args")
+			flag.Usage()
+		}
+		arg200 := flag.Arg(1)
+		mbTrans201 := thrift.NewTMemoryBufferLen(len(arg200))
+		defer mbTrans201.Close()
+		_, err202 := mbTrans201.WriteString(arg200)
+		if err202 != nil {
+			Usage()
+			return
+		}
+		factory203 := thrift.NewTSimpleJSONProtocolFactory()
+		jsProt204 := factory203.GetProtocol(mbTrans201)
+		argvalue0 := vpp2papi.NewSecureMessageRequest()
+		err205 := argvalue0.Read(jsProt204)
+		if err205 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.SecureMessage(value0))
+		fmt.Print("\n")
+		break
 	case "ping":
 		if flag.NArg()-1 != 0 {
 			fmt.Fprintln(os.Stderr, "Ping requires 0 args")
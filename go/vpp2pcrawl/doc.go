@@ -0,0 +1,27 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+// Package main contains a command which crawls a ring from one of
+// its hosts, and exports its topology as Graphviz DOT or JSON.
+// Hosts with an http or https URL are contacted over HTTP, others
+// with raw Thrift sockets.
+// Typical usage:
+//
+//	vpp2pcrawl tcp://ufoot.org:8777 | dot -Tsvg > ring.svg
+package main
@@ -0,0 +1,124 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2ptopo"
+	"io"
+	"os"
+)
+
+const (
+	formatDOT  = "dot"
+	formatJSON = "json"
+)
+
+var showHelp = false
+var showPackage = false
+var showVersion = false
+var format = formatDOT
+var output = ""
+var maxHosts = vpp2ptopo.DefaultMaxHosts
+
+func usage() {
+	if len(os.Args) > 0 {
+		fmt.Printf("usage: %s <options> <host URL>\n\n", os.Args[0])
+	}
+	flag.PrintDefaults()
+}
+
+func init() {
+	flag.BoolVar(&showHelp, "help", false, "show usage information")
+	flag.BoolVar(&showVersion, "version", false, "show version information")
+	flag.BoolVar(&showPackage, "package", false, "show package information")
+	flag.StringVar(&format, "format", formatDOT, "output format, dot or json")
+	flag.StringVar(&output, "o", "", "output file, default is standard output")
+	flag.IntVar(&maxHosts, "max-hosts", vpp2ptopo.DefaultMaxHosts, "maximum number of hosts to contact")
+}
+
+func write(topology *vpp2ptopo.Topology, w io.Writer) error {
+	switch format {
+	case formatDOT:
+		return topology.WriteDOT(w)
+	case formatJSON:
+		return topology.WriteJSON(w)
+	}
+
+	return fmt.Errorf("unknown format \"%s\"", format)
+}
+
+func main() {
+	flag.Parse()
+
+	if showHelp {
+		usage()
+		return
+	}
+	if showVersion {
+		fmt.Printf("%d.%d.%s\n", VersionMajor, VersionMinor, VersionStamp)
+		return
+	}
+	if showPackage {
+		fmt.Printf("%s-%d.%d.%s (%s %d.%d.%s)\n", PackageTarname, VersionMajor, VersionMinor, VersionStamp, PackageName, VersionMajor, VersionMinor, VersionStamp)
+		fmt.Printf("%s - %s\n", PackageURL, PackageEmail)
+		fmt.Printf("%s (%s)\n", PackageCopyright, PackageLicense)
+		return
+	}
+	if flag.NArg() != 1 || (format != formatDOT && format != formatJSON) {
+		usage()
+		os.Exit(1)
+	}
+
+	vplog.LogInit("vpp2pcrawl")
+
+	pool := vpp2p.NewRemoteHostPool(vpp2p.NewHostInfoCatalog())
+	defer pool.Close()
+	topology, err := vpp2ptopo.Crawl(flag.Arg(0), vpp2ptopo.PoolConnector(pool), maxHosts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to crawl:", err)
+		os.Exit(1)
+	}
+
+	w := os.Stdout
+	if output != "" {
+		w, err = os.Create(output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "unable to create output file:", err)
+			os.Exit(1)
+		}
+		defer w.Close()
+	}
+	err = write(topology, w)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to write topology:", err)
+		os.Exit(1)
+	}
+
+	for _, ring := range topology.Rings {
+		fmt.Fprintf(os.Stderr, "ring %s: %d nodes, %d issues\n", ring.RingID, len(ring.Nodes), len(ring.Issues))
+		for _, issue := range ring.Issues {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", issue.Type, issue.Message)
+		}
+	}
+}
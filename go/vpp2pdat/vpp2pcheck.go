@@ -54,6 +54,18 @@ const (
 	MinLenPasswordHash = 8
 	// MaxLenPasswordHash is the maximum length for PasswordHash fields
 	MaxLenPasswordHash = 64
+	// MinLenValue is the minimum length for Value fields
+	MinLenValue = 0
+	// MaxLenValue is the maximum length for Value fields
+	MaxLenValue = 1000000
+	// MinLenTopic is the minimum length for Topic fields
+	MinLenTopic = 1
+	// MaxLenTopic is the maximum length for Topic fields
+	MaxLenTopic = 1000
+	// MinLenMessage is the minimum length for Message fields
+	MinLenMessage = 0
+	// MaxLenMessage is the maximum length for Message fields
+	MaxLenMessage = 100000
 )
 
 func checkLenByte(fieldName string, content []byte, minLen, maxLen int) (bool, error) {
@@ -117,6 +129,35 @@ func CheckNodeID(ID []byte) (bool, error) {
 	return checkLenByte("ID", ID, NodeIDBufNbBytes, NodeIDBufNbBytes)
 }
 
+// CheckKey checks that a key, as stored on a ring, has the right format.
+func CheckKey(key []byte) (bool, error) {
+	return checkLenByte("Key", key, NodeIDBufNbBytes, NodeIDBufNbBytes)
+}
+
+// CheckValue checks that a value, as stored on a ring, has the right format.
+func CheckValue(value []byte) (bool, error) {
+	return checkLenByte("Value", value, MinLenValue, MaxLenValue)
+}
+
+// CheckTopic checks that a publish/subscribe topic is correct.
+func CheckTopic(topic string) (bool, error) {
+	b, err := checkLenString("Topic", topic, MinLenTopic, MaxLenTopic)
+	if b != true || err != nil {
+		return false, err
+	}
+	b, err = checkUTF8("topic", topic)
+	if b != true || err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CheckMessage checks that a message, as published on a topic, has the right format.
+func CheckMessage(message []byte) (bool, error) {
+	return checkLenByte("Message", message, MinLenMessage, MaxLenMessage)
+}
+
 // CheckTitle checks that a title is correct
 func CheckTitle(title string) (bool, error) {
 	b, err := checkLenString("Title", title, MinLenTitle, MaxLenTitle)
@@ -131,6 +172,21 @@ func CheckTitle(title string) (bool, error) {
 	return true, nil
 }
 
+// CheckTitlePrefix checks that a title prefix, used to search by title, is correct.
+// Unlike titles, it can be empty.
+func CheckTitlePrefix(titlePrefix string) (bool, error) {
+	b, err := checkLenString("TitlePrefix", titlePrefix, 0, MaxLenTitle)
+	if b != true || err != nil {
+		return false, err
+	}
+	b, err = checkUTF8("title prefix", titlePrefix)
+	if b != true || err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 // CheckDescription checks that a description is correct
 func CheckDescription(description string) (bool, error) {
 	b, err := checkLenString("Description", description, MinLenDescription, MaxLenDescription)
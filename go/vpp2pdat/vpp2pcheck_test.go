@@ -86,6 +86,17 @@ func TestCheckTitle(t *testing.T) {
 	}
 }
 
+func TestCheckTitlePrefix(t *testing.T) {
+	b, err := CheckTitlePrefix("")
+	if b != true || err != nil {
+		t.Error("CheckTitlePrefix returned an error on empty prefix", err)
+	}
+	b, err = CheckTitlePrefix(strings.Repeat(" ", MaxLenTitle+1))
+	if b == true || err == nil {
+		t.Error("CheckTitlePrefix does not report an error on too long prefix")
+	}
+}
+
 func TestCheckDescription(t *testing.T) {
 	b, err := CheckDescription(testDescription)
 	if b != true || err != nil {
@@ -157,3 +168,55 @@ func TestCheckSig(t *testing.T) {
 		t.Error("CheckSig does not report an error on too long Sig")
 	}
 }
+
+func TestCheckKey(t *testing.T) {
+	b, err := CheckKey(make([]byte, NodeIDBufNbBytes))
+	if b != true || err != nil {
+		t.Error("CheckKey returned an error", err)
+	}
+	b, err = CheckKey(testID)
+	if b == true || err == nil {
+		t.Error("CheckKey does not report an error on bad length Key")
+	}
+}
+
+func TestCheckValue(t *testing.T) {
+	b, err := CheckValue(make([]byte, MinLenValue))
+	if b != true || err != nil {
+		t.Error("CheckValue returned an error on short Value", err)
+	}
+	b, err = CheckValue(make([]byte, MaxLenValue))
+	if b != true || err != nil {
+		t.Error("CheckValue returned an error on long Value", err)
+	}
+	b, err = CheckValue(make([]byte, MaxLenValue+1))
+	if b == true || err == nil {
+		t.Error("CheckValue does not report an error on too long Value")
+	}
+}
+
+func TestCheckTopic(t *testing.T) {
+	b, err := CheckTopic("chat")
+	if b != true || err != nil {
+		t.Error("CheckTopic returned an error", err)
+	}
+	b, err = CheckTopic("")
+	if b == true || err == nil {
+		t.Error("CheckTopic does not report an error on empty Topic")
+	}
+	b, err = CheckTopic(string([]byte{0xff, 0xfe}))
+	if b == true || err == nil {
+		t.Error("CheckTopic does not report an error on non UTF-8 Topic")
+	}
+}
+
+func TestCheckMessage(t *testing.T) {
+	b, err := CheckMessage(make([]byte, MaxLenMessage))
+	if b != true || err != nil {
+		t.Error("CheckMessage returned an error on long Message", err)
+	}
+	b, err = CheckMessage(make([]byte, MaxLenMessage+1))
+	if b == true || err == nil {
+		t.Error("CheckMessage does not report an error on too long Message")
+	}
+}
@@ -37,8 +37,9 @@ const (
 	// Host0URL is the base url where to find the default directory
 	// host. It should be a well known, usually up and running server.
 	// Technically, once could use any address, but let's say this
-	// is just a default seed.
-	Host0URL = "http://ufoot.org:8777"
+	// is just a default seed. The tcp scheme means it is reached
+	// with raw Thrift sockets, http would mean Thrift over HTTP.
+	Host0URL = "tcp://ufoot.org:8777"
 	// Ring0Title is the title of the default directory ring.
 	Ring0Title = "Vapor Ring 0"
 	// Ring0Description is the description of the default directory ring.
@@ -73,8 +74,41 @@ const (
 	// DefaultDataLifetime is the amount of time after which keys are automatically deleted
 	// to purge the data store, no matter what. Any update on a key extends this duration.
 	DefaultDataLifetime = 86400
+	// MaxMinNodeZeroes is the highest MinNodeZeroes a ring can require,
+	// above this, generating a node ID would take forever.
+	MaxMinNodeZeroes = 32
+
+	// MaxLookupEntries is the maximum number of keys which can be
+	// looked up in a single LookupMany call.
+	MaxLookupEntries = 1000
+
+	// MerkleFanout is the number of children of each inner node of
+	// the Merkle trees used to compare replicas.
+	MerkleFanout = 16
+	// MerkleDepth is the depth of the leaves of the Merkle trees used
+	// to compare replicas, with the default fanout, this gives 4096 leaves.
+	MerkleDepth = 3
+	// MaxMerklePrefixes is the maximum number of Merkle tree nodes
+	// which can be requested in a single Merkle call.
+	MaxMerklePrefixes = 4096
+	// MaxMerkleEntries is the maximum number of entries which can be
+	// transferred, or asked for, in a single Merkle call.
+	MaxMerkleEntries = 1000
+
+	// RingAnnounceLifetime is the amount of time, in seconds, after which a ring
+	// announced in a ring directory is removed, unless it is announced again.
+	RingAnnounceLifetime = 900
+	// RingDirectoryKeyString is the string used to build the key of the ring
+	// directory, the node holding this key holds the directory.
+	RingDirectoryKeyString = "Vapor ring directory"
 )
 
+// RingDirectoryKey returns the key of the ring directory, on a given ring.
+// All the rings announcements are stored on the node holding this key.
+func RingDirectoryKey() []byte {
+	return vpsum.Checksum256([]byte(RingDirectoryKeyString))
+}
+
 // HostInfoSigBytes returns the byte buffer that needs to be signed.
 func HostInfoSigBytes(hostInfo *vpp2papi.HostInfo) []byte {
 	return []byte(fmt.Sprintf("%s;%s", hostInfo.HostTitle, hostInfo.HostURL))
@@ -220,6 +254,22 @@ func CheckNodeInfo(node *vpp2papi.NodeInfo) (bool, error) {
 	return true, nil
 }
 
+// CheckNodeInfoZeroes checks that the hash of the node signature has
+// at least minZeroes zeroes, this is the proof of work required by
+// rings with a MinNodeZeroes setting. Unsigned nodes have no zeroes.
+// Returns the number of zeroes.
+func CheckNodeInfoZeroes(node *vpp2papi.NodeInfo, minZeroes int) (int, error) {
+	z, err := NodeInfoCheckSig(node)
+	if err != nil {
+		return 0, err
+	}
+	if z < minZeroes {
+		return z, fmt.Errorf("not enough zeroes in node signature, %d<%d", z, minZeroes)
+	}
+
+	return z, nil
+}
+
 // DefaultRingConfig returns a default ring configuration, with 256-bit keys
 func DefaultRingConfig() *vpp2papi.RingConfig {
 	return &vpp2papi.RingConfig{BruijnM: DefaultBruijnM, BruijnN: DefaultBruijnN, NbCopy: DefaultNbCopy, NbStep: DefaultNbStep, CallTimeout: DefaultCallTimeout, SyncDelay: DefaultSyncDelay, DisconnectTimeout: DefaultDisconnectTimeout, DataLifetime: DefaultDataLifetime}
@@ -234,6 +284,11 @@ func RingInfoSigBytes(ringInfo *vpp2papi.RingInfo) []byte {
 	// on spaces and implementation details...) but a unique projection
 	// of the data.
 	bufScalar := fmt.Sprintf("(%d,%d,%d,%d,%d,%d,%d,%d,%t)", ringInfo.Config.BruijnM, ringInfo.Config.BruijnN, ringInfo.Config.NbCopy, ringInfo.Config.NbStep, ringInfo.Config.CallTimeout, ringInfo.Config.SyncDelay, ringInfo.Config.DisconnectTimeout, ringInfo.Config.DataLifetime, ringInfo.HasPassword)
+	if ringInfo.Config.MinNodeZeroes != 0 {
+		// only added when set, so that rings signed before this
+		// setting existed, such as ring0, keep a valid signature
+		bufScalar += fmt.Sprintf("(%d)", ringInfo.Config.MinNodeZeroes)
+	}
 	ret := make([]byte, len(ringInfo.RingID)+len(bufTitle)+len(bufDescription)+len(ringInfo.AppID)+len(bufScalar))
 	begin := 0
 	end := begin + len(ringInfo.RingID)
@@ -269,6 +324,18 @@ func CheckRingConfig(config *vpp2papi.RingConfig) (bool, error) {
 	if config.NbStep < 1 || config.NbStep > config.BruijnN {
 		return false, fmt.Errorf("bad NbStep param %d, should be between 1 and BruijnN which is %d", config.NbStep, config.BruijnN)
 	}
+	if config.SyncDelay < 1 {
+		return false, fmt.Errorf("bad SyncDelay param %d, should be at least 1", config.SyncDelay)
+	}
+	if config.DisconnectTimeout < config.SyncDelay {
+		return false, fmt.Errorf("bad DisconnectTimeout param %d, should be at least SyncDelay which is %d", config.DisconnectTimeout, config.SyncDelay)
+	}
+	if config.DataLifetime < 1 {
+		return false, fmt.Errorf("bad DataLifetime param %d, should be at least 1", config.DataLifetime)
+	}
+	if config.MinNodeZeroes < 0 || config.MinNodeZeroes > MaxMinNodeZeroes {
+		return false, fmt.Errorf("bad MinNodeZeroes param %d, should be between 0 and %d", config.MinNodeZeroes, MaxMinNodeZeroes)
+	}
 	return true, nil
 }
 
@@ -367,10 +434,109 @@ func CheckContextInfo(context *vpp2papi.ContextInfo) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	if context.SourceRing.Config.MinNodeZeroes > 0 {
+		_, err = CheckNodeInfoZeroes(context.SourceNode, int(context.SourceRing.Config.MinNodeZeroes))
+		if err != nil {
+			return false, err
+		}
+	}
 	_, err = CheckNodeID(context.TargetNodeID)
 	if err != nil {
 		return false, err
 	}
+	if len(context.Nonce) != NonceNbBytes {
+		return false, fmt.Errorf("bad nonce length %d, should be %d", len(context.Nonce), NonceNbBytes)
+	}
+
+	return true, nil
+}
+
+// CheckLookupEntries checks that the entries of a LookupMany request are correct.
+func CheckLookupEntries(entries []*vpp2papi.LookupEntry) (bool, error) {
+	if len(entries) == 0 {
+		return false, fmt.Errorf("no lookup entries")
+	}
+	if len(entries) > MaxLookupEntries {
+		return false, fmt.Errorf("too many lookup entries %d max=%d", len(entries), MaxLookupEntries)
+	}
+	for _, v := range entries {
+		if v == nil {
+			return false, fmt.Errorf("lookup entry is nil")
+		}
+		_, err := CheckKey(v.Key)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// CheckMerklePrefix checks that the path to a Merkle tree node is correct.
+func CheckMerklePrefix(prefix []byte) (bool, error) {
+	if len(prefix) > MerkleDepth {
+		return false, fmt.Errorf("Merkle prefix too long len=%d max=%d", len(prefix), MerkleDepth)
+	}
+	for _, v := range prefix {
+		if int(v) >= MerkleFanout {
+			return false, fmt.Errorf("bad Merkle prefix element %d max=%d", v, MerkleFanout-1)
+		}
+	}
+
+	return true, nil
+}
+
+// CheckMerklePrefixes checks that the prefixes of a Merkle request are correct.
+func CheckMerklePrefixes(prefixes [][]byte) (bool, error) {
+	if len(prefixes) > MaxMerklePrefixes {
+		return false, fmt.Errorf("too many Merkle prefixes %d max=%d", len(prefixes), MaxMerklePrefixes)
+	}
+	for _, v := range prefixes {
+		_, err := CheckMerklePrefix(v)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// CheckDataEntries checks that the entries transferred by a Merkle request are correct.
+func CheckDataEntries(entries []*vpp2papi.DataEntry) (bool, error) {
+	if len(entries) > MaxMerkleEntries {
+		return false, fmt.Errorf("too many data entries %d max=%d", len(entries), MaxMerkleEntries)
+	}
+	for _, v := range entries {
+		if v == nil {
+			return false, fmt.Errorf("data entry is nil")
+		}
+		_, err := CheckKey(v.Key)
+		if err != nil {
+			return false, err
+		}
+		_, err = CheckValue(v.Value)
+		if err != nil {
+			return false, err
+		}
+		if v.TTL <= 0 {
+			return false, fmt.Errorf("bad data entry TTL %d", v.TTL)
+		}
+	}
+
+	return true, nil
+}
+
+// CheckWantedKeys checks that the keys asked for by a Merkle request are correct.
+func CheckWantedKeys(keys [][]byte) (bool, error) {
+	if len(keys) > MaxMerkleEntries {
+		return false, fmt.Errorf("too many wanted keys %d max=%d", len(keys), MaxMerkleEntries)
+	}
+	for _, v := range keys {
+		_, err := CheckKey(v)
+		if err != nil {
+			return false, err
+		}
+	}
 
 	return true, nil
 }
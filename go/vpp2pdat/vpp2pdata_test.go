@@ -35,3 +35,110 @@ func TestHostInfoSigBytes(t *testing.T) {
 		t.Errorf("bad HostInfoSigBytes return value \"%s\"", string(sbh))
 	}
 }
+
+func TestCheckRingConfig(t *testing.T) {
+	config := DefaultRingConfig()
+	if ok, err := CheckRingConfig(config); !ok || err != nil {
+		t.Error("default config reported as invalid", err)
+	}
+
+	config.SyncDelay = 0
+	if ok, err := CheckRingConfig(config); ok || err == nil {
+		t.Error("null SyncDelay not detected")
+	}
+
+	config = DefaultRingConfig()
+	config.DisconnectTimeout = config.SyncDelay - 1
+	if ok, err := CheckRingConfig(config); ok || err == nil {
+		t.Error("DisconnectTimeout lower than SyncDelay not detected")
+	}
+
+	config = DefaultRingConfig()
+	config.DataLifetime = 0
+	if ok, err := CheckRingConfig(config); ok || err == nil {
+		t.Error("null DataLifetime not detected")
+	}
+
+	config = DefaultRingConfig()
+	config.MinNodeZeroes = MaxMinNodeZeroes + 1
+	if ok, err := CheckRingConfig(config); ok || err == nil {
+		t.Error("too high MinNodeZeroes not detected")
+	}
+	config.MinNodeZeroes = -1
+	if ok, err := CheckRingConfig(config); ok || err == nil {
+		t.Error("negative MinNodeZeroes not detected")
+	}
+}
+
+func TestRingInfoSigBytesMinNodeZeroes(t *testing.T) {
+	ri := vpp2papi.NewRingInfo()
+	ri.Config = DefaultRingConfig()
+	sb0 := RingInfoSigBytes(ri)
+	if bytes.Contains(sb0, []byte(")(")) {
+		t.Errorf("MinNodeZeroes in sig bytes while not set \"%s\"", string(sb0))
+	}
+	ri.Config.MinNodeZeroes = 4
+	sb4 := RingInfoSigBytes(ri)
+	if bytes.Compare(sb0, sb4) == 0 {
+		t.Error("MinNodeZeroes not in sig bytes")
+	}
+}
+
+func TestCheckLookupEntries(t *testing.T) {
+	entries := []*vpp2papi.LookupEntry{{Key: TopicToKey("level")}}
+	b, err := CheckLookupEntries(entries)
+	if b != true || err != nil {
+		t.Error("CheckLookupEntries returned an error", err)
+	}
+	b, err = CheckLookupEntries(nil)
+	if b == true || err == nil {
+		t.Error("CheckLookupEntries does not report an error on empty entries")
+	}
+	b, err = CheckLookupEntries(append(entries, nil))
+	if b == true || err == nil {
+		t.Error("CheckLookupEntries does not report an error on nil entry")
+	}
+	b, err = CheckLookupEntries(make([]*vpp2papi.LookupEntry, MaxLookupEntries+1))
+	if b == true || err == nil {
+		t.Error("CheckLookupEntries does not report an error on too many entries")
+	}
+}
+
+func TestCheckMerkle(t *testing.T) {
+	b, err := CheckMerklePrefixes([][]byte{{}, {0, 15}, {1, 2, 3}})
+	if b != true || err != nil {
+		t.Error("CheckMerklePrefixes returned an error", err)
+	}
+	b, err = CheckMerklePrefixes([][]byte{{16}})
+	if b == true || err == nil {
+		t.Error("CheckMerklePrefixes does not report an error on bad prefix element")
+	}
+	b, err = CheckMerklePrefixes([][]byte{make([]byte, MerkleDepth+1)})
+	if b == true || err == nil {
+		t.Error("CheckMerklePrefixes does not report an error on long prefix")
+	}
+
+	entries := []*vpp2papi.DataEntry{{Key: TopicToKey("level"), Value: []byte("map"), TTL: 60}}
+	b, err = CheckDataEntries(entries)
+	if b != true || err != nil {
+		t.Error("CheckDataEntries returned an error", err)
+	}
+	entries[0].TTL = 0
+	b, err = CheckDataEntries(entries)
+	if b == true || err == nil {
+		t.Error("CheckDataEntries does not report an error on expired entry")
+	}
+	b, err = CheckDataEntries(append(entries, nil))
+	if b == true || err == nil {
+		t.Error("CheckDataEntries does not report an error on nil entry")
+	}
+
+	b, err = CheckWantedKeys([][]byte{TopicToKey("level")})
+	if b != true || err != nil {
+		t.Error("CheckWantedKeys returned an error", err)
+	}
+	b, err = CheckWantedKeys([][]byte{[]byte("short")})
+	if b == true || err == nil {
+		t.Error("CheckWantedKeys does not report an error on bad key")
+	}
+}
@@ -20,9 +20,16 @@
 package vpp2pdat
 
 import (
+	"encoding/hex"
 	"fmt"
 	"github.com/dineshappavoo/basex"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpsum"
 	"math/big"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -121,3 +128,33 @@ func NodeIDToShortString(nodeID []byte) string {
 func RingIDToShortString(ringID []byte) string {
 	return bytesToBasex(ringID, RingIDShortStringLen)
 }
+
+// KeyToString converts a key to an hexadecimal string, unlike short
+// strings, it contains the whole key, so it can be used as a map index.
+func KeyToString(key []byte) string {
+	return hex.EncodeToString(key)
+}
+
+// TopicToKey returns the key of a publish/subscribe topic, the node
+// holding this key keeps the list of subscribers.
+func TopicToKey(topic string) []byte {
+	return vpsum.Checksum256([]byte(topic))
+}
+
+// HostURLToAddr converts a host URL to a host:port address, suitable for
+// dialing or listening. If the URL has no port, vpp2papi.DefaultPort is used.
+func HostURLToAddr(hostURL string) (string, error) {
+	parsedURL, err := url.Parse(hostURL)
+	if err != nil {
+		return "", err
+	}
+	if parsedURL.Host == "" {
+		return "", fmt.Errorf("no host in URL \"%s\"", hostURL)
+	}
+	_, _, err = net.SplitHostPort(parsedURL.Host)
+	if err == nil {
+		return parsedURL.Host, nil
+	}
+
+	return net.JoinHostPort(strings.Trim(parsedURL.Host, "[]"), strconv.Itoa(vpp2papi.DefaultPort)), nil
+}
@@ -76,3 +76,44 @@ func TestRingIDToShortString(t *testing.T) {
 		t.Errorf("bad len %d for RingID short string, should be %d", len(s), RingIDShortStringLen)
 	}
 }
+
+func TestHostURLToAddr(t *testing.T) {
+	addr, err := HostURLToAddr(Host0URL)
+	if err != nil || addr != "ufoot.org:8777" {
+		t.Errorf("bad addr \"%s\" for Host0URL: %v", addr, err)
+	}
+	addr, err = HostURLToAddr("http://localhost")
+	if err != nil || addr != "localhost:8777" {
+		t.Errorf("bad addr \"%s\" for URL without port: %v", addr, err)
+	}
+	addr, err = HostURLToAddr("http://[::1]/foo")
+	if err != nil || addr != "[::1]:8777" {
+		t.Errorf("bad addr \"%s\" for IPv6 URL: %v", addr, err)
+	}
+	_, err = HostURLToAddr("foo")
+	if err == nil {
+		t.Error("no error on URL without host")
+	}
+}
+
+func TestTopicToKey(t *testing.T) {
+	key := TopicToKey("chat")
+	_, err := CheckKey(key)
+	if err != nil {
+		t.Error("topic key is not a valid key", err)
+	}
+	if !bytes.Equal(key, TopicToKey("chat")) || bytes.Equal(key, TopicToKey("sessions")) {
+		t.Error("topic keys are not consistent")
+	}
+}
+
+func TestKeyToString(t *testing.T) {
+	key1 := TopicToKey("chat")
+	key2 := TopicToKey("sessions")
+	if KeyToString(key1) == KeyToString(key2) || KeyToString(key1) != KeyToString(TopicToKey("chat")) {
+		t.Error("key strings are not consistent")
+	}
+	if len(KeyToString(key1)) != 2*len(key1) {
+		t.Errorf("bad key string len=%d", len(KeyToString(key1)))
+	}
+}
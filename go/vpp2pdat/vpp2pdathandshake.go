@@ -0,0 +1,178 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2pdat
+
+import (
+	"fmt"
+	"github.com/ufoot/vapor/go/vpapp"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"sort"
+)
+
+const (
+	// MinProtocol is the oldest protocol version this program speaks.
+	MinProtocol = 1
+	// MaxProtocol is the newest protocol version this program speaks.
+	MaxProtocol = 1
+	// MaxCapabilities is the maximum number of capabilities a peer can declare.
+	MaxCapabilities = 100
+	// MinLenCapability is the minimum length for Capability fields
+	MinLenCapability = 1
+	// MaxLenCapability is the maximum length for Capability fields
+	MaxLenCapability = 72
+)
+
+const (
+	// CapabilityData means the peer implements Put, Get and Delete.
+	CapabilityData = "data"
+	// CapabilityDirectory means the peer implements AnnounceRing and ListRings.
+	CapabilityDirectory = "directory"
+	// CapabilityPubSub means the peer implements Subscribe, Unsubscribe and Publish.
+	CapabilityPubSub = "pubsub"
+	// CapabilitySecure means the peer implements SecureMessage.
+	CapabilitySecure = "secure"
+	// CapabilityLookupMany means the peer implements LookupMany.
+	CapabilityLookupMany = "lookupmany"
+	// CapabilityMerkle means the peer implements Merkle.
+	CapabilityMerkle = "merkle"
+)
+
+// IncompatiblePrefix starts the description of an IncompatibleError,
+// so that it can be recognized once it has been sent over the network
+// as a plain error message.
+const IncompatiblePrefix = "incompatible peer: "
+
+// IncompatibleError is returned when a peer can't be talked with,
+// because it runs another application, or because there's no
+// protocol version both sides speak.
+type IncompatibleError struct {
+	// Reason explains, in plain text, why the peer is refused.
+	Reason string
+	// Local is what this program speaks.
+	Local *vpp2papi.HandshakeInfo
+	// Remote is what the peer speaks, nil if unknown.
+	Remote *vpp2papi.HandshakeInfo
+}
+
+// Error returns a description of the incompatibility.
+func (e *IncompatibleError) Error() string {
+	if e.Remote == nil || e.Remote.Package == nil || e.Remote.Version == nil {
+		return IncompatiblePrefix + e.Reason
+	}
+	return fmt.Sprintf("%s%s, remote is %s %s protocol [%d,%d], local is %s %s protocol [%d,%d]",
+		IncompatiblePrefix, e.Reason,
+		e.Remote.Package.Tarname, vpapp.VersionToString(e.Remote.Version), e.Remote.MinProtocol, e.Remote.MaxProtocol,
+		e.Local.Package.Tarname, vpapp.VersionToString(e.Local.Version), e.Local.MinProtocol, e.Local.MaxProtocol)
+}
+
+// IsIncompatible returns true if err is an IncompatibleError.
+func IsIncompatible(err error) bool {
+	_, ok := err.(*IncompatibleError)
+	return ok
+}
+
+// DefaultCapabilities returns the capabilities of this program.
+func DefaultCapabilities() []string {
+	return []string{CapabilityData, CapabilityDirectory, CapabilityPubSub, CapabilitySecure, CapabilityLookupMany, CapabilityMerkle}
+}
+
+// DefaultHandshakeInfo returns what this program speaks.
+func DefaultHandshakeInfo() *vpp2papi.HandshakeInfo {
+	return &vpp2papi.HandshakeInfo{Package: vpapp.DefaultPackage(), Version: vpapp.DefaultVersion(), MinProtocol: MinProtocol, MaxProtocol: MaxProtocol, Capabilities: DefaultCapabilities()}
+}
+
+// CheckCapability checks that a capability name is correct.
+func CheckCapability(capability string) (bool, error) {
+	b, err := checkLenString("Capability", capability, MinLenCapability, MaxLenCapability)
+	if b != true || err != nil {
+		return false, err
+	}
+	b, err = checkASCII("Capability", capability)
+	if b != true || err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CheckHandshakeInfo checks that an handshake info has the right format.
+// It does not check whether it is compatible with this program.
+func CheckHandshakeInfo(info *vpp2papi.HandshakeInfo) (bool, error) {
+	if info == nil {
+		return false, fmt.Errorf("handshake info is nil")
+	}
+	if info.Package == nil {
+		return false, fmt.Errorf("package is nil")
+	}
+	if info.Version == nil {
+		return false, fmt.Errorf("version is nil")
+	}
+	if info.MinProtocol < 1 || info.MaxProtocol < info.MinProtocol {
+		return false, fmt.Errorf("bad protocol range [%d,%d]", info.MinProtocol, info.MaxProtocol)
+	}
+	if len(info.Capabilities) > MaxCapabilities {
+		return false, fmt.Errorf("too many capabilities %d max=%d", len(info.Capabilities), MaxCapabilities)
+	}
+	for _, v := range info.Capabilities {
+		b, err := CheckCapability(v)
+		if b != true || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Negotiate compares what local and remote peers speak. It returns
+// the highest protocol version both sides speak, and the capabilities
+// they both have, sorted. If the peers can't talk together, it
+// returns an IncompatibleError. The result does not depend on which
+// side is local, so both peers agree.
+func Negotiate(local, remote *vpp2papi.HandshakeInfo) (int32, []string, error) {
+	_, err := CheckHandshakeInfo(remote)
+	if err != nil {
+		return 0, nil, &IncompatibleError{Reason: err.Error(), Local: local, Remote: nil}
+	}
+	if !vpapp.Compatible(local.Package, remote.Package) {
+		return 0, nil, &IncompatibleError{Reason: "different application", Local: local, Remote: remote}
+	}
+	protocol := local.MaxProtocol
+	if remote.MaxProtocol < protocol {
+		protocol = remote.MaxProtocol
+	}
+	if protocol < local.MinProtocol || protocol < remote.MinProtocol {
+		return 0, nil, &IncompatibleError{Reason: "no common protocol version", Local: local, Remote: remote}
+	}
+
+	remoteCapabilities := make(map[string]bool)
+	for _, v := range remote.Capabilities {
+		remoteCapabilities[v] = true
+	}
+	capabilities := make([]string, 0, len(local.Capabilities))
+	for _, v := range local.Capabilities {
+		if remoteCapabilities[v] {
+			capabilities = append(capabilities, v)
+			delete(remoteCapabilities, v)
+		}
+	}
+	sort.Strings(capabilities)
+
+	return protocol, capabilities, nil
+}
@@ -0,0 +1,71 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2pdat
+
+import (
+	"github.com/ufoot/vapor/go/vpapp"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	local := DefaultHandshakeInfo()
+	remote := DefaultHandshakeInfo()
+
+	remote.MinProtocol = 1
+	remote.MaxProtocol = MaxProtocol + 1
+	remote.Capabilities = []string{"other", CapabilityPubSub, CapabilityData}
+	protocol, capabilities, err := Negotiate(local, remote)
+	if err != nil {
+		t.Fatal("unable to negotiate", err)
+	}
+	if protocol != MaxProtocol {
+		t.Errorf("bad protocol %d!=%d", protocol, MaxProtocol)
+	}
+	if len(capabilities) != 2 || capabilities[0] != CapabilityData || capabilities[1] != CapabilityPubSub {
+		t.Error("bad capabilities", capabilities)
+	}
+	protocolR, capabilitiesR, err := Negotiate(remote, local)
+	if err != nil || protocolR != protocol || len(capabilitiesR) != len(capabilities) {
+		t.Error("negotiation is not symmetric", err)
+	}
+
+	remote.MinProtocol = MaxProtocol + 1
+	_, _, err = Negotiate(local, remote)
+	if !IsIncompatible(err) {
+		t.Error("no common protocol not detected", err)
+	}
+	remote = DefaultHandshakeInfo()
+	remote.Package = vpapp.NewPackage("other", "Other", "", "", "", "")
+	_, _, err = Negotiate(local, remote)
+	if !IsIncompatible(err) {
+		t.Error("different application not detected", err)
+	}
+	t.Logf("incompatible error: %s", err)
+	remote = DefaultHandshakeInfo()
+	remote.Capabilities = []string{""}
+	_, _, err = Negotiate(local, remote)
+	if !IsIncompatible(err) {
+		t.Error("bad capability not detected", err)
+	}
+	_, _, err = Negotiate(local, nil)
+	if !IsIncompatible(err) {
+		t.Error("nil handshake info not detected", err)
+	}
+}
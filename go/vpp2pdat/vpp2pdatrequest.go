@@ -0,0 +1,265 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2pdat
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpcrypto"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpsum"
+)
+
+const (
+	// ChallengeNbBytes is the number of bytes of a challenge.
+	ChallengeNbBytes = 32
+	// NonceNbBytes is the number of bytes of a request nonce.
+	NonceNbBytes = 16
+)
+
+// joinSigBytes concatenates buffers, each of them being prefixed
+// by its length, so that the result can't be ambiguous.
+func joinSigBytes(bufs ...[]byte) []byte {
+	n := 0
+	for _, v := range bufs {
+		n += 4 + len(v)
+	}
+	ret := make([]byte, 0, n)
+	for _, v := range bufs {
+		ret = append(ret, vpsum.IntToBuf32(uint32(len(v)))...)
+		ret = append(ret, v...)
+	}
+
+	return ret
+}
+
+// ContextInfoSigBytes returns the byte buffer that needs to be signed,
+// for the context part of a request.
+func ContextInfoSigBytes(context *vpp2papi.ContextInfo) []byte {
+	var hostPubKey, ringID, nodeID []byte
+
+	if context.SourceHost != nil {
+		hostPubKey = context.SourceHost.HostPubKey
+	}
+	if context.SourceRing != nil {
+		ringID = context.SourceRing.RingID
+	}
+	if context.SourceNode != nil {
+		nodeID = context.SourceNode.NodeID
+	}
+
+	return joinSigBytes(hostPubKey, ringID, nodeID, context.TargetNodeID, context.Challenge, []byte(fmt.Sprintf("%d", context.Timestamp)), context.Nonce)
+}
+
+// ChallengeRequestSigBytes returns the byte buffer that needs to be signed.
+func ChallengeRequestSigBytes(request *vpp2papi.ChallengeRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Challenge"))
+}
+
+// LookupRequestSigBytes returns the byte buffer that needs to be signed.
+func LookupRequestSigBytes(request *vpp2papi.LookupRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Lookup"), request.Key, request.KeyShift, request.ImaginaryNode)
+}
+
+// LookupManyRequestSigBytes returns the byte buffer that needs to be signed.
+func LookupManyRequestSigBytes(request *vpp2papi.LookupManyRequest) []byte {
+	bufs := make([][]byte, 0, 3*len(request.Entries)+2)
+	bufs = append(bufs, ContextInfoSigBytes(request.Context), []byte("LookupMany"))
+	for _, v := range request.Entries {
+		if v != nil {
+			bufs = append(bufs, v.Key, v.KeyShift, v.ImaginaryNode)
+		}
+	}
+
+	return joinSigBytes(bufs...)
+}
+
+// MerkleRequestSigBytes returns the byte buffer that needs to be signed.
+func MerkleRequestSigBytes(request *vpp2papi.MerkleRequest) []byte {
+	bufs := make([][]byte, 0, len(request.Prefixes)+3*len(request.Entries)+len(request.WantedKeys)+7)
+	bufs = append(bufs, ContextInfoSigBytes(request.Context), []byte("Merkle"), request.RangeStart, request.RangeEnd)
+	bufs = append(bufs, []byte(fmt.Sprintf("%d", len(request.Prefixes))))
+	bufs = append(bufs, request.Prefixes...)
+	bufs = append(bufs, []byte(fmt.Sprintf("%d", len(request.Entries))))
+	for _, v := range request.Entries {
+		if v != nil {
+			bufs = append(bufs, v.Key, v.Value, []byte(fmt.Sprintf("%d", v.TTL)))
+		}
+	}
+	bufs = append(bufs, []byte(fmt.Sprintf("%d", len(request.WantedKeys))))
+	bufs = append(bufs, request.WantedKeys...)
+
+	return joinSigBytes(bufs...)
+}
+
+// GetSuccessorsRequestSigBytes returns the byte buffer that needs to be signed.
+func GetSuccessorsRequestSigBytes(request *vpp2papi.GetSuccessorsRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("GetSuccessors"))
+}
+
+// GetPredecessorRequestSigBytes returns the byte buffer that needs to be signed.
+func GetPredecessorRequestSigBytes(request *vpp2papi.GetPredecessorRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("GetPredecessor"))
+}
+
+// SyncRequestSigBytes returns the byte buffer that needs to be signed.
+func SyncRequestSigBytes(request *vpp2papi.SyncRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Sync"), request.KeyShift, request.ImaginaryNode)
+}
+
+// PutRequestSigBytes returns the byte buffer that needs to be signed.
+func PutRequestSigBytes(request *vpp2papi.PutRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Put"), request.Key, request.Value, []byte(fmt.Sprintf("%t", request.Replica)))
+}
+
+// GetRequestSigBytes returns the byte buffer that needs to be signed.
+func GetRequestSigBytes(request *vpp2papi.GetRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Get"), request.Key, []byte(fmt.Sprintf("%t", request.Replica)))
+}
+
+// DeleteRequestSigBytes returns the byte buffer that needs to be signed.
+func DeleteRequestSigBytes(request *vpp2papi.DeleteRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Delete"), request.Key, []byte(fmt.Sprintf("%t", request.Replica)))
+}
+
+// subscriberSigBytes returns the part of the byte buffer to sign
+// which identifies a subscriber.
+func subscriberSigBytes(subscriber *vpp2papi.NodeInfo) []byte {
+	if subscriber == nil {
+		return nil
+	}
+
+	return subscriber.NodeID
+}
+
+// SubscribeRequestSigBytes returns the byte buffer that needs to be signed.
+func SubscribeRequestSigBytes(request *vpp2papi.SubscribeRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Subscribe"), []byte(request.Topic), subscriberSigBytes(request.Subscriber), []byte(fmt.Sprintf("%t", request.Replica)))
+}
+
+// UnsubscribeRequestSigBytes returns the byte buffer that needs to be signed.
+func UnsubscribeRequestSigBytes(request *vpp2papi.UnsubscribeRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Unsubscribe"), []byte(request.Topic), subscriberSigBytes(request.Subscriber), []byte(fmt.Sprintf("%t", request.Replica)))
+}
+
+// PublishRequestSigBytes returns the byte buffer that needs to be signed.
+func PublishRequestSigBytes(request *vpp2papi.PublishRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("Publish"), []byte(request.Topic), request.Message, []byte(fmt.Sprintf("%t", request.Deliver)))
+}
+
+// LeaveRequestSigBytes returns the byte buffer that needs to be signed.
+func LeaveRequestSigBytes(request *vpp2papi.LeaveRequest) []byte {
+	bufs := make([][]byte, 0, len(request.SuccessorNodes)+4)
+	bufs = append(bufs, ContextInfoSigBytes(request.Context), []byte("Leave"))
+	for _, v := range request.SuccessorNodes {
+		if v != nil {
+			bufs = append(bufs, v.NodeID)
+		}
+	}
+	if request.PredecessorNode != nil {
+		bufs = append(bufs, []byte("Predecessor"), request.PredecessorNode.NodeID)
+	}
+
+	return joinSigBytes(bufs...)
+}
+
+// AnnounceRingRequestSigBytes returns the byte buffer that needs to be signed.
+func AnnounceRingRequestSigBytes(request *vpp2papi.AnnounceRingRequest) []byte {
+	var ringSigBytes, ringSig []byte
+
+	if request.Ring != nil && request.Ring.Config != nil {
+		ringSigBytes = RingInfoSigBytes(request.Ring)
+		ringSig = request.Ring.RingSig
+	}
+
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("AnnounceRing"), ringSigBytes, ringSig, []byte(fmt.Sprintf("%t", request.Replica)))
+}
+
+// ListRingsRequestSigBytes returns the byte buffer that needs to be signed.
+func ListRingsRequestSigBytes(request *vpp2papi.ListRingsRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("ListRings"), request.AppID, []byte(request.TitlePrefix), []byte(fmt.Sprintf("%t", request.Replica)))
+}
+
+// RingAuth returns the HMAC of a request, keyed by the ring password hash.
+// The content is typically returned by one of the *SigBytes functions.
+func RingAuth(passwordHash, content []byte) []byte {
+	mac := hmac.New(sha256.New, passwordHash)
+	mac.Write(content)
+
+	return mac.Sum(nil)
+}
+
+// CheckRingAuth checks that a request has been authenticated with
+// the right ring password hash.
+func CheckRingAuth(passwordHash, content, auth []byte) (bool, error) {
+	if auth == nil || len(auth) == 0 {
+		return false, fmt.Errorf("no ring auth")
+	}
+	if !hmac.Equal(auth, RingAuth(passwordHash, content)) {
+		return false, fmt.Errorf("bad ring auth")
+	}
+
+	return true, nil
+}
+
+// RequestCheckSig checks if the signature of a request is OK, if it's not,
+// returns false and an error. The signature is checked against the public
+// key of the source host. A request which is not signed is accepted only if
+// mustSign is false and the source host is not expected to sign anything.
+func RequestCheckSig(context *vpp2papi.ContextInfo, content, sig []byte, mustSign bool) (bool, error) {
+	var ok bool
+
+	if context.SourceHost == nil || context.SourceNode == nil {
+		return false, fmt.Errorf("no source in context")
+	}
+	hostPubKey := context.SourceHost.HostPubKey
+	if bytes.Compare(hostPubKey, context.SourceNode.HostPubKey) != 0 {
+		return false, fmt.Errorf("source node does not belong to source host")
+	}
+	if sig == nil || len(sig) <= 0 {
+		if mustSign || IsPubKeyExpectedToSign(hostPubKey) {
+			return false, fmt.Errorf("no signature")
+		}
+		// no signature but we don't expect such a key to sign anything
+		return true, nil
+	}
+	_, err := CheckSig(sig)
+	if err != nil {
+		return false, err
+	}
+
+	key, err := vpcrypto.ImportPubKey(hostPubKey)
+	if err != nil {
+		return false, err
+	}
+	ok, err = key.CheckSig(content, sig)
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+// SecureMessageRequestSigBytes returns the byte buffer that needs to be signed.
+func SecureMessageRequestSigBytes(request *vpp2papi.SecureMessageRequest) []byte {
+	return joinSigBytes(ContextInfoSigBytes(request.Context), []byte("SecureMessage"), request.SessionID, request.SessionKey, request.Payload)
+}
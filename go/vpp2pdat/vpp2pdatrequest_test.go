@@ -0,0 +1,65 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2pdat
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vprand"
+	"github.com/ufoot/vapor/go/vpsum"
+	"testing"
+)
+
+func TestRingAuth(t *testing.T) {
+	passwordHash := vpsum.Checksum256([]byte("password"))
+	request := vpp2papi.NewPutRequest()
+	request.Context = vpp2papi.NewContextInfo()
+	request.Context.TargetNodeID = vpsum.IntToBuf256(vprand.Rand256(nil, nil))
+	request.Context.Challenge = vpsum.IntToBuf256(vprand.Rand256(nil, nil))
+	request.Key = vpsum.IntToBuf256(vprand.Rand256(nil, nil))
+	request.Value = []byte("value")
+
+	auth := RingAuth(passwordHash, PutRequestSigBytes(request))
+	ok, err := CheckRingAuth(passwordHash, PutRequestSigBytes(request), auth)
+	if !ok || err != nil {
+		t.Error("unable to check ring auth", err)
+	}
+	_, err = CheckRingAuth(vpsum.Checksum256([]byte("wrong")), PutRequestSigBytes(request), auth)
+	if err == nil {
+		t.Error("ring auth accepted with wrong password")
+	}
+	_, err = CheckRingAuth(passwordHash, PutRequestSigBytes(request), nil)
+	if err == nil {
+		t.Error("ring auth accepted without auth")
+	}
+	request.Value = []byte("other value")
+	_, err = CheckRingAuth(passwordHash, PutRequestSigBytes(request), auth)
+	if err == nil {
+		t.Error("ring auth accepted with modified request")
+	}
+}
+
+func TestSigBytesAmbiguity(t *testing.T) {
+	a := joinSigBytes([]byte("ab"), []byte("c"))
+	b := joinSigBytes([]byte("a"), []byte("bc"))
+	if bytes.Compare(a, b) == 0 {
+		t.Error("sig bytes are ambiguous")
+	}
+}
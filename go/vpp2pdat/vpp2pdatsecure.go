@@ -0,0 +1,79 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2pdat
+
+import (
+	"fmt"
+)
+
+const (
+	// SessionIDNbBytes is the number of bytes of a secure session ID.
+	SessionIDNbBytes = 16
+	// SessionKeyNbBytes is the number of bytes of a secure session key,
+	// before it is encrypted with the public key of the target host.
+	SessionKeyNbBytes = 32
+	// MaxLenSessionKey is the maximum length of an encrypted session key.
+	MaxLenSessionKey = 5000
+	// MinLenPayload is the minimum length for Payload fields, once decrypted.
+	MinLenPayload = 1
+	// MaxLenPayload is the maximum length for Payload fields, once decrypted.
+	MaxLenPayload = MaxLenMessage
+	// MaxLenEncryptedPayload is the maximum length for encrypted Payload fields.
+	MaxLenEncryptedPayload = 2 * MaxLenPayload
+)
+
+// CheckSessionID checks that a secure session ID has the right format.
+func CheckSessionID(sessionID []byte) (bool, error) {
+	if len(sessionID) != SessionIDNbBytes {
+		return false, fmt.Errorf("bad session ID len=%d, should be %d", len(sessionID), SessionIDNbBytes)
+	}
+
+	return true, nil
+}
+
+// CheckSessionKey checks that an encrypted secure session key has the
+// right format. It can be empty, when the session is already known.
+func CheckSessionKey(sessionKey []byte) (bool, error) {
+	if sessionKey == nil {
+		return true, nil
+	}
+	return checkLenByte("SessionKey", sessionKey, 0, MaxLenSessionKey)
+}
+
+// CheckPayload checks that a secure message payload, before it is
+// encrypted, has the right format.
+func CheckPayload(payload []byte) (bool, error) {
+	return checkLenByte("Payload", payload, MinLenPayload, MaxLenPayload)
+}
+
+// CheckEncryptedPayload checks that an encrypted secure message
+// payload has the right format.
+func CheckEncryptedPayload(payload []byte) (bool, error) {
+	return checkLenByte("Payload", payload, MinLenPayload, MaxLenEncryptedPayload)
+}
+
+// SessionIDToBuf converts a slice to a fixed-length session ID buffer.
+func SessionIDToBuf(sessionID []byte) [SessionIDNbBytes]byte {
+	var ret [SessionIDNbBytes]byte
+
+	copy(ret[:], sessionID)
+
+	return ret
+}
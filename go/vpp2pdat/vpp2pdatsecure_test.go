@@ -0,0 +1,51 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2pdat
+
+import (
+	"testing"
+)
+
+func TestCheckSecure(t *testing.T) {
+	b, err := CheckSessionID(make([]byte, SessionIDNbBytes))
+	if b != true || err != nil {
+		t.Error("CheckSessionID returned an error", err)
+	}
+	b, err = CheckSessionID(make([]byte, SessionIDNbBytes-1))
+	if b == true || err == nil {
+		t.Error("CheckSessionID does not report an error on short session ID")
+	}
+	b, err = CheckSessionKey(nil)
+	if b != true || err != nil {
+		t.Error("CheckSessionKey returned an error on empty key", err)
+	}
+	b, err = CheckSessionKey(make([]byte, MaxLenSessionKey+1))
+	if b == true || err == nil {
+		t.Error("CheckSessionKey does not report an error on too long key")
+	}
+	b, err = CheckPayload([]byte{})
+	if b == true || err == nil {
+		t.Error("CheckPayload does not report an error on empty payload")
+	}
+	b, err = CheckEncryptedPayload(make([]byte, MaxLenEncryptedPayload))
+	if b != true || err != nil {
+		t.Error("CheckEncryptedPayload returned an error on long payload", err)
+	}
+}
@@ -19,5 +19,7 @@
 
 // Package vpp2psim is only a test/demo which instanciates  elements
 // from vpp2p to show and check how they work. Think of this as a an
-// integration check.
+// integration check. Hosts can be connected through a simulated
+// network, with a virtual clock, latency, packet loss and partitions,
+// so that routing and stabilization can be checked reproducibly.
 package vpp2psim
@@ -0,0 +1,381 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psim
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EventJoin makes new nodes join the ring.
+	EventJoin = "join"
+	// EventCrash stops nodes abruptly, without telling anyone.
+	EventCrash = "crash"
+	// EventLeave makes nodes leave the ring gracefully.
+	EventLeave = "leave"
+)
+
+// Event is something happening to the ring at a given time.
+type Event struct {
+	// At is the simulated time, since the start of the scenario,
+	// at which the event happens.
+	At time.Duration
+	// Type is one of EventJoin, EventCrash or EventLeave.
+	Type string
+	// NbNodes is the number of nodes concerned, picked at random.
+	NbNodes int
+}
+
+// eventList sorts events by time.
+type eventList []Event
+
+func (l eventList) Len() int {
+	return len(l)
+}
+
+func (l eventList) Less(i, j int) bool {
+	return l[i].At < l[j].At
+}
+
+func (l eventList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+// Scenario describes a churn simulation.
+type Scenario struct {
+	// Config is the ring config, nil means the default one.
+	Config *vpp2papi.RingConfig
+	// NbHosts is the number of hosts.
+	NbHosts int
+	// NbNodesPerHost is the number of nodes per host at startup.
+	NbNodesPerHost int
+	// NbWarmupRounds is the number of rounds run to stabilize the
+	// ring before keys are stored and time starts.
+	NbWarmupRounds int
+	// NbKeys is the number of keys stored on the ring at startup.
+	NbKeys int
+	// Duration is the simulated duration of the scenario.
+	Duration time.Duration
+	// Events are the joins, crashes and leaves to play.
+	Events []Event
+}
+
+// ChurnSample is a measure of data availability at a given time.
+type ChurnSample struct {
+	// Elapsed is the simulated time since the start of the scenario.
+	Elapsed time.Duration
+	// NbNodes is the number of nodes up.
+	NbNodes int
+	// NbAvailable is the number of keys which could be retrieved.
+	NbAvailable int
+	// Availability is NbAvailable divided by the number of keys.
+	Availability float64
+}
+
+// ChurnReport contains the results of a churn simulation.
+type ChurnReport struct {
+	// Seed is the seed of the simulated network.
+	Seed int64
+	// Config is the ring config.
+	Config *vpp2papi.RingConfig
+	// NbKeys is the number of keys stored at startup.
+	NbKeys int
+	// NbJoins is the number of nodes which joined during the scenario.
+	NbJoins int
+	// NbFailedJoins is the number of nodes which could not join, typically
+	// because their lookup went through a node which had crashed.
+	NbFailedJoins int
+	// NbCrashes is the number of nodes which crashed.
+	NbCrashes int
+	// NbLeaves is the number of nodes which left gracefully.
+	NbLeaves int
+	// Samples are the availability measures, one per round.
+	Samples []ChurnSample
+	// MinAvailability is the lowest availability measured.
+	MinAvailability float64
+	// FinalAvailability is the availability at the end of the scenario.
+	FinalAvailability float64
+	// Network counts the calls made on the network.
+	Network NetworkStats
+}
+
+// ParseEvents parses a scenario script, with one event per line, such as
+// "90s crash 2", "2m join 3" or "5m30s leave 1". Durations use the
+// time.ParseDuration syntax. Empty lines and lines starting with # are ignored.
+func ParseEvents(script string) ([]Event, error) {
+	var ret []Event
+
+	scanner := bufio.NewScanner(strings.NewReader(script))
+	for i := 1; scanner.Scan(); i++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected \"<time> <join|crash|leave> <nbNodes>\", got \"%s\"", i, line)
+		}
+		at, err := time.ParseDuration(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i, err)
+		}
+		if at < 0 {
+			return nil, fmt.Errorf("line %d: negative time %s", i, fields[0])
+		}
+		switch fields[1] {
+		case EventJoin, EventCrash, EventLeave:
+		default:
+			return nil, fmt.Errorf("line %d: unknown event \"%s\"", i, fields[1])
+		}
+		nbNodes, err := strconv.Atoi(fields[2])
+		if err != nil || nbNodes < 1 {
+			return nil, fmt.Errorf("line %d: bad number of nodes \"%s\"", i, fields[2])
+		}
+		ret = append(ret, Event{At: at, Type: fields[1], NbNodes: nbNodes})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// churn holds the state of a running churn simulation.
+type churn struct {
+	network *Network
+	hosts   []*vpp2p.Host
+	ring    *vpp2p.Ring
+	nodes   []*vpp2p.Node
+	keys    [][]byte
+	values  [][]byte
+	report  *ChurnReport
+}
+
+// upNodes returns the nodes which are up, in creation order.
+func (c *churn) upNodes() []*vpp2p.Node {
+	ret := make([]*vpp2p.Node, 0, len(c.nodes))
+	for _, node := range c.nodes {
+		if node.Up() {
+			ret = append(ret, node)
+		}
+	}
+
+	return ret
+}
+
+// randomUpNode returns a random node which is up, or nil if there's none.
+func (c *churn) randomUpNode() *vpp2p.Node {
+	up := c.upNodes()
+	if len(up) == 0 {
+		return nil
+	}
+
+	return up[c.network.intn(len(up))]
+}
+
+func (c *churn) play(event Event) {
+	for i := 0; i < event.NbNodes; i++ {
+		switch event.Type {
+		case EventJoin:
+			bootstrap := c.randomUpNode()
+			if bootstrap == nil {
+				return
+			}
+			host := c.hosts[c.network.intn(len(c.hosts))]
+			node, err := c.network.NewNode(host, c.ring)
+			if err != nil {
+				vplog.LoggerWarning(c.network.env.Logger(), "unable to create node", err)
+				continue
+			}
+			c.nodes = append(c.nodes, node)
+			err = node.Join(c.hostOf(bootstrap).Info.HostURL)
+			if err != nil {
+				vplog.LoggerDebug(c.network.env.Logger(), "unable to join", err)
+				c.report.NbFailedJoins++
+				continue
+			}
+			c.report.NbJoins++
+		case EventCrash, EventLeave:
+			if len(c.upNodes()) <= 1 {
+				// keep at least one node, else there's no ring any more
+				return
+			}
+			node := c.randomUpNode()
+			if event.Type == EventCrash {
+				node.Stop()
+				c.report.NbCrashes++
+			} else {
+				err := node.Leave()
+				if err != nil {
+					vplog.LoggerDebug(c.network.env.Logger(), "unable to leave gracefully", err)
+				}
+				c.report.NbLeaves++
+			}
+		}
+	}
+}
+
+// hostOf returns the host of a node.
+func (c *churn) hostOf(node *vpp2p.Node) *vpp2p.Host {
+	for _, host := range c.hosts {
+		if bytes.Equal(host.Info.HostPubKey, node.Status.Info.HostPubKey) {
+			return host
+		}
+	}
+
+	return nil
+}
+
+// sample measures how many keys can be retrieved, each key being
+// looked up from a random node.
+func (c *churn) sample(elapsed time.Duration) {
+	s := ChurnSample{Elapsed: elapsed, NbNodes: len(c.upNodes())}
+	for i, key := range c.keys {
+		node := c.randomUpNode()
+		if node == nil {
+			break
+		}
+		found, value, _, err := node.Get(key, false)
+		if err == nil && found && bytes.Equal(value, c.values[i]) {
+			s.NbAvailable++
+		}
+	}
+	if len(c.keys) > 0 {
+		s.Availability = float64(s.NbAvailable) / float64(len(c.keys))
+	}
+	if len(c.report.Samples) == 0 || s.Availability < c.report.MinAvailability {
+		c.report.MinAvailability = s.Availability
+	}
+	c.report.FinalAvailability = s.Availability
+	c.report.Samples = append(c.report.Samples, s)
+}
+
+// RunChurn plays a churn scenario. It sets up the ring, stabilizes it,
+// stores NbKeys random keys, then runs one round every SyncDelay seconds
+// of simulated time, playing events when their time has come, and
+// measuring data availability after each round. Nodes are stopped
+// once done.
+func RunChurn(seed int64, scenario *Scenario) (*ChurnReport, error) {
+	var c churn
+	var rings []*vpp2p.Ring
+	var err error
+
+	c.network = NewNetwork(seed)
+	c.hosts, rings, c.nodes, err = SetupSim(c.network, scenario.Config, scenario.NbHosts, 1, scenario.NbNodesPerHost)
+	defer func() {
+		for _, node := range c.nodes {
+			node.Stop()
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+	c.ring = rings[0]
+	config := c.ring.Info.Config
+	delay := time.Duration(config.SyncDelay) * time.Second
+
+	for i := 0; i < scenario.NbWarmupRounds; i++ {
+		c.network.Round(c.nodes, delay)
+	}
+
+	c.report = &ChurnReport{Seed: seed, Config: config, NbKeys: scenario.NbKeys}
+	for i := 0; i < scenario.NbKeys; i++ {
+		key := c.network.NewNodeID()
+		value := []byte(fmt.Sprintf("value %d", i))
+		_, _, err = c.randomUpNode().Put(key, value, false)
+		if err != nil {
+			return nil, err
+		}
+		c.keys = append(c.keys, key)
+		c.values = append(c.values, value)
+	}
+
+	events := make([]Event, len(scenario.Events))
+	copy(events, scenario.Events)
+	sort.Stable(eventList(events))
+
+	c.sample(0)
+	for elapsed := delay; elapsed <= scenario.Duration; elapsed += delay {
+		for len(events) > 0 && events[0].At <= elapsed {
+			c.play(events[0])
+			events = events[1:]
+		}
+		c.network.Round(c.nodes, delay)
+		c.sample(elapsed)
+	}
+	c.report.Network = c.network.Stats()
+
+	return c.report, nil
+}
+
+// WriteChurnCSV writes the availability samples of reports as CSV, one
+// line per sample, so that several configs can be compared.
+func WriteChurnCSV(w io.Writer, reports []*ChurnReport) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{"seed", "nb_copy", "sync_delay", "disconnect_timeout", "elapsed", "nb_nodes", "nb_keys", "nb_available", "availability"})
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		for _, s := range r.Samples {
+			err = cw.Write([]string{
+				strconv.FormatInt(r.Seed, 10),
+				strconv.Itoa(int(r.Config.NbCopy)),
+				strconv.Itoa(int(r.Config.SyncDelay)),
+				strconv.Itoa(int(r.Config.DisconnectTimeout)),
+				formatFloat(s.Elapsed.Seconds()),
+				strconv.Itoa(s.NbNodes),
+				strconv.Itoa(r.NbKeys),
+				strconv.Itoa(s.NbAvailable),
+				formatFloat(s.Availability),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteChurnJSON writes complete churn reports as JSON.
+func WriteChurnJSON(w io.Writer, reports []*ChurnReport) error {
+	buf, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+
+	return err
+}
@@ -0,0 +1,141 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psim
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testChurnScript = `
+# crash a few nodes, replace them, and make some leave
+1m crash 2
+2m join 3
+3m leave 2
+4m crash 2
+`
+
+func TestParseEvents(t *testing.T) {
+	events, err := ParseEvents(testChurnScript)
+	if err != nil {
+		t.Fatal("unable to parse script", err)
+	}
+	if len(events) != 4 || events[1].At != 2*time.Minute || events[1].Type != EventJoin || events[1].NbNodes != 3 {
+		t.Error("bad events", events)
+	}
+	for _, script := range []string{"1m crash", "1x crash 1", "1m explode 1", "1m join 0", "-1m join 1"} {
+		_, err = ParseEvents(script)
+		if err == nil {
+			t.Errorf("bad script \"%s\" accepted", script)
+		}
+	}
+}
+
+func TestRunChurn(t *testing.T) {
+	var reports []*ChurnReport
+
+	events, err := ParseEvents(testChurnScript)
+	if err != nil {
+		t.Fatal("unable to parse script", err)
+	}
+	for _, nbCopy := range []int32{1, 3} {
+		config := vpp2pdat.DefaultRingConfig()
+		config.NbCopy = nbCopy
+		scenario := &Scenario{Config: config, NbHosts: 4, NbNodesPerHost: 4, NbWarmupRounds: 10, NbKeys: 50, Duration: 6 * time.Minute, Events: events}
+		report, err := RunChurn(testSimSeed, scenario)
+		if err != nil {
+			t.Fatal("unable to run churn scenario", err)
+		}
+		t.Logf("NbCopy=%d min=%f final=%f joins=%d/%d crashes=%d leaves=%d", nbCopy, report.MinAvailability, report.FinalAvailability, report.NbJoins, report.NbJoins+report.NbFailedJoins, report.NbCrashes, report.NbLeaves)
+		if len(report.Samples) == 0 || report.Samples[0].Availability != 1 {
+			t.Error("keys not available before churn")
+		}
+		if report.NbJoins+report.NbFailedJoins != 3 || report.NbCrashes != 4 || report.NbLeaves != 2 {
+			t.Errorf("events not played joins=%d crashes=%d leaves=%d", report.NbJoins+report.NbFailedJoins, report.NbCrashes, report.NbLeaves)
+		}
+		reports = append(reports, report)
+	}
+	if reports[1].FinalAvailability < reports[0].FinalAvailability {
+		t.Error("more copies gave a lower availability")
+	}
+	// crashed nodes have been dropped by then, every key has a copy left
+	if reports[1].FinalAvailability != 1 {
+		t.Errorf("keys lost with %d copies, availability=%f", reports[1].Config.NbCopy, reports[1].FinalAvailability)
+	}
+
+	var buf bytes.Buffer
+	err = WriteChurnCSV(&buf, reports)
+	if err != nil {
+		t.Fatal("unable to write CSV", err)
+	}
+	if strings.Count(buf.String(), "\n") != len(reports[0].Samples)+len(reports[1].Samples)+1 {
+		t.Error("bad CSV", buf.String())
+	}
+	buf.Reset()
+	err = WriteChurnJSON(&buf, reports)
+	if err != nil || !strings.Contains(buf.String(), "FinalAvailability") {
+		t.Error("bad JSON", err)
+	}
+}
+
+func TestRunChurnExpiry(t *testing.T) {
+	config := vpp2pdat.DefaultRingConfig()
+	config.DataLifetime = 150
+	scenario := &Scenario{Config: config, NbHosts: 4, NbNodesPerHost: 2, NbWarmupRounds: 10, NbKeys: 20, Duration: 5 * time.Minute}
+	report, err := RunChurn(testSimSeed, scenario)
+	if err != nil {
+		t.Fatal("unable to run churn scenario", err)
+	}
+	for _, s := range report.Samples {
+		// keys were stored at the start of the scenario, they expire in simulated time
+		if s.Elapsed < 2*time.Minute && s.Availability != 1 {
+			t.Errorf("keys not available before DataLifetime, elapsed=%s availability=%f", s.Elapsed, s.Availability)
+		}
+		if s.Elapsed > 3*time.Minute && s.Availability != 0 {
+			t.Errorf("keys still available after DataLifetime, elapsed=%s availability=%f", s.Elapsed, s.Availability)
+		}
+	}
+}
+
+func TestRunChurnRepair(t *testing.T) {
+	// waves of crashes, each one leaving enough time for the dead
+	// nodes to be dropped and for replicas to be repaired, in the end
+	// more nodes than NbCopy have crashed, without repair some keys are lost
+	events, err := ParseEvents("1m crash 2\n4m crash 2\n7m crash 2\n")
+	if err != nil {
+		t.Fatal("unable to parse script", err)
+	}
+	config := vpp2pdat.DefaultRingConfig()
+	scenario := &Scenario{Config: config, NbHosts: 4, NbNodesPerHost: 4, NbWarmupRounds: 10, NbKeys: 100, Duration: 12 * time.Minute, Events: events}
+	report, err := RunChurn(testSimSeed, scenario)
+	if err != nil {
+		t.Fatal("unable to run churn scenario", err)
+	}
+	t.Logf("NbCopy=%d min=%f final=%f crashes=%d", config.NbCopy, report.MinAvailability, report.FinalAvailability, report.NbCrashes)
+	if report.NbCrashes != 6 {
+		t.Errorf("bad number of crashes %d", report.NbCrashes)
+	}
+	if report.FinalAvailability != 1 {
+		t.Errorf("keys lost although replicas had time to be repaired, availability=%f", report.FinalAvailability)
+	}
+}
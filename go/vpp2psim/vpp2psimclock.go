@@ -0,0 +1,69 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psim
+
+import (
+	"sync"
+	"time"
+)
+
+// SimEpoch is the date at which all simulations start, any fixed
+// value would do, what matters is that it does not depend on the system.
+var SimEpoch = time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Clock is a virtual clock, which only moves forward when told so.
+// It implements vpp2p.Clock.
+type Clock struct {
+	access sync.RWMutex
+	now    time.Time
+}
+
+// NewClock creates a new virtual clock, set to SimEpoch.
+func NewClock() *Clock {
+	return &Clock{now: SimEpoch}
+}
+
+// Now returns the current virtual time.
+// It's thread-safe.
+func (c *Clock) Now() time.Time {
+	defer c.access.RUnlock()
+	c.access.RLock()
+
+	return c.now
+}
+
+// Advance moves the clock forward, negative durations are ignored.
+// It's thread-safe.
+func (c *Clock) Advance(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	defer c.access.Unlock()
+	c.access.Lock()
+
+	c.now = c.now.Add(d)
+}
+
+// Elapsed returns the virtual time elapsed since SimEpoch.
+// It's thread-safe.
+func (c *Clock) Elapsed() time.Duration {
+	return c.Now().Sub(SimEpoch)
+}
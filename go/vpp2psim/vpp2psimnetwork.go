@@ -0,0 +1,475 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psim
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpcommonapi"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLatency is the default one-way latency of simulated calls.
+	DefaultLatency = 50 * time.Millisecond
+)
+
+// NetworkStats counts what happened on a simulated network.
+type NetworkStats struct {
+	// NbCalls is the number of calls sent on the network.
+	NbCalls int
+	// NbDelivered is the number of calls which reached their target.
+	NbDelivered int
+	// NbLost is the number of calls dropped because of packet loss.
+	NbLost int
+	// NbPartitioned is the number of calls dropped because source
+	// and target were in different partitions.
+	NbPartitioned int
+}
+
+// Network is a deterministic in-memory network between local hosts.
+// It implements vpp2p.Transport, calls are delivered synchronously,
+// each one moving the virtual clock forward by its latency, and can
+// be lost, or blocked by partitions. All random decisions come from
+// a single generator, initialized with a seed, so as long as calls
+// are made in the same order, which is what Round does, two
+// simulations with the same seed give the same results. Each network
+// has its own environment, so that simulations are isolated from each
+// other, hosts, rings and nodes must be created within it.
+type Network struct {
+	access     sync.Mutex
+	env        *vpp2p.Env
+	seed       int64
+	rand       *rand.Rand
+	clock      *Clock
+	minLatency time.Duration
+	maxLatency time.Duration
+	loss       float64
+	partitions map[string]int
+	stats      NetworkStats
+}
+
+// nodeList sorts nodes by ID.
+type nodeList []*vpp2p.Node
+
+func (l nodeList) Len() int {
+	return len(l)
+}
+
+func (l nodeList) Less(i, j int) bool {
+	return bytes.Compare(l[i].Status.Info.NodeID, l[j].Status.Info.NodeID) < 0
+}
+
+func (l nodeList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+// NewNetwork creates a new simulated network, with DefaultLatency,
+// no packet loss and no partition. It comes with a new environment,
+// which uses the network to reach local hosts, and its virtual clock
+// to track when hosts are seen.
+func NewNetwork(seed int64) *Network {
+	ret := &Network{env: vpp2p.NewEnv(), seed: seed, rand: rand.New(rand.NewSource(seed)), clock: NewClock(), minLatency: DefaultLatency, maxLatency: DefaultLatency, partitions: make(map[string]int)}
+	ret.env.SetTransport(ret)
+	ret.env.HostInfoCatalog().SetClock(ret.clock)
+
+	return ret
+}
+
+// Env returns the environment of the network.
+func (n *Network) Env() *vpp2p.Env {
+	return n.env
+}
+
+// Seed returns the seed used to initialize the network.
+func (n *Network) Seed() int64 {
+	return n.seed
+}
+
+// Clock returns the virtual clock of the network.
+func (n *Network) Clock() *Clock {
+	return n.clock
+}
+
+// SetLatency sets the one-way latency of calls, picked at random
+// between minLatency and maxLatency.
+// It's thread-safe.
+func (n *Network) SetLatency(minLatency, maxLatency time.Duration) error {
+	if minLatency < 0 || maxLatency < minLatency {
+		return fmt.Errorf("bad latency range [%s,%s]", minLatency, maxLatency)
+	}
+
+	defer n.access.Unlock()
+	n.access.Lock()
+
+	n.minLatency = minLatency
+	n.maxLatency = maxLatency
+
+	return nil
+}
+
+// SetLoss sets the probability, between 0 and 1, for a call to be lost.
+// It's thread-safe.
+func (n *Network) SetLoss(loss float64) error {
+	if loss < 0 || loss > 1 {
+		return fmt.Errorf("bad loss %f, range is [0,1]", loss)
+	}
+
+	defer n.access.Unlock()
+	n.access.Lock()
+
+	n.loss = loss
+
+	return nil
+}
+
+// Partition splits the network, hosts within a group can talk together
+// but not with hosts in other groups. Hosts which are not in any group
+// form a group of their own. This replaces any previous partition.
+// It's thread-safe.
+func (n *Network) Partition(groups ...[]*vpp2p.Host) {
+	defer n.access.Unlock()
+	n.access.Lock()
+
+	n.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, host := range group {
+			n.partitions[host.Info.HostURL] = i + 1
+		}
+	}
+}
+
+// Heal removes all partitions.
+// It's thread-safe.
+func (n *Network) Heal() {
+	n.Partition()
+}
+
+// Stats returns what happened on the network so far.
+// It's thread-safe.
+func (n *Network) Stats() NetworkStats {
+	defer n.access.Unlock()
+	n.access.Lock()
+
+	return n.stats
+}
+
+// Connect returns a handler which forwards calls to host through the network.
+func (n *Network) Connect(host *vpp2p.Host) (vpp2papi.VpP2pApi, error) {
+	if host == nil {
+		return nil, fmt.Errorf("no host")
+	}
+
+	return &link{network: n, host: host}, nil
+}
+
+// NewNodeID returns a random node ID, taken from the network generator,
+// so that the position of nodes on rings is reproducible.
+// It's thread-safe.
+func (n *Network) NewNodeID() []byte {
+	defer n.access.Unlock()
+	n.access.Lock()
+
+	ret := make([]byte, vpp2pdat.NodeIDBufNbBytes)
+	for i := range ret {
+		ret[i] = byte(n.rand.Intn(256))
+	}
+
+	return ret
+}
+
+// intn returns a random number in [0,n), taken from the network generator.
+// It's thread-safe.
+func (n *Network) intn(max int) int {
+	defer n.access.Unlock()
+	n.access.Lock()
+
+	return n.rand.Intn(max)
+}
+
+// NewNode creates a node with a reproducible ID. The node uses the
+// virtual clock, and does not run background stabilization,
+// it is stabilized by Round.
+func (n *Network) NewNode(host *vpp2p.Host, ring *vpp2p.Ring) (*vpp2p.Node, error) {
+	node, err := vpp2p.NewNode(n.env, host, ring, n.NewNodeID())
+	if err != nil {
+		return nil, err
+	}
+	node.SetAutoSync(false)
+	node.SetClock(n.clock)
+
+	return node, nil
+}
+
+// Round runs one stabilization round, calling SyncStep on every node
+// which is up, in an order picked at random, then moves the virtual
+// clock forward by delay, typically the ring SyncDelay. This way
+// nodes repair replicas, and expire data, in simulated time.
+func (n *Network) Round(nodes []*vpp2p.Node, delay time.Duration) {
+	sorted := make([]*vpp2p.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.Sort(nodeList(sorted))
+
+	n.access.Lock()
+	perm := n.rand.Perm(len(sorted))
+	n.access.Unlock()
+
+	for _, i := range perm {
+		if sorted[i].Up() {
+			sorted[i].SyncStep()
+		}
+	}
+	n.clock.Advance(delay)
+}
+
+// deliver decides wether a call from the source described by context
+// reaches target, and moves the clock forward by the call latency.
+// Calls without context, such as Status, can not be blocked by
+// partitions since their source is unknown.
+func (n *Network) deliver(context *vpp2papi.ContextInfo, target *vpp2p.Host) error {
+	var latency time.Duration
+
+	n.access.Lock()
+	n.stats.NbCalls++
+	latency = n.minLatency
+	if n.maxLatency > n.minLatency {
+		latency += time.Duration(n.rand.Int63n(int64(n.maxLatency - n.minLatency + 1)))
+	}
+	lost := n.loss > 0 && n.rand.Float64() < n.loss
+	partitioned := false
+	if context != nil && context.SourceHost != nil {
+		partitioned = n.partitions[context.SourceHost.HostURL] != n.partitions[target.Info.HostURL]
+	}
+	switch {
+	case partitioned:
+		n.stats.NbPartitioned++
+	case lost:
+		n.stats.NbLost++
+	default:
+		n.stats.NbDelivered++
+	}
+	n.access.Unlock()
+
+	n.clock.Advance(latency)
+	if partitioned {
+		return fmt.Errorf("host %s unreachable, network is partitioned", target.Info.HostURL)
+	}
+	if lost {
+		return fmt.Errorf("call to host %s lost", target.Info.HostURL)
+	}
+
+	return nil
+}
+
+// link forwards calls to a host through a simulated network.
+type link struct {
+	network *Network
+	host    *vpp2p.Host
+}
+
+// Ping forwards the call to the target host, through the network.
+func (l *link) Ping() error {
+	if err := l.network.deliver(nil, l.host); err != nil {
+		return err
+	}
+	return l.host.Ping()
+}
+
+// GetVersion forwards the call to the target host, through the network.
+func (l *link) GetVersion() (*vpcommonapi.Version, error) {
+	if err := l.network.deliver(nil, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.GetVersion()
+}
+
+// GetPackage forwards the call to the target host, through the network.
+func (l *link) GetPackage() (*vpcommonapi.Package, error) {
+	if err := l.network.deliver(nil, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.GetPackage()
+}
+
+// Uptime forwards the call to the target host, through the network.
+func (l *link) Uptime() (int64, error) {
+	if err := l.network.deliver(nil, l.host); err != nil {
+		return 0, err
+	}
+	return l.host.Uptime()
+}
+
+// Status forwards the call to the target host, through the network.
+func (l *link) Status() (*vpp2papi.HostStatus, error) {
+	if err := l.network.deliver(nil, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Status()
+}
+
+// Challenge forwards the call to the target host, through the network.
+func (l *link) Challenge(request *vpp2papi.ChallengeRequest) (*vpp2papi.ChallengeResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Challenge(request)
+}
+
+// Lookup forwards the call to the target host, through the network.
+func (l *link) Lookup(request *vpp2papi.LookupRequest) (*vpp2papi.LookupResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Lookup(request)
+}
+
+// LookupMany forwards the call to the target host, through the network.
+func (l *link) LookupMany(request *vpp2papi.LookupManyRequest) (*vpp2papi.LookupManyResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.LookupMany(request)
+}
+
+// GetSuccessors forwards the call to the target host, through the network.
+func (l *link) GetSuccessors(request *vpp2papi.GetSuccessorsRequest) (*vpp2papi.GetSuccessorsResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.GetSuccessors(request)
+}
+
+// GetPredecessor forwards the call to the target host, through the network.
+func (l *link) GetPredecessor(request *vpp2papi.GetPredecessorRequest) (*vpp2papi.GetPredecessorResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.GetPredecessor(request)
+}
+
+// Sync forwards the call to the target host, through the network.
+func (l *link) Sync(request *vpp2papi.SyncRequest) (*vpp2papi.SyncResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Sync(request)
+}
+
+// Put forwards the call to the target host, through the network.
+func (l *link) Put(request *vpp2papi.PutRequest) (*vpp2papi.PutResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Put(request)
+}
+
+// Get forwards the call to the target host, through the network.
+func (l *link) Get(request *vpp2papi.GetRequest) (*vpp2papi.GetResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Get(request)
+}
+
+// Delete forwards the call to the target host, through the network.
+func (l *link) Delete(request *vpp2papi.DeleteRequest) (*vpp2papi.DeleteResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Delete(request)
+}
+
+// Merkle forwards the call to the target host, through the network.
+func (l *link) Merkle(request *vpp2papi.MerkleRequest) (*vpp2papi.MerkleResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Merkle(request)
+}
+
+// Leave forwards the call to the target host, through the network.
+func (l *link) Leave(request *vpp2papi.LeaveRequest) (*vpp2papi.LeaveResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Leave(request)
+}
+
+// AnnounceRing forwards the call to the target host, through the network.
+func (l *link) AnnounceRing(request *vpp2papi.AnnounceRingRequest) (*vpp2papi.AnnounceRingResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.AnnounceRing(request)
+}
+
+// ListRings forwards the call to the target host, through the network.
+func (l *link) ListRings(request *vpp2papi.ListRingsRequest) (*vpp2papi.ListRingsResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.ListRings(request)
+}
+
+// Subscribe forwards the call to the target host, through the network.
+func (l *link) Subscribe(request *vpp2papi.SubscribeRequest) (*vpp2papi.SubscribeResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Subscribe(request)
+}
+
+// Unsubscribe forwards the call to the target host, through the network.
+func (l *link) Unsubscribe(request *vpp2papi.UnsubscribeRequest) (*vpp2papi.UnsubscribeResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Unsubscribe(request)
+}
+
+// Publish forwards the call to the target host, through the network.
+func (l *link) Publish(request *vpp2papi.PublishRequest) (*vpp2papi.PublishResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Publish(request)
+}
+
+// Handshake forwards the call to the target host, through the network.
+func (l *link) Handshake(request *vpp2papi.HandshakeRequest) (*vpp2papi.HandshakeResponse, error) {
+	if err := l.network.deliver(nil, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.Handshake(request)
+}
+
+// SecureMessage forwards the call to the target host, through the network.
+func (l *link) SecureMessage(request *vpp2papi.SecureMessageRequest) (*vpp2papi.SecureMessageResponse, error) {
+	if err := l.network.deliver(request.Context, l.host); err != nil {
+		return nil, err
+	}
+	return l.host.SecureMessage(request)
+}
@@ -0,0 +1,154 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psim
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"sort"
+	"testing"
+	"time"
+)
+
+const (
+	testSimNbHosts            = 4
+	testSimNbNodesPerHostRing = 4
+	testSimNbRounds           = 8
+	testSimSeed               = 42
+	testSimRoundDelay         = 15 * time.Second
+)
+
+// testSimRing returns a string describing who follows whom on the ring,
+// nodes being sorted by ID, along with an error if a node's first
+// successor is not the next node.
+func testSimRing(nodes []*vpp2p.Node) (string, error) {
+	var err error
+	var buf bytes.Buffer
+
+	sorted := make([]*vpp2p.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.Sort(nodeList(sorted))
+	for i, node := range sorted {
+		next := sorted[(i+1)%len(sorted)]
+		successors := node.GetSuccessors()
+		if len(successors) == 0 {
+			err = fmt.Errorf("node %d has no successor", i)
+			continue
+		}
+		fmt.Fprintf(&buf, "%x->%x\n", node.Status.Info.NodeID[:4], successors[0].NodeID[:4])
+		if !bytes.Equal(successors[0].NodeID, next.Status.Info.NodeID) {
+			err = fmt.Errorf("node %d has a bad successor", i)
+		}
+	}
+
+	return buf.String(), err
+}
+
+func testSimRun(t *testing.T) (string, NetworkStats, time.Duration) {
+	network := NewNetwork(testSimSeed)
+	err := network.SetLatency(10*time.Millisecond, 100*time.Millisecond)
+	if err != nil {
+		t.Fatal("unable to set latency", err)
+	}
+	_, _, nodes, err := SetupSim(network, nil, testSimNbHosts, 1, testSimNbNodesPerHostRing)
+	if err != nil {
+		t.Fatal("unable to set up simulation", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+	}
+
+	for i := 0; i < testSimNbRounds; i++ {
+		network.Round(nodes, testSimRoundDelay)
+	}
+	ring, err := testSimRing(nodes)
+	if err != nil {
+		t.Error("ring not stabilized", err)
+	}
+
+	for i, node := range nodes {
+		key := network.NewNodeID()
+		found, path, err := node.Lookup(key, node.GetKeyShift(key), node.GetImaginaryNode(key))
+		if err != nil || !found || len(path) == 0 {
+			t.Errorf("unable to lookup key from node %d: %v", i, err)
+		}
+	}
+
+	return ring, network.Stats(), network.Clock().Elapsed()
+}
+
+func TestNetworkDeterminism(t *testing.T) {
+	ring1, stats1, elapsed1 := testSimRun(t)
+	ring2, stats2, elapsed2 := testSimRun(t)
+
+	if ring1 != ring2 {
+		t.Error("different rings with the same seed")
+	}
+	if stats1 != stats2 {
+		t.Errorf("different stats with the same seed %v!=%v", stats1, stats2)
+	}
+	if elapsed1 != elapsed2 {
+		t.Errorf("different elapsed time with the same seed %s!=%s", elapsed1, elapsed2)
+	}
+	if stats1.NbCalls == 0 || stats1.NbDelivered != stats1.NbCalls {
+		t.Errorf("bad stats %v", stats1)
+	}
+	if elapsed1 < testSimNbRounds*testSimRoundDelay {
+		t.Errorf("virtual clock did not move forward enough, elapsed=%s", elapsed1)
+	}
+}
+
+func TestNetworkFailures(t *testing.T) {
+	network := NewNetwork(testSimSeed + 1)
+	hosts, _, nodes, err := SetupSim(network, nil, testSimNbHosts, 1, testSimNbNodesPerHostRing)
+	if err != nil {
+		t.Fatal("unable to set up simulation", err)
+	}
+	for _, node := range nodes {
+		defer node.Stop()
+	}
+	for i := 0; i < testSimNbRounds; i++ {
+		network.Round(nodes, testSimRoundDelay)
+	}
+
+	network.Partition(hosts[:testSimNbHosts/2], hosts[testSimNbHosts/2:])
+	network.Round(nodes, testSimRoundDelay)
+	stats := network.Stats()
+	if stats.NbPartitioned == 0 {
+		t.Error("no call blocked by partition")
+	}
+	network.Heal()
+
+	err = network.SetLoss(1)
+	if err != nil {
+		t.Fatal("unable to set loss", err)
+	}
+	network.Round(nodes, testSimRoundDelay)
+	if network.Stats().NbLost == 0 || network.Stats().NbDelivered != stats.NbDelivered {
+		t.Errorf("calls delivered despite total loss %v", network.Stats())
+	}
+	if network.SetLoss(2) == nil {
+		t.Error("bad loss accepted")
+	}
+	if network.SetLatency(time.Second, time.Millisecond) == nil {
+		t.Error("bad latency accepted")
+	}
+}
@@ -0,0 +1,351 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psim
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpbruijn"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// NodeShare is the part of the key space a node is responsible for.
+type NodeShare struct {
+	// NodeID is the hex-encoded node ID.
+	NodeID string
+	// Host is the short string of the node host public key.
+	Host string
+	// Share is the part of the ring, between 0 and 1, from
+	// the node predecessor (excluded) to the node (included).
+	Share float64
+}
+
+// Report contains routing quality and load-balance measures for a ring.
+type Report struct {
+	// Seed is the seed of the simulated network.
+	Seed int64
+	// Config is the ring config.
+	Config *vpp2papi.RingConfig
+	// NbNodes is the number of nodes on the ring.
+	NbNodes int
+	// Log2NbNodes is log2(NbNodes), the reference for routing costs.
+	Log2NbNodes float64
+
+	// NbLookups is the number of random lookups issued.
+	NbLookups int
+	// NbFailures is the number of lookups which returned an error,
+	// or did not find any node.
+	NbFailures int
+	// NbWrong is the number of lookups which returned a node
+	// which is not the one holding the key.
+	NbWrong int
+	// FailureRate is (NbFailures+NbWrong)/NbLookups.
+	FailureRate float64
+	// HopsHistogram gives, for each number of hops, the number of
+	// successful lookups which needed it.
+	HopsHistogram []int
+	// HopsMean is the average number of hops of successful lookups.
+	HopsMean float64
+	// HopsP50 is the median number of hops.
+	HopsP50 int
+	// HopsP90 is the 90th percentile of the number of hops.
+	HopsP90 int
+	// HopsP99 is the 99th percentile of the number of hops.
+	HopsP99 int
+	// HopsMax is the greatest number of hops.
+	HopsMax int
+
+	// Shares is the key space share of each node, sorted by node ID.
+	Shares []NodeShare
+	// ShareMin is the smallest share.
+	ShareMin float64
+	// ShareMax is the greatest share.
+	ShareMax float64
+	// ShareStdDev is the standard deviation of shares, the mean
+	// being 1/NbNodes.
+	ShareStdDev float64
+
+	// Network counts the calls made on the network during the whole simulation.
+	Network NetworkStats
+	// Elapsed is the virtual time elapsed during the whole simulation.
+	Elapsed time.Duration
+}
+
+// ringNodeList sorts nodes by position on a ring, that is their ID
+// modulo BruijnM^BruijnN. Bruijn walkers can't be used for this,
+// their Cmp function compares points on a circle.
+type ringNodeList struct {
+	positions []*big.Int
+	nodes     []*vpp2p.Node
+}
+
+func newRingNodeList(config *vpp2papi.RingConfig, nodes []*vpp2p.Node) ringNodeList {
+	ret := ringNodeList{positions: make([]*big.Int, len(nodes)), nodes: nodes}
+
+	max := big.NewInt(0)
+	max.Exp(big.NewInt(int64(config.BruijnM)), big.NewInt(int64(config.BruijnN)), nil)
+	for i, node := range nodes {
+		ret.positions[i] = big.NewInt(0)
+		ret.positions[i].SetBytes(node.Status.Info.NodeID)
+		ret.positions[i].Mod(ret.positions[i], max)
+	}
+
+	return ret
+}
+
+func (l ringNodeList) Len() int {
+	return len(l.nodes)
+}
+
+func (l ringNodeList) Less(i, j int) bool {
+	return l.positions[i].Cmp(l.positions[j]) < 0
+}
+
+func (l ringNodeList) Swap(i, j int) {
+	l.positions[i], l.positions[j] = l.positions[j], l.positions[i]
+	l.nodes[i], l.nodes[j] = l.nodes[j], l.nodes[i]
+}
+
+// Measure issues nbLookups lookups for random keys, from random nodes,
+// on the nodes of ring which are up. Each lookup result is checked
+// against the node which really holds the key. It also measures the
+// key space share of each node.
+func Measure(network *Network, ring *vpp2p.Ring, nodes []*vpp2p.Node, nbLookups int) (*Report, error) {
+	var ret Report
+
+	config := ring.Info.Config
+	walker, err := vpbruijn.BruijnNew(int(config.BruijnM), int(config.BruijnN))
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]*vpp2p.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Up() && bytes.Equal(node.Status.Info.RingID, ring.Info.RingID) {
+			sorted = append(sorted, node)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil, fmt.Errorf("no node up on ring")
+	}
+	sort.Sort(newRingNodeList(config, sorted))
+
+	ret.Seed = network.Seed()
+	ret.Config = config
+	ret.NbNodes = len(sorted)
+	ret.Log2NbNodes = math.Log2(float64(ret.NbNodes))
+
+	measureLookups(&ret, network, walker, sorted, nbLookups)
+	measureShares(&ret, walker, sorted)
+
+	ret.Network = network.Stats()
+	ret.Elapsed = network.Clock().Elapsed()
+
+	return &ret, nil
+}
+
+// owner returns the node holding key, sorted must be sorted.
+func owner(walker vpbruijn.BruijnWalker, sorted []*vpp2p.Node, key []byte) *vpp2p.Node {
+	nbNodes := len(sorted)
+	for i, node := range sorted {
+		predecessor := sorted[(i+nbNodes-1)%nbNodes]
+		if walker.GtLe(key, predecessor.Status.Info.NodeID, node.Status.Info.NodeID) {
+			return node
+		}
+	}
+
+	return sorted[0]
+}
+
+func measureLookups(report *Report, network *Network, walker vpbruijn.BruijnWalker, sorted []*vpp2p.Node, nbLookups int) {
+	var hops []int
+
+	report.NbLookups = nbLookups
+	for i := 0; i < nbLookups; i++ {
+		key := network.NewNodeID()
+		node := sorted[network.intn(len(sorted))]
+		found, path, err := node.Lookup(key, node.GetKeyShift(key), node.GetImaginaryNode(key))
+		if err != nil || !found || len(path) == 0 {
+			report.NbFailures++
+			continue
+		}
+		if !bytes.Equal(path[len(path)-1].NodeID, owner(walker, sorted, key).Status.Info.NodeID) {
+			report.NbWrong++
+			continue
+		}
+		hops = append(hops, len(path)-1)
+	}
+	if nbLookups > 0 {
+		report.FailureRate = float64(report.NbFailures+report.NbWrong) / float64(nbLookups)
+	}
+	if len(hops) == 0 {
+		return
+	}
+
+	sort.Ints(hops)
+	report.HopsMax = hops[len(hops)-1]
+	report.HopsHistogram = make([]int, report.HopsMax+1)
+	sum := 0
+	for _, v := range hops {
+		report.HopsHistogram[v]++
+		sum += v
+	}
+	report.HopsMean = float64(sum) / float64(len(hops))
+	report.HopsP50 = hops[(len(hops)-1)*50/100]
+	report.HopsP90 = hops[(len(hops)-1)*90/100]
+	report.HopsP99 = hops[(len(hops)-1)*99/100]
+}
+
+func measureShares(report *Report, walker vpbruijn.BruijnWalker, sorted []*vpp2p.Node) {
+	nbNodes := len(sorted)
+	mean := 1.0 / float64(nbNodes)
+	variance := 0.0
+
+	report.Shares = make([]NodeShare, nbNodes)
+	for i, node := range sorted {
+		share := 1.0
+		if nbNodes > 1 {
+			predecessor := sorted[(i+nbNodes-1)%nbNodes]
+			share = walker.RingRange(predecessor.Status.Info.NodeID, node.Status.Info.NodeID)
+			if share <= 0 {
+				// range wraps around zero
+				share += 1.0
+			}
+		}
+		report.Shares[i] = NodeShare{NodeID: fmt.Sprintf("%x", node.Status.Info.NodeID), Host: vpp2pdat.HostPubKeyToShortString(node.Status.Info.HostPubKey), Share: share}
+		if i == 0 || share < report.ShareMin {
+			report.ShareMin = share
+		}
+		if share > report.ShareMax {
+			report.ShareMax = share
+		}
+		variance += (share - mean) * (share - mean)
+	}
+	report.ShareStdDev = math.Sqrt(variance / float64(nbNodes))
+}
+
+// RunReport sets up a simulation with nbHosts hosts, nbNodesPerHost nodes
+// per host, on a ring using config, runs nbRounds stabilization rounds,
+// then measures the ring with nbLookups random lookups. Nodes are stopped
+// once done.
+func RunReport(seed int64, config *vpp2papi.RingConfig, nbHosts, nbNodesPerHost, nbRounds, nbLookups int) (*Report, error) {
+	network := NewNetwork(seed)
+
+	_, rings, nodes, err := SetupSim(network, config, nbHosts, 1, nbNodesPerHost)
+	for _, node := range nodes {
+		defer node.Stop()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	delay := time.Duration(rings[0].Info.Config.SyncDelay) * time.Second
+	for i := 0; i < nbRounds; i++ {
+		network.Round(nodes, delay)
+	}
+
+	return Measure(network, rings[0], nodes, nbLookups)
+}
+
+var reportsCSVHeader = []string{"seed", "bruijn_m", "bruijn_n", "nb_step", "nb_copy", "nb_nodes", "log2_nb_nodes", "nb_lookups", "nb_failures", "nb_wrong", "failure_rate", "hops_mean", "hops_p50", "hops_p90", "hops_p99", "hops_max", "share_min", "share_max", "share_stddev", "nb_calls"}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', 6, 64)
+}
+
+// WriteCSV writes a summary of reports as CSV, one line per report,
+// so that several ring configs can be compared.
+func WriteCSV(w io.Writer, reports []*Report) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(reportsCSVHeader)
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		err = cw.Write([]string{
+			strconv.FormatInt(r.Seed, 10),
+			strconv.Itoa(int(r.Config.BruijnM)),
+			strconv.Itoa(int(r.Config.BruijnN)),
+			strconv.Itoa(int(r.Config.NbStep)),
+			strconv.Itoa(int(r.Config.NbCopy)),
+			strconv.Itoa(r.NbNodes),
+			formatFloat(r.Log2NbNodes),
+			strconv.Itoa(r.NbLookups),
+			strconv.Itoa(r.NbFailures),
+			strconv.Itoa(r.NbWrong),
+			formatFloat(r.FailureRate),
+			formatFloat(r.HopsMean),
+			strconv.Itoa(r.HopsP50),
+			strconv.Itoa(r.HopsP90),
+			strconv.Itoa(r.HopsP99),
+			strconv.Itoa(r.HopsMax),
+			formatFloat(r.ShareMin),
+			formatFloat(r.ShareMax),
+			formatFloat(r.ShareStdDev),
+			strconv.Itoa(r.Network.NbCalls),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteSharesCSV writes the key space share of each node of a report as CSV.
+func WriteSharesCSV(w io.Writer, report *Report) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{"node_id", "host", "share"})
+	if err != nil {
+		return err
+	}
+	for _, v := range report.Shares {
+		err = cw.Write([]string{v.NodeID, v.Host, formatFloat(v.Share)})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteJSON writes complete reports, including histograms and shares, as JSON.
+func WriteJSON(w io.Writer, reports []*Report) error {
+	buf, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+
+	return err
+}
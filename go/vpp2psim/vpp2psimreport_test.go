@@ -0,0 +1,95 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psim
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"strings"
+	"testing"
+)
+
+const (
+	testReportNbHosts        = 4
+	testReportNbNodesPerHost = 8
+	testReportNbRounds       = 10
+	testReportNbLookups      = 200
+)
+
+func TestReport(t *testing.T) {
+	var reports []*Report
+
+	config1 := vpp2pdat.DefaultRingConfig()
+	config2 := vpp2pdat.DefaultRingConfig()
+	config2.BruijnM = 4
+	config2.BruijnN = 128
+	config2.NbStep = 16
+	for i, config := range []*vpp2papi.RingConfig{config1, config2} {
+		report, err := RunReport(testSimSeed+int64(i), config, testReportNbHosts, testReportNbNodesPerHost, testReportNbRounds, testReportNbLookups)
+		if err != nil {
+			t.Fatal("unable to run report", err)
+		}
+		t.Logf("M=%d N=%d nodes=%d failures=%f hops mean=%f p90=%d max=%d share min=%f max=%f", config.BruijnM, config.BruijnN, report.NbNodes, report.FailureRate, report.HopsMean, report.HopsP90, report.HopsMax, report.ShareMin, report.ShareMax)
+		if report.NbNodes != testReportNbHosts*testReportNbNodesPerHost {
+			t.Errorf("bad number of nodes %d", report.NbNodes)
+		}
+		if report.FailureRate != 0 {
+			t.Errorf("lookups failed on a stable ring, rate=%f", report.FailureRate)
+		}
+		if report.HopsMean > 2*report.Log2NbNodes {
+			t.Errorf("too many hops %f, log2(n)=%f", report.HopsMean, report.Log2NbNodes)
+		}
+		total := 0.0
+		for _, v := range report.Shares {
+			total += v.Share
+		}
+		if total < 0.999 || total > 1.001 {
+			t.Errorf("shares do not cover the ring, total=%f", total)
+		}
+		reports = append(reports, report)
+	}
+
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, reports)
+	if err != nil {
+		t.Fatal("unable to write CSV", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(reports)+1 || !strings.HasPrefix(lines[0], "seed,bruijn_m") {
+		t.Error("bad CSV", buf.String())
+	}
+	buf.Reset()
+	err = WriteSharesCSV(&buf, reports[0])
+	if err != nil || strings.Count(buf.String(), "\n") != reports[0].NbNodes+1 {
+		t.Error("bad shares CSV", err)
+	}
+	buf.Reset()
+	err = WriteJSON(&buf, reports)
+	if err != nil {
+		t.Fatal("unable to write JSON", err)
+	}
+	var decoded []*Report
+	err = json.Unmarshal(buf.Bytes(), &decoded)
+	if err != nil || len(decoded) != len(reports) || decoded[1].Config.BruijnM != 4 {
+		t.Error("bad JSON", err)
+	}
+}
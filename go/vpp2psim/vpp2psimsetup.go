@@ -22,6 +22,7 @@ package vpp2psim
 import (
 	"fmt"
 	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
 	"github.com/ufoot/vapor/go/vpp2pdat"
 	"github.com/ufoot/vapor/go/vprand"
 	"github.com/ufoot/vapor/go/vpsum"
@@ -42,31 +43,42 @@ const (
 	MaxNbNodes = 1000000
 )
 
-// SetupLocal sets up a number of local hosts with some nodes per hosts.
-func SetupLocal(nbHosts, nbRings, nbNodesPerHostRing int, useSig bool) ([]*vpp2p.Host, []*vpp2p.Ring, []*vpp2p.Node, error) {
-	var err error
-	seed := vpsum.IntToStr32(vprand.Rand32(nil, 1000000000))
-
+func checkSetup(nbHosts, nbRings, nbNodesPerHostRing int) error {
 	if nbHosts < 1 || nbHosts > MaxNbHosts {
-		return nil, nil, nil, fmt.Errorf("Bad nbHosts=%d, range is [1,%d]", nbHosts, MaxNbHosts)
+		return fmt.Errorf("Bad nbHosts=%d, range is [1,%d]", nbHosts, MaxNbHosts)
 	}
 	if nbRings < 1 || nbRings > MaxNbRings {
-		return nil, nil, nil, fmt.Errorf("Bad nbRings=%d, range is [1,%d]", nbRings, MaxNbRings)
+		return fmt.Errorf("Bad nbRings=%d, range is [1,%d]", nbRings, MaxNbRings)
 	}
 	if nbNodesPerHostRing < 1 || nbNodesPerHostRing > MaxNbNodesPerHostRing {
-		return nil, nil, nil, fmt.Errorf("Bad nbNodesPerHostRing=%d, range is [1,%d]", nbNodesPerHostRing, MaxNbNodesPerHostRing)
+		return fmt.Errorf("Bad nbNodesPerHostRing=%d, range is [1,%d]", nbNodesPerHostRing, MaxNbNodesPerHostRing)
 	}
 	nbNodes := nbHosts * nbRings * nbNodesPerHostRing
 	if nbNodes < 1 || nbNodes > MaxNbNodes {
-		return nil, nil, nil, fmt.Errorf("Bad nbNodes=%d, range is [1,%d]", nbNodes, MaxNbNodes)
+		return fmt.Errorf("Bad nbNodes=%d, range is [1,%d]", nbNodes, MaxNbNodes)
+	}
+
+	return nil
+}
+
+// SetupLocal sets up a number of local hosts with some nodes per hosts,
+// within env.
+func SetupLocal(env *vpp2p.Env, nbHosts, nbRings, nbNodesPerHostRing int, useSig bool) ([]*vpp2p.Host, []*vpp2p.Ring, []*vpp2p.Node, error) {
+	var err error
+	seed := vpsum.IntToStr32(vprand.Rand32(nil, 1000000000))
+
+	err = checkSetup(nbHosts, nbRings, nbNodesPerHostRing)
+	if err != nil {
+		return nil, nil, nil, err
 	}
+	nbNodes := nbHosts * nbRings * nbNodesPerHostRing
 
 	hosts := make([]*vpp2p.Host, nbHosts)
 
 	for i := range hosts {
-		hosts[i], err = vpp2p.NewHost(fmt.Sprintf("Host %s/%d", seed, i),
+		hosts[i], err = vpp2p.NewHost(env, fmt.Sprintf("Host %s/%d", seed, i),
 			fmt.Sprintf("http://localhost:%04d/%s", 8080+i, seed),
-			useSig, vpp2p.GlobalHostInfoCatalog())
+			useSig)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -75,7 +87,7 @@ func SetupLocal(nbHosts, nbRings, nbNodesPerHostRing int, useSig bool) ([]*vpp2p
 	rings := make([]*vpp2p.Ring, nbRings)
 
 	for i := range rings {
-		rings[i], err = vpp2p.NewRing(hosts[0], fmt.Sprintf("Ring %s/%d", seed, i), fmt.Sprintf("Simulation ring %s/%d", seed, i), vpsum.Checksum128([]byte(fmt.Sprintf("%s/%d", seed, i))), vpp2pdat.DefaultRingConfig(), nil, nil)
+		rings[i], err = vpp2p.NewRing(env, hosts[0], fmt.Sprintf("Ring %s/%d", seed, i), fmt.Sprintf("Simulation ring %s/%d", seed, i), vpsum.Checksum128([]byte(fmt.Sprintf("%s/%d", seed, i))), vpp2pdat.DefaultRingConfig(), nil, nil)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -87,7 +99,7 @@ func SetupLocal(nbHosts, nbRings, nbNodesPerHostRing int, useSig bool) ([]*vpp2p
 	for _, v := range hosts {
 		for _, w := range rings {
 			for j := 0; j < nbNodesPerHostRing; j++ {
-				nodes[i], err = vpp2p.NewNode(v, w, nil, vpp2p.GlobalNodeCatalog())
+				nodes[i], err = vpp2p.NewNode(env, v, w, nil)
 				if err != nil {
 					return nil, nil, nil, err
 				}
@@ -98,3 +110,69 @@ func SetupLocal(nbHosts, nbRings, nbNodesPerHostRing int, useSig bool) ([]*vpp2p
 
 	return hosts, rings, nodes, nil
 }
+
+// SetupSim creates hosts, rings and nodes on a simulated network, and
+// makes the nodes join their rings, the first node of each ring, on
+// the first host, being used as a bootstrap. Everything is created
+// within the network env. Each join is followed by a stabilization
+// round, without moving the clock, but the rings are not fully stabilized
+// yet, this is done by calling network.Round a few times. If config
+// is nil, the default ring config is used.
+func SetupSim(network *Network, config *vpp2papi.RingConfig, nbHosts, nbRings, nbNodesPerHostRing int) ([]*vpp2p.Host, []*vpp2p.Ring, []*vpp2p.Node, error) {
+	var err error
+
+	err = checkSetup(nbHosts, nbRings, nbNodesPerHostRing)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	nbNodes := nbHosts * nbRings * nbNodesPerHostRing
+	if config == nil {
+		config = vpp2pdat.DefaultRingConfig()
+	}
+
+	hosts := make([]*vpp2p.Host, nbHosts)
+
+	for i := range hosts {
+		hosts[i], err = vpp2p.NewHost(network.env, fmt.Sprintf("Host %d/%d", network.seed, i),
+			fmt.Sprintf("http://localhost:%04d/sim/%d", 8080+i, network.seed),
+			false)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		hosts[i].SetClock(network.clock)
+	}
+
+	rings := make([]*vpp2p.Ring, nbRings)
+
+	for i := range rings {
+		rings[i], err = vpp2p.NewRing(network.env, hosts[0], fmt.Sprintf("Ring %d/%d", network.seed, i), fmt.Sprintf("Simulation ring %d/%d", network.seed, i), vpsum.Checksum128([]byte(fmt.Sprintf("%d/%d", network.seed, i))), config, nil, nil)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	nodes := make([]*vpp2p.Node, 0, nbNodes)
+
+	for _, v := range hosts {
+		for _, w := range rings {
+			for j := 0; j < nbNodesPerHostRing; j++ {
+				node, err := network.NewNode(v, w)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				if v == hosts[0] && j == 0 {
+					node.Start()
+				} else {
+					err = node.Join(hosts[0].Info.HostURL)
+					if err != nil {
+						return nil, nil, nil, err
+					}
+				}
+				nodes = append(nodes, node)
+				network.Round(nodes, 0)
+			}
+		}
+	}
+
+	return hosts, rings, nodes, nil
+}
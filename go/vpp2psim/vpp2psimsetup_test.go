@@ -20,6 +20,7 @@
 package vpp2psim
 
 import (
+	"github.com/ufoot/vapor/go/vpp2p"
 	"testing"
 )
 
@@ -33,7 +34,7 @@ const (
 )
 
 func testSetupLocal(t *testing.T, testNbHosts, testNbRings, testNbNodesPerHostRing int, useSig bool) {
-	hosts, rings, nodes, err := SetupLocal(testNbHosts, testNbRings, testNbNodesPerHostRing, useSig)
+	hosts, rings, nodes, err := SetupLocal(vpp2p.NewEnv(), testNbHosts, testNbRings, testNbNodesPerHostRing, useSig)
 
 	if err != nil {
 		t.Error("unable to set up env (sig)", err)
@@ -0,0 +1,40 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psrv
+
+// PackageTarname contains a short name of the package, suitable for a filename.
+const PackageTarname = "vapor" // PackageTarname set by version.sh
+// PackageName contains a readable name of the package, suitable for display.
+const PackageName = "Vapor Toolkit" // PackageName set by version.sh
+// PackageEmail contains a contact email for the package.
+const PackageEmail = "ufoot@ufoot.org" // PackageEmail set by version.sh
+// PackageURL contains the address of the project homepage.
+const PackageURL = "https://github.com/ufoot/vapor" // PackageURL set by version.sh
+// PackageCopyright contains a short copyright notice.
+const PackageCopyright = "Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>" // PackageCopyright set by version.sh
+// PackageLicense contains a short license information.
+const PackageLicense = "GNU GPL v3" // PackageLicense set by version.sh
+
+// VersionMajor is the project major version.
+const VersionMajor = 0 // VersionMajor set by version.sh
+// VersionMinor is the project minor version.
+const VersionMinor = 0 // VersionMinor set by version.sh
+// VersionStamp is the project stamp, possibly changes for each build.
+const VersionStamp = "0000000" // VersionStamp set by version.sh
@@ -0,0 +1,145 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psrv
+
+import (
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/ufoot/vapor/go/vperror"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// HTTPMaxRequestSize is the maximum size of a Thrift call
+	// posted to the HTTP handler.
+	HTTPMaxRequestSize = 16 * 1024 * 1024
+	// HTTPContentType is the content type of Thrift calls over HTTP.
+	HTTPContentType = "application/x-thrift"
+)
+
+// httpHandler serves the Thrift API of a host over HTTP, each call
+// being the body of a POST request, and each reply the body of
+// the response.
+type httpHandler struct {
+	host            *vpp2p.Host
+	processor       thrift.TProcessor
+	protocolFactory thrift.TProtocolFactory
+}
+
+// NewHTTPHandler returns an HTTP handler which serves the Thrift API
+// of host. Clients can reach it with vpp2p.DialHTTP.
+func NewHTTPHandler(host *vpp2p.Host) http.Handler {
+	return &httpHandler{host: host, processor: vpp2papi.NewVpP2pApiProcessor(host), protocolFactory: thrift.NewTBinaryProtocolFactoryDefault()}
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Thrift calls must be posted", http.StatusMethodNotAllowed)
+		return
+	}
+
+	in := thrift.NewStreamTransportR(http.MaxBytesReader(w, r.Body, HTTPMaxRequestSize))
+	out := thrift.NewTMemoryBuffer()
+	_, err := h.processor.Process(h.protocolFactory.GetProtocol(in), h.protocolFactory.GetProtocol(out))
+	if err != nil && out.Len() == 0 {
+		// the processor did not even manage to write an exception,
+		// typically because the request could not be read
+		vplog.LoggerDebug(h.host.Env().Logger(), "unable to process HTTP Thrift call", err)
+		http.Error(w, "unable to process Thrift call", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", HTTPContentType)
+	_, err = w.Write(out.Bytes())
+	if err != nil {
+		vplog.LoggerDebug(h.host.Env().Logger(), "unable to write HTTP Thrift reply", err)
+	}
+}
+
+// HTTPPath returns the path the Thrift API of host is served on,
+// this is the path of its URL, or / if there's none.
+func HTTPPath(host *vpp2p.Host) (string, error) {
+	parsedURL, err := url.Parse(host.Info.HostURL)
+	if err != nil {
+		return "", err
+	}
+	if parsedURL.Path == "" {
+		return "/", nil
+	}
+
+	return parsedURL.Path, nil
+}
+
+// NewHTTP creates an HTTP server for a given host. It listens on the
+// port given by the host URL, or vpp2papi.DefaultPort if there's none,
+// and serves the Thrift API on the path of the URL. Peers use HTTP
+// only for http and https URLs, so the host URL must use one of them.
+func NewHTTP(host *vpp2p.Host) (*http.Server, error) {
+	addr, err := vpp2pdat.HostURLToAddr(host.Info.HostURL)
+	if err != nil {
+		return nil, vperror.Chain(err, "unable to get address from host URL")
+	}
+
+	return NewHTTPAddr(host, addr)
+}
+
+// NewHTTPAddr creates an HTTP server for a given host, listening on addr.
+func NewHTTPAddr(host *vpp2p.Host, addr string) (*http.Server, error) {
+	path, err := HTTPPath(host)
+	if err != nil {
+		return nil, vperror.Chain(err, "unable to get path from host URL")
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, NewHTTPHandler(host))
+
+	vplog.LoggerNoticef(host.Env().Logger(), "New Thrift HTTP server on %s%s", addr, path)
+
+	return &http.Server{Addr: addr, Handler: mux}, nil
+}
+
+// AsyncServeHTTP starts listening, and serves HTTP requests in a
+// goroutine. Contrary to AsyncServe, an error is returned right away
+// if the server can't listen. Call server.Close to stop it.
+func AsyncServeHTTP(server *http.Server) error {
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		vplog.LogWarning("Unable to start Thrift HTTP server", err)
+		return vperror.Chainf(err, "unable to listen on %s", server.Addr)
+	}
+
+	go func() {
+		vplog.LogNoticef("Start Thrift HTTP server")
+
+		err := server.Serve(listener)
+		if err == nil || err == http.ErrServerClosed {
+			vplog.LogNotice("Done with Thrift HTTP server")
+		} else {
+			vplog.LogWarning("Thrift HTTP server stopped ", err)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,151 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psrv
+
+import (
+	"bytes"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testHTTPURL = "http://127.0.0.1:8779/vapor"
+const testHTTPSlowURL = "http://127.0.0.1:8781/slow"
+
+func TestHTTPServer(t *testing.T) {
+	var host *vpp2p.Host
+	var ring *vpp2p.Ring
+	var node *vpp2p.Node
+	var err error
+
+	env := vpp2p.NewEnv()
+	host, err = vpp2p.NewHost(env, testTitle, testHTTPURL, false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	ring, err = vpp2p.NewRing(env, host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	node, err = vpp2p.NewNode(env, host, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	node.Start()
+	defer node.Stop()
+
+	server, err := NewHTTP(host)
+	if err != nil {
+		t.Fatal("unable to create Thrift HTTP server", err)
+	}
+	err = AsyncServeHTTP(server)
+	if err != nil {
+		t.Fatal("unable to start Thrift HTTP server", err)
+	}
+	defer server.Close()
+
+	response, err := http.Get(testHTTPURL)
+	if err != nil {
+		t.Error("unable to get URL", err)
+	} else {
+		response.Body.Close()
+		if response.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("GET answered with status %d", response.StatusCode)
+		}
+	}
+
+	remoteHost, err := vpp2p.NewHTTPRemoteHost(&(host.Info), nil)
+	if err != nil {
+		t.Fatal("unable to create remote host", err)
+	}
+	defer remoteHost.Close()
+
+	for i := 0; i < 3; i++ {
+		// several calls, to check connections are re-used properly
+		err = remoteHost.Ping()
+		if err != nil {
+			t.Error("unable to ping remote host", err)
+		}
+	}
+	status, err := remoteHost.Status()
+	if err != nil {
+		t.Fatal("unable to get remote host status", err)
+	}
+	if status.ThisHostInfo.HostURL != testHTTPURL {
+		t.Errorf("bad host URL, got \"%s\", expected \"%s\"", status.ThisHostInfo.HostURL, testHTTPURL)
+	}
+	if len(status.LocalNodeStatus) != 1 || bytes.Compare(status.LocalNodeStatus[0].Info.NodeID, node.Status.Info.NodeID) != 0 {
+		t.Error("remote host does not report its local node")
+	}
+
+	// the pool picks HTTP from the URL scheme
+	hostInfoCatalog := vpp2p.NewHostInfoCatalog()
+	remoteHostPool := vpp2p.NewRemoteHostPool(hostInfoCatalog)
+	defer remoteHostPool.Close()
+	nodeCatalog := vpp2p.NewNodeCatalogWithRemotes(hostInfoCatalog, remoteHostPool)
+	hostInfoCatalog.RegisterHost(&(host.Info))
+	api, err := nodeCatalog.ConnectToNode(node.Status.Info)
+	if err != nil {
+		t.Fatal("unable to connect to node", err)
+	}
+	uptime, err := api.Uptime()
+	if err != nil {
+		t.Error("unable to get remote uptime", err)
+	}
+	t.Logf("remote uptime is %d", uptime)
+
+	_, err = vpp2p.DialHTTP("tcp://127.0.0.1:8779", 0)
+	if err == nil {
+		t.Error("dialed HTTP with a non-HTTP URL")
+	}
+}
+
+func TestDialHTTPTimeout(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2 * time.Second)
+	})
+	server := &http.Server{Addr: "127.0.0.1:8781", Handler: mux}
+	err := AsyncServeHTTP(server)
+	if err != nil {
+		t.Fatal("unable to start HTTP server", err)
+	}
+	defer server.Close()
+
+	transport, err := vpp2p.DialHTTP(testHTTPSlowURL, 100*time.Millisecond)
+	if err != nil {
+		t.Fatal("unable to dial HTTP", err)
+	}
+	defer transport.Close()
+	_, err = transport.Write([]byte("ping"))
+	if err != nil {
+		t.Fatal("unable to write request", err)
+	}
+	start := time.Now()
+	err = transport.Flush()
+	if err == nil {
+		t.Error("slow call did not time out")
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("call timed out after %s", time.Since(start))
+	}
+}
@@ -0,0 +1,96 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psrv
+
+import (
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/ufoot/vapor/go/vperror"
+	"github.com/ufoot/vapor/go/vplog"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"time"
+)
+
+func newServer(host *vpp2p.Host, transportFactory thrift.TTransportFactory, protocolFactory thrift.TProtocolFactory, addr string) (*thrift.TSimpleServer, error) {
+	var transport thrift.TServerTransport
+	var err error
+
+	transport, err = thrift.NewTServerSocket(addr)
+
+	if err != nil {
+		return nil, vperror.Chain(err, "unable to create server socket")
+	}
+	logger := host.Env().Logger()
+	vplog.LoggerNoticef(logger, "%T", transport)
+	processor := vpp2papi.NewVpP2pApiProcessor(host)
+	server := thrift.NewTSimpleServer4(processor, transport, transportFactory, protocolFactory)
+
+	vplog.LoggerNoticef(logger, "New Thrift server on %s", addr)
+
+	return server, nil
+}
+
+// New creates a server for a given host. It listens on the port
+// given by the host URL, or vpp2papi.DefaultPort if there's none.
+// Peers pick raw Thrift sockets for URLs which are not http or https,
+// so the host URL would typically use the tcp scheme.
+func New(host *vpp2p.Host) (*thrift.TSimpleServer, error) {
+	addr, err := vpp2pdat.HostURLToAddr(host.Info.HostURL)
+	if err != nil {
+		return nil, vperror.Chain(err, "unable to get address from host URL")
+	}
+
+	return NewAddr(host, addr)
+}
+
+// NewAddr creates a server for a given host, listening on addr.
+func NewAddr(host *vpp2p.Host, addr string) (*thrift.TSimpleServer, error) {
+	return newServer(host, thrift.NewTTransportFactory(), thrift.NewTBinaryProtocolFactoryDefault(), addr)
+}
+
+// AsyncServe calls Serve in a goroutine.
+func AsyncServe(server *thrift.TSimpleServer) error {
+	var err error
+
+	start := time.Now()
+	go func() {
+		vplog.LogNoticef("Start Thrift server")
+
+		err = server.Serve()
+		if err == nil {
+			vplog.LogNotice("Done with Thrift server")
+		} else {
+			vplog.LogWarning("unable to start Thrift server ", err)
+		}
+	}()
+
+	for start.Unix()+2 > time.Now().Unix() && err == nil {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err == nil {
+		vplog.LogNoticef("Started Thrift server")
+	} else {
+		vplog.LogWarning("Unable to start Thrift server", err)
+	}
+
+	return err
+}
@@ -0,0 +1,181 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2psrv
+
+import (
+	"bytes"
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/ufoot/vapor/go/vpapp"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testTitle = "This is a title"
+const testDescription = "This is a description"
+const testURL = "tcp://127.0.0.1:8778"
+
+var testID = []byte("01234567890123456789012345678901")
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func TestServer(t *testing.T) {
+	var server *thrift.TSimpleServer
+	var host *vpp2p.Host
+	var ring *vpp2p.Ring
+	var node *vpp2p.Node
+	var err error
+
+	env := vpp2p.NewEnv()
+	host, err = vpp2p.NewHost(env, testTitle, testURL, false)
+	if err != nil {
+		t.Fatal("unable to create host", err)
+	}
+	ring, err = vpp2p.NewRing(env, host, testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+	if err != nil {
+		t.Fatal("unable to create ring", err)
+	}
+	node, err = vpp2p.NewNode(env, host, ring, nil)
+	if err != nil {
+		t.Fatal("unable to create node", err)
+	}
+	node.Start()
+	defer node.Stop()
+
+	server, err = New(host)
+	if err != nil {
+		t.Fatal("unable to create Thrift server", err)
+	}
+	err = AsyncServe(server)
+	if err != nil {
+		t.Fatal("unable to start Thrift server", err)
+	}
+	defer server.Stop()
+
+	remoteHost, err := vpp2p.NewRemoteHost(testURL, nil)
+	if err != nil {
+		t.Fatal("unable to create remote host", err)
+	}
+	defer remoteHost.Close()
+
+	err = remoteHost.Ping()
+	if err != nil {
+		t.Error("unable to ping remote host", err)
+	}
+	status, err := remoteHost.Status()
+	if err != nil {
+		t.Fatal("unable to get remote host status", err)
+	}
+	if status.ThisHostInfo.HostURL != testURL {
+		t.Errorf("bad host URL, got \"%s\", expected \"%s\"", status.ThisHostInfo.HostURL, testURL)
+	}
+	if len(status.LocalNodeStatus) != 1 || bytes.Compare(status.LocalNodeStatus[0].Info.NodeID, node.Status.Info.NodeID) != 0 {
+		t.Error("remote host does not report its local node")
+	}
+	if remoteHost.Protocol() != vpp2pdat.MaxProtocol || !remoteHost.HasCapability(vpp2pdat.CapabilityPubSub) {
+		t.Error("bad handshake", remoteHost.Protocol(), remoteHost.Capabilities())
+	}
+
+	nbDials := 0
+	dialer := func(hostURL string, timeout time.Duration) (thrift.TTransport, error) {
+		nbDials++
+		return vpp2p.DialTCP(hostURL, timeout)
+	}
+	otherHost, err := vpp2p.NewRemoteHostDialer(testURL, nil, dialer)
+	if err != nil {
+		t.Fatal("unable to create remote host", err)
+	}
+	defer otherHost.Close()
+	clock := &testClock{now: time.Now()}
+	otherHost.SetClock(clock)
+	otherInfo := vpp2pdat.DefaultHandshakeInfo()
+	otherInfo.Package = vpapp.NewPackage("other", "Other", "", "", "", "")
+	otherHost.SetHandshakeInfo(otherInfo)
+	for i := 0; i < 2; i++ {
+		err = otherHost.Ping()
+		if !vpp2pdat.IsIncompatible(err) {
+			t.Error("incompatible host not refused", err)
+		}
+	}
+	if nbDials != 1 {
+		t.Errorf("incompatible host contacted again, %d dials", nbDials)
+	}
+	// the host might have been upgraded, try again later
+	clock.now = clock.now.Add(2 * vpp2p.RemoteHostIncompatibleDelay)
+	err = otherHost.Ping()
+	if !vpp2pdat.IsIncompatible(err) {
+		t.Error("incompatible host not refused", err)
+	}
+	if nbDials != 2 {
+		t.Errorf("handshake not tried again after delay, %d dials", nbDials)
+	}
+	otherHost.SetHandshakeInfo(nil)
+	err = otherHost.Ping()
+	if err != nil {
+		t.Error("unable to ping remote host after restoring handshake info", err)
+	}
+
+	hostInfoCatalog := vpp2p.NewHostInfoCatalog()
+	remoteHostPool := vpp2p.NewRemoteHostPool(hostInfoCatalog)
+	defer remoteHostPool.Close()
+	nodeCatalog := vpp2p.NewNodeCatalogWithRemotes(hostInfoCatalog, remoteHostPool)
+	_, err = nodeCatalog.ConnectToNode(node.Status.Info)
+	if err == nil {
+		t.Error("connected to a node whose host is unknown")
+	}
+	hostInfoCatalog.RegisterHost(&(host.Info))
+	api, err := nodeCatalog.ConnectToNode(node.Status.Info)
+	if err != nil {
+		t.Fatal("unable to connect to node", err)
+	}
+	uptime, err := api.Uptime()
+	if err != nil {
+		t.Error("unable to get remote uptime", err)
+	}
+	t.Logf("remote uptime is %d", uptime)
+
+	// a remote caller claiming to be the host itself is still limited
+	err = host.Limiter().SetConfig(&vpp2p.LimiterConfig{Rate: 0.001, Burst: 1, MaxConcurrentCalls: 10, MaxSources: 10})
+	if err != nil {
+		t.Fatal("unable to set limits", err)
+	}
+	for i := 0; i < 2; i++ {
+		context := vpp2papi.NewContextInfo()
+		context.SourceHost = &(host.Info)
+		context.SourceRing = &(ring.Info)
+		context.SourceNode = node.Status.Info
+		context.TargetNodeID = node.Status.Info.NodeID
+		context.Timestamp = time.Now().Unix()
+		context.Nonce = []byte{byte(i), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+		_, err = remoteHost.GetSuccessors(&vpp2papi.GetSuccessorsRequest{Context: context})
+	}
+	if err == nil || !strings.Contains(err.Error(), "host overloaded") {
+		t.Error("forged local call not limited", err)
+	}
+}
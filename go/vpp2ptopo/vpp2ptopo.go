@@ -0,0 +1,359 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2ptopo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpbruijn"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"sort"
+)
+
+const (
+	// IssueNoSuccessor is reported when a node has no successor.
+	IssueNoSuccessor = "no-successor"
+	// IssueNoPredecessor is reported when a node has no predecessor.
+	IssueNoPredecessor = "no-predecessor"
+	// IssueAsymmetric is reported when the successor of a node does
+	// not have it as a predecessor, or the other way round.
+	IssueAsymmetric = "asymmetric"
+	// IssueSkipped is reported when a known node lies between
+	// a node and its successor.
+	IssueSkipped = "skipped"
+	// IssueWrongD is reported when the D pointer of a node is not
+	// the node just before the first Bruijn node it points to.
+	IssueWrongD = "wrong-d"
+	// IssueUnreached is reported when a node is referenced by others,
+	// but its host could not be contacted.
+	IssueUnreached = "unreached"
+	// IssueStale is reported when a node is referenced by others,
+	// but its host, which could be contacted, does not run it.
+	IssueStale = "stale"
+)
+
+// Issue is an inconsistency found on a ring.
+type Issue struct {
+	// Type is one of the Issue... constants.
+	Type string
+	// NodeID is the hex ID of the node the issue is about.
+	NodeID string
+	// PeerID is the hex ID of the other node involved, if any.
+	PeerID string
+	// Message is a human readable description of the issue.
+	Message string
+}
+
+// Node is a node found while crawling. Nodes which are only
+// referenced by others, but whose status could not be obtained,
+// are not reached, and have no peers.
+type Node struct {
+	// NodeID is the hex ID of the node.
+	NodeID string
+	// HostURL is the URL of the host of the node, if known.
+	HostURL string
+	// RingPos is the position of the node on the ring, between 0 and 1.
+	RingPos float64
+	// Reached tells wether the node status has been obtained.
+	Reached bool
+	// Successors are the hex IDs of the successors of the node.
+	Successors []string
+	// Predecessor is the hex ID of the predecessor of the node.
+	Predecessor string
+	// D is the hex ID of the D pointer of the node.
+	D string
+
+	id []byte
+}
+
+// Ring is a ring, rebuilt from the nodes found while crawling.
+type Ring struct {
+	// RingID is the hex ID of the ring.
+	RingID string
+	// RingTitle is the title of the ring, if known.
+	RingTitle string
+	// Nodes are all the nodes found on the ring, sorted by ID.
+	Nodes []*Node
+	// Issues are the inconsistencies found on the ring.
+	Issues []Issue
+
+	id     []byte
+	walker vpbruijn.BruijnWalker
+	nodes  map[string]*Node
+}
+
+// Host is a host found while crawling.
+type Host struct {
+	// HostURL is the URL of the host.
+	HostURL string
+	// HostTitle is the title of the host, if it could be contacted.
+	HostTitle string
+	// NbNodes is the number of nodes run by the host.
+	NbNodes int
+	// Error is why the host could not be contacted, empty if it could.
+	Error string
+}
+
+// Topology is the result of a crawl.
+type Topology struct {
+	// Hosts are the hosts found, in the order they were crawled.
+	Hosts []*Host
+	// Rings are the rings found, sorted by ID.
+	Rings []*Ring
+}
+
+// nodeList sorts nodes by ID.
+type nodeList []*Node
+
+func (l nodeList) Len() int {
+	return len(l)
+}
+
+func (l nodeList) Less(i, j int) bool {
+	return bytes.Compare(l[i].id, l[j].id) < 0
+}
+
+func (l nodeList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+// ringList sorts rings by ID.
+type ringList []*Ring
+
+func (l ringList) Len() int {
+	return len(l)
+}
+
+func (l ringList) Less(i, j int) bool {
+	return bytes.Compare(l[i].id, l[j].id) < 0
+}
+
+func (l ringList) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+// builder accumulates host statuses, and turns them into a topology.
+type builder struct {
+	hosts       []*Host
+	reachedURLs map[string]bool
+	rings       map[string]*Ring
+	ringInfos   map[string]*vpp2papi.RingInfo
+}
+
+func newBuilder() *builder {
+	return &builder{reachedURLs: make(map[string]bool), rings: make(map[string]*Ring), ringInfos: make(map[string]*vpp2papi.RingInfo)}
+}
+
+func (b *builder) ring(ringID []byte) *Ring {
+	key := hex.EncodeToString(ringID)
+	ret := b.rings[key]
+	if ret == nil {
+		ret = &Ring{RingID: key, id: ringID, nodes: make(map[string]*Node)}
+		b.rings[key] = ret
+	}
+
+	return ret
+}
+
+// node returns the node on ring, creating it if needed. The URL
+// of its host is searched in hostsRefs.
+func (b *builder) node(ring *Ring, info *vpp2papi.NodeInfo, hostsRefs map[string]*vpp2papi.HostInfo) *Node {
+	key := hex.EncodeToString(info.NodeID)
+	ret := ring.nodes[key]
+	if ret == nil {
+		ret = &Node{NodeID: key, id: info.NodeID}
+		ring.nodes[key] = ret
+	}
+	if ret.HostURL == "" && hostsRefs != nil {
+		hostInfo := hostsRefs[vpp2pdat.HostPubKeyToShortString(info.HostPubKey)]
+		if hostInfo != nil {
+			ret.HostURL = hostInfo.HostURL
+		}
+	}
+
+	return ret
+}
+
+func (b *builder) addError(hostURL string, err error) {
+	b.hosts = append(b.hosts, &Host{HostURL: hostURL, Error: err.Error()})
+}
+
+func (b *builder) addStatus(status *vpp2papi.HostStatus) {
+	host := &Host{NbNodes: len(status.LocalNodeStatus)}
+	if status.ThisHostInfo != nil {
+		host.HostURL = status.ThisHostInfo.HostURL
+		host.HostTitle = status.ThisHostInfo.HostTitle
+		b.reachedURLs[host.HostURL] = true
+	}
+	b.hosts = append(b.hosts, host)
+	for k, v := range status.RingsRefs {
+		b.ringInfos[k] = v
+	}
+
+	for _, nodeStatus := range status.LocalNodeStatus {
+		if nodeStatus == nil || nodeStatus.Info == nil {
+			continue
+		}
+		// peers are considered to be on the same ring as the node,
+		// whatever they say, so that links always stay within a ring
+		ring := b.ring(nodeStatus.Info.RingID)
+		node := b.node(ring, nodeStatus.Info, status.HostsRefs)
+		node.HostURL = host.HostURL
+		node.Reached = true
+		if nodeStatus.Peers != nil {
+			node.Successors = make([]string, 0, len(nodeStatus.Peers.Successors))
+			for _, successor := range nodeStatus.Peers.Successors {
+				if successor != nil {
+					node.Successors = append(node.Successors, b.node(ring, successor, status.HostsRefs).NodeID)
+				}
+			}
+			if nodeStatus.Peers.D != nil {
+				node.D = b.node(ring, nodeStatus.Peers.D, status.HostsRefs).NodeID
+			}
+		}
+		if nodeStatus.Predecessor != nil {
+			node.Predecessor = b.node(ring, nodeStatus.Predecessor, status.HostsRefs).NodeID
+		}
+	}
+}
+
+func (b *builder) topology() *Topology {
+	ret := &Topology{Hosts: b.hosts, Rings: make([]*Ring, 0, len(b.rings))}
+
+	for _, ring := range b.rings {
+		config := vpp2pdat.DefaultRingConfig()
+		info := b.ringInfos[vpp2pdat.RingIDToShortString(ring.id)]
+		if info != nil {
+			ring.RingTitle = info.RingTitle
+			if info.Config != nil {
+				config = info.Config
+			}
+		}
+		walker, err := vpbruijn.BruijnNew(int(config.BruijnM), int(config.BruijnN))
+		if err != nil {
+			walker, _ = vpbruijn.BruijnNew(int(vpp2pdat.DefaultRingConfig().BruijnM), int(vpp2pdat.DefaultRingConfig().BruijnN))
+		}
+		ring.walker = walker
+		ring.Nodes = make([]*Node, 0, len(ring.nodes))
+		for _, node := range ring.nodes {
+			node.RingPos = walker.RingPos(node.id)
+			ring.Nodes = append(ring.Nodes, node)
+		}
+		sort.Sort(nodeList(ring.Nodes))
+		ring.check(b.reachedURLs)
+		ret.Rings = append(ret.Rings, ring)
+	}
+	sort.Sort(ringList(ret.Rings))
+
+	return ret
+}
+
+// Build rebuilds a topology from host statuses, typically obtained by
+// calling Status on all the hosts of a ring.
+func Build(statuses []*vpp2papi.HostStatus) *Topology {
+	b := newBuilder()
+	for _, status := range statuses {
+		if status != nil {
+			b.addStatus(status)
+		}
+	}
+
+	return b.topology()
+}
+
+func (r *Ring) addIssue(issueType string, node *Node, peerID string, format string, a ...interface{}) {
+	r.Issues = append(r.Issues, Issue{Type: issueType, NodeID: node.NodeID, PeerID: peerID, Message: fmt.Sprintf(format, a...)})
+}
+
+// shortID returns a short readable representation of a hex node ID.
+func shortID(nodeID string) string {
+	id, err := hex.DecodeString(nodeID)
+	if err != nil {
+		return nodeID
+	}
+
+	return vpp2pdat.NodeIDToShortString(id)
+}
+
+// check looks for inconsistencies between nodes. Since nodes are
+// sorted by ID, the expected successor of a node is the next one in
+// the list, and its expected D is the last node before the first
+// Bruijn node it points to.
+func (r *Ring) check(reachedURLs map[string]bool) {
+	n := len(r.Nodes)
+	for i, node := range r.Nodes {
+		if !node.Reached {
+			if reachedURLs[node.HostURL] {
+				r.addIssue(IssueStale, node, "", "node %s is referenced, but its host %s does not run it", shortID(node.NodeID), node.HostURL)
+			} else {
+				r.addIssue(IssueUnreached, node, "", "node %s is referenced, but its host \"%s\" could not be contacted", shortID(node.NodeID), node.HostURL)
+			}
+			continue
+		}
+
+		if len(node.Successors) == 0 {
+			r.addIssue(IssueNoSuccessor, node, "", "node %s has no successor", shortID(node.NodeID))
+		} else {
+			successor := r.nodes[node.Successors[0]]
+			next := r.Nodes[(i+1)%n]
+			if successor != next {
+				r.addIssue(IssueSkipped, node, next.NodeID, "node %s has successor %s, but %s is in between", shortID(node.NodeID), shortID(successor.NodeID), shortID(next.NodeID))
+			}
+			if successor.Reached && successor.Predecessor != node.NodeID {
+				r.addIssue(IssueAsymmetric, node, successor.NodeID, "node %s has successor %s, whose predecessor is %s", shortID(node.NodeID), shortID(successor.NodeID), shortID(successor.Predecessor))
+			}
+		}
+
+		if node.Predecessor == "" {
+			r.addIssue(IssueNoPredecessor, node, "", "node %s has no predecessor", shortID(node.NodeID))
+		} else {
+			predecessor := r.nodes[node.Predecessor]
+			if predecessor.Reached && (len(predecessor.Successors) == 0 || predecessor.Successors[0] != node.NodeID) {
+				r.addIssue(IssueAsymmetric, node, predecessor.NodeID, "node %s has predecessor %s, which does not have it as a successor", shortID(node.NodeID), shortID(predecessor.NodeID))
+			}
+		}
+
+		if node.D != "" {
+			target := r.walker.NextFirst(node.id)
+			expected := r.Nodes[0]
+			for j := range r.Nodes {
+				if n == 1 || r.walker.GeLt(target, r.Nodes[j].id, r.Nodes[(j+1)%n].id) {
+					expected = r.Nodes[j]
+					break
+				}
+			}
+			if node.D != expected.NodeID {
+				r.addIssue(IssueWrongD, node, expected.NodeID, "node %s has D %s, but %s is closer to %s", shortID(node.NodeID), shortID(node.D), shortID(expected.NodeID), vpp2pdat.NodeIDToShortString(target))
+			}
+		}
+	}
+}
+
+// NbIssues returns the number of issues found on all rings.
+func (t *Topology) NbIssues() int {
+	ret := 0
+	for _, ring := range t.Rings {
+		ret += len(ring.Issues)
+	}
+
+	return ret
+}
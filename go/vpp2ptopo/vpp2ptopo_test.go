@@ -0,0 +1,207 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2ptopo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"github.com/ufoot/vapor/go/vpp2pdat"
+	"strings"
+	"testing"
+)
+
+const testTitle = "This is a title"
+const testDescription = "This is a description"
+const testURL = "http://127.0.0.1:8780/topo"
+
+var testID = []byte("01234567890123456789012345678901")
+
+func testStabilize(nodes []*vpp2p.Node, nbRounds int) {
+	for i := 0; i < nbRounds; i++ {
+		for _, node := range nodes {
+			node.Stabilize()
+		}
+	}
+}
+
+func setupRing(t *testing.T, nbHosts, nbNodesPerHost int) (*vpp2p.Env, []*vpp2p.Host, []*vpp2p.Node) {
+	env := vpp2p.NewEnv()
+	hosts := make([]*vpp2p.Host, nbHosts)
+	nodes := make([]*vpp2p.Node, 0, nbHosts*nbNodesPerHost)
+	var ring *vpp2p.Ring
+	var err error
+
+	for i := range hosts {
+		hosts[i], err = vpp2p.NewHost(env, testTitle, fmt.Sprintf("%s/%d", testURL, i), false)
+		if err != nil {
+			t.Fatal("unable to create host", err)
+		}
+		if ring == nil {
+			ring, err = vpp2p.NewRing(env, hosts[i], testTitle, testDescription, testID, vpp2pdat.DefaultRingConfig(), nil, nil)
+			if err != nil {
+				t.Fatal("unable to create ring", err)
+			}
+		}
+		for j := 0; j < nbNodesPerHost; j++ {
+			node, err := vpp2p.NewNode(env, hosts[i], ring, nil)
+			if err != nil {
+				t.Fatal("unable to create node", err)
+			}
+			if len(nodes) == 0 {
+				node.Start()
+			} else {
+				err = node.Join(hosts[0].Info.HostURL)
+				if err != nil {
+					t.Fatal("unable to join", err)
+				}
+			}
+			nodes = append(nodes, node)
+			testStabilize(nodes, 1)
+		}
+	}
+	testStabilize(nodes, 10)
+
+	return env, hosts, nodes
+}
+
+func TestCrawl(t *testing.T) {
+	const nbHosts = 4
+	const nbNodesPerHost = 3
+
+	env, hosts, nodes := setupRing(t, nbHosts, nbNodesPerHost)
+	for _, node := range nodes {
+		defer node.Stop()
+	}
+
+	_, err := Crawl(testURL+"/nobody", env.NodeCatalog().ConnectToHost, DefaultMaxHosts)
+	if err == nil {
+		t.Error("crawled from a host which does not exist")
+	}
+	topology, err := Crawl(hosts[nbHosts-1].Info.HostURL, env.NodeCatalog().ConnectToHost, DefaultMaxHosts)
+	if err != nil {
+		t.Fatal("unable to crawl", err)
+	}
+	if len(topology.Hosts) != nbHosts {
+		t.Errorf("bad number of hosts %d, expected %d", len(topology.Hosts), nbHosts)
+	}
+	if len(topology.Rings) != 1 {
+		t.Fatalf("bad number of rings %d", len(topology.Rings))
+	}
+	ring := topology.Rings[0]
+	if ring.RingTitle != testTitle {
+		t.Errorf("bad ring title \"%s\"", ring.RingTitle)
+	}
+	if len(ring.Nodes) != len(nodes) {
+		t.Errorf("bad number of nodes %d, expected %d", len(ring.Nodes), len(nodes))
+	}
+	for i, node := range ring.Nodes {
+		if !node.Reached {
+			t.Errorf("node %s not reached", node.NodeID)
+		}
+		if i > 0 && node.RingPos <= ring.Nodes[i-1].RingPos {
+			t.Errorf("nodes not sorted, %f after %f", node.RingPos, ring.Nodes[i-1].RingPos)
+		}
+	}
+	for _, issue := range ring.Issues {
+		t.Errorf("issue on stabilized ring %s: %s", issue.Type, issue.Message)
+	}
+
+	topology, err = Crawl(hosts[0].Info.HostURL, env.NodeCatalog().ConnectToHost, 1)
+	if err != nil {
+		t.Fatal("unable to crawl", err)
+	}
+	if len(topology.Hosts) != 1 || topology.NbIssues() == 0 {
+		t.Errorf("crawling only one host gave %d hosts and no issues", len(topology.Hosts))
+	}
+}
+
+func TestBuildIssues(t *testing.T) {
+	const nbHosts = 3
+	const nbNodesPerHost = 2
+
+	_, hosts, nodes := setupRing(t, nbHosts, nbNodesPerHost)
+	for _, node := range nodes {
+		defer node.Stop()
+	}
+
+	statuses := make([]*vpp2papi.HostStatus, nbHosts)
+	for i, host := range hosts {
+		var err error
+		statuses[i], err = host.Status()
+		if err != nil {
+			t.Fatal("unable to get status", err)
+		}
+	}
+	if Build(statuses).NbIssues() != 0 {
+		t.Error("issues on a stabilized ring")
+	}
+
+	// break a link, the node now says it is its own predecessor
+	broken := statuses[0].LocalNodeStatus[0]
+	broken.Predecessor = broken.Info
+	topology := Build(statuses)
+	found := false
+	for _, issue := range topology.Rings[0].Issues {
+		t.Logf("%s: %s", issue.Type, issue.Message)
+		if issue.Type == IssueAsymmetric {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("asymmetric link not detected")
+	}
+
+	// forget about a host, its nodes are not reached any more
+	topology = Build(statuses[1:])
+	nbUnreached := 0
+	for _, issue := range topology.Rings[0].Issues {
+		if issue.Type == IssueUnreached {
+			nbUnreached++
+		}
+	}
+	if nbUnreached == 0 || nbUnreached > nbNodesPerHost {
+		t.Errorf("bad number of unreached nodes %d", nbUnreached)
+	}
+
+	var buf bytes.Buffer
+	err := topology.WriteDOT(&buf)
+	if err != nil {
+		t.Error("unable to write DOT", err)
+	}
+	if !strings.HasPrefix(buf.String(), "digraph") || !strings.Contains(buf.String(), IssueUnreached) {
+		t.Error("bad DOT output", buf.String())
+	}
+	buf.Reset()
+	err = topology.WriteJSON(&buf)
+	if err != nil {
+		t.Error("unable to write JSON", err)
+	}
+	var decoded Topology
+	err = json.Unmarshal(buf.Bytes(), &decoded)
+	if err != nil {
+		t.Error("unable to decode JSON", err)
+	}
+	if len(decoded.Rings) != 1 || len(decoded.Rings[0].Nodes) != len(topology.Rings[0].Nodes) {
+		t.Error("JSON output does not contain all nodes")
+	}
+}
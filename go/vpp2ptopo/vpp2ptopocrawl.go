@@ -0,0 +1,99 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2ptopo
+
+import (
+	"fmt"
+	"github.com/ufoot/vapor/go/vperror"
+	"github.com/ufoot/vapor/go/vpp2p"
+	"github.com/ufoot/vapor/go/vpp2papi"
+	"sort"
+)
+
+const (
+	// DefaultMaxHosts is the default maximum number of hosts crawled.
+	DefaultMaxHosts = 1000
+)
+
+// Connector returns a handler to make API calls on the host with
+// the given URL. NodeCatalog.ConnectToHost is a valid connector.
+type Connector func(hostURL string) (vpp2papi.VpP2pApi, error)
+
+// PoolConnector returns a connector which contacts hosts over the
+// network, using the connections of pool.
+func PoolConnector(pool *vpp2p.RemoteHostPool) Connector {
+	return func(hostURL string) (vpp2papi.VpP2pApi, error) {
+		return pool.Connect(hostURL)
+	}
+}
+
+// Crawl rebuilds the topology of the rings startURL is on. It calls
+// Status on the host with the given URL, then on the hosts of all the
+// successors, predecessors and D pointers it reports, and so on, until
+// there are no new hosts, or maxHosts hosts have been contacted. Hosts
+// which can't be contacted are reported in the topology, and their nodes
+// flagged as unreached, only failing to contact the first host is an error.
+func Crawl(startURL string, connect Connector, maxHosts int) (*Topology, error) {
+	if maxHosts < 1 {
+		return nil, fmt.Errorf("bad maxHosts=%d, should be at least 1", maxHosts)
+	}
+
+	b := newBuilder()
+	queue := []string{startURL}
+	queued := map[string]bool{startURL: true}
+	for len(queue) > 0 && len(b.hosts) < maxHosts {
+		hostURL := queue[0]
+		queue = queue[1:]
+
+		var status *vpp2papi.HostStatus
+		api, err := connect(hostURL)
+		if err == nil {
+			status, err = api.Status()
+		}
+		if err == nil && status == nil {
+			err = fmt.Errorf("no status")
+		}
+		if err != nil {
+			if len(b.hosts) == 0 {
+				return nil, vperror.Chainf(err, "unable to get status of %s", hostURL)
+			}
+			b.addError(hostURL, err)
+			continue
+		}
+		if status.ThisHostInfo != nil {
+			queued[status.ThisHostInfo.HostURL] = true
+		}
+		b.addStatus(status)
+
+		// hosts refs contain the hosts of all the peers reported,
+		// sort them so that crawling is always done in the same order
+		urls := make([]string, 0, len(status.HostsRefs))
+		for _, hostInfo := range status.HostsRefs {
+			if hostInfo != nil && !queued[hostInfo.HostURL] {
+				urls = append(urls, hostInfo.HostURL)
+				queued[hostInfo.HostURL] = true
+			}
+		}
+		sort.Strings(urls)
+		queue = append(queue, urls...)
+	}
+
+	return b.topology(), nil
+}
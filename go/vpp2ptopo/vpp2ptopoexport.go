@@ -0,0 +1,96 @@
+// Vapor is a toolkit designed to support Liquid War 7.
+// Copyright (C)  2015, 2016  Christian Mauduit <ufoot@ufoot.org>
+//
+// This program is free software; you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+//
+// Vapor homepage: https://github.com/ufoot/vapor
+// Contact author: ufoot@ufoot.org
+
+package vpp2ptopo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// dotID returns the Graphviz ID of a node, the ring ID is included
+// so that IDs stay unique when several rings are drawn.
+func dotID(ring *Ring, nodeID string) string {
+	return fmt.Sprintf("%q", ring.RingID+"/"+nodeID)
+}
+
+// WriteDOT writes the topology as a Graphviz DOT graph, with one cluster
+// per ring. Each node is labelled with its short ID, its RingPos and the
+// URL of its host. Plain edges point to the first successor, dashed ones
+// to the predecessor and dotted ones to D. Nodes which could not be reached
+// are dashed, nodes involved in an issue are red, and issues are listed
+// as comments.
+func (t *Topology) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "digraph vapor {\n")
+	fmt.Fprintf(bw, "\tnode [fontsize=10];\n")
+	fmt.Fprintf(bw, "\tedge [fontsize=8];\n")
+	for i, ring := range t.Rings {
+		withIssue := make(map[string]bool)
+		for _, issue := range ring.Issues {
+			withIssue[issue.NodeID] = true
+		}
+
+		fmt.Fprintf(bw, "\tsubgraph cluster_%d {\n", i)
+		fmt.Fprintf(bw, "\t\tlabel=%q;\n", fmt.Sprintf("%s (%s)", ring.RingTitle, shortID(ring.RingID)))
+		for _, issue := range ring.Issues {
+			fmt.Fprintf(bw, "\t\t// %s: %s\n", issue.Type, issue.Message)
+		}
+		for _, node := range ring.Nodes {
+			attrs := ""
+			if !node.Reached {
+				attrs += ", style=dashed"
+			}
+			if withIssue[node.NodeID] {
+				attrs += ", color=red"
+			}
+			fmt.Fprintf(bw, "\t\t%s [label=%q%s];\n", dotID(ring, node.NodeID), fmt.Sprintf("%s\n%.6f\n%s", shortID(node.NodeID), node.RingPos, node.HostURL), attrs)
+		}
+		for _, node := range ring.Nodes {
+			if len(node.Successors) > 0 {
+				fmt.Fprintf(bw, "\t\t%s -> %s [label=\"successor\"];\n", dotID(ring, node.NodeID), dotID(ring, node.Successors[0]))
+			}
+			if node.Predecessor != "" {
+				fmt.Fprintf(bw, "\t\t%s -> %s [label=\"predecessor\", style=dashed, color=blue];\n", dotID(ring, node.NodeID), dotID(ring, node.Predecessor))
+			}
+			if node.D != "" {
+				fmt.Fprintf(bw, "\t\t%s -> %s [label=\"D\", style=dotted, color=gray];\n", dotID(ring, node.NodeID), dotID(ring, node.D))
+			}
+		}
+		fmt.Fprintf(bw, "\t}\n")
+	}
+	fmt.Fprintf(bw, "}\n")
+
+	return bw.Flush()
+}
+
+// WriteJSON writes the complete topology as JSON, including hosts,
+// nodes with their RingPos and peers, and issues.
+func (t *Topology) WriteJSON(w io.Writer) error {
+	buf, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+
+	return err
+}
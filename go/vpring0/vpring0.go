@@ -29,7 +29,7 @@ import (
 // can typically be copy/pasted into the code to immortalize the
 // reference instance of that ring.
 func Base64Ring0() (string, string, string, string, error) {
-	ring0, err := vpp2p.NewRing0()
+	ring0, err := vpp2p.NewRing0(vpp2p.NewEnv())
 
 	if err != nil {
 		return "", "", "", "", err
@@ -58,24 +58,27 @@ struct NodeInfo {
 
 /**
  * NodePeers contains informations about node peers,
- * those it should contact.
+ * those it should contact. D is not set until it is known.
  */
 struct NodePeers {
   1: list<NodeInfo> Successors,
-  2: NodeInfo D,
+  2: optional NodeInfo D,
 }
 
 /**
- * NodeStatus contains details about a node.
+ * NodeStatus contains details about a node. Predecessor
+ * is not set until it is known.
  */
 struct NodeStatus {
   1: NodeInfo Info,
   2: NodePeers Peers,
-  3: NodeInfo Predecessor,
+  3: optional NodeInfo Predecessor,
 }
 
 /**
  * RingConfig contains functional parameters of a ring.
+ * MinNodeZeroes is the minimum number of zeroes required in the
+ * hash of node signatures, 0 means any node ID is accepted.
  */
 struct RingConfig {
   1: i32 BruijnM,
@@ -86,6 +89,7 @@ struct RingConfig {
   6: i32 SyncDelay,
   7: i32 DisconnectTimeout,
   8: i32 DataLifetime,
+  9: i32 MinNodeZeroes,
 }
 
 /**
@@ -104,13 +108,24 @@ struct RingInfo {
 
 /**
  * ContextInfo contains static informations about the program
- * calling a fonction, it gives context.
+ * calling a fonction, it gives context. On rings with a password,
+ * Challenge must have been obtained from the target node, and
+ * RingAuth is the HMAC of the request, keyed by the password hash.
+ * The Sig field of requests is the signature of the request by
+ * the source host, it is required on signed rings, and whenever
+ * the source host is able to sign. Timestamp is when the request
+ * was made, in seconds since the epoch, and Nonce a random value
+ * used only once, so that stale or replayed requests are refused.
  */
 struct ContextInfo {
   1: HostInfo SourceHost,
   2: RingInfo SourceRing,
   3: NodeInfo SourceNode,
   4: binary TargetNodeID,
+  5: binary Challenge,
+  6: binary RingAuth,
+  7: i64 Timestamp,
+  8: binary Nonce,
 }
 
 /**
@@ -123,6 +138,22 @@ struct HostStatus {
   4: map<string,HostInfo> HostsRefs,
 }
 
+/**
+ * Used to store Challenge requests.
+ */
+struct ChallengeRequest {
+    1:ContextInfo Context,
+    2:binary Sig,
+}
+
+/**
+ * Used to store results when doing Challenge requests.
+ * The challenge can be used only once, within the call timeout.
+ */
+struct ChallengeResponse {
+  1: binary Challenge,
+}
+
 /**
  * Used to store Lookup-like requests.
  */
@@ -143,6 +174,44 @@ struct LookupResponse {
   3: map<string,HostInfo> HostsRefs,
 }
 
+/**
+ * LookupEntry is a key to look up, along with the state of the
+ * De Bruijn walk, as in LookupRequest.
+ */
+struct LookupEntry {
+  1: binary Key,
+  2: binary KeyShift,
+  3: binary ImaginaryNode,
+}
+
+/**
+ * LookupResult is the result of the lookup of a key.
+ */
+struct LookupResult {
+  1: bool Found,
+  2: list<NodeInfo> NodesPath,
+}
+
+/**
+ * Used to store LookupMany requests. Each entry is looked up as
+ * in Lookup, but entries which have the same next hop are
+ * forwarded together, in a single call.
+ */
+struct LookupManyRequest {
+    1:ContextInfo Context,
+    2:list<LookupEntry> Entries,
+    3:binary Sig,
+}
+
+/**
+ * Used to store results when doing LookupMany requests. Results
+ * are indexed by key, as an hexadecimal string.
+ */
+struct LookupManyResponse {
+  1: map<string,LookupResult> Results,
+  2: map<string,HostInfo> HostsRefs,
+}
+
 /**
  * Used to store  GetSuccessors requests.
  */
@@ -171,7 +240,7 @@ struct GetPredecessorRequest {
  * Used to store results when doing GetPredecessor requests.
  */
 struct GetPredecessorResponse {
-  1: NodeInfo PredecessorNode,
+  1: optional NodeInfo PredecessorNode,
   2: map<string,HostInfo> HostsRefs,
 }
 
@@ -192,10 +261,322 @@ struct SyncResponse {
   1: bool Found,
   2: list<NodeInfo> NodesPath,
   3: list<NodeInfo> SuccessorNodes,
-  4: NodeInfo PredecessorNode,
+  4: optional NodeInfo PredecessorNode,
   5: map<string,HostInfo> HostsRefs,
 }
 
+/**
+ * Used to store Put requests. If Replica is false, the request is
+ * routed to the node holding the key, which then replicates it
+ * on its successors. If Replica is true, the value is stored
+ * on the target node, as is.
+ */
+struct PutRequest {
+    1:ContextInfo Context,
+    2:binary Key,
+    3:binary Value,
+    4:bool Replica,
+    5:binary Sig,
+}
+
+/**
+ * Used to store results when doing Put requests.
+ */
+struct PutResponse {
+  1: i32 NbCopy,
+  2: list<NodeInfo> NodesPath,
+  3: map<string,HostInfo> HostsRefs,
+}
+
+/**
+ * Used to store Get requests. If Replica is true, only the
+ * target node local store is searched.
+ */
+struct GetRequest {
+    1:ContextInfo Context,
+    2:binary Key,
+    3:bool Replica,
+    4:binary Sig,
+}
+
+/**
+ * Used to store results when doing Get requests.
+ */
+struct GetResponse {
+  1: bool Found,
+  2: binary Value,
+  3: list<NodeInfo> NodesPath,
+  4: map<string,HostInfo> HostsRefs,
+}
+
+/**
+ * Used to store Delete requests. If Replica is true, the key
+ * is only removed from the target node local store.
+ */
+struct DeleteRequest {
+    1:ContextInfo Context,
+    2:binary Key,
+    3:bool Replica,
+    4:binary Sig,
+}
+
+/**
+ * Used to store results when doing Delete requests.
+ */
+struct DeleteResponse {
+  1: i32 NbCopy,
+  2: list<NodeInfo> NodesPath,
+  3: map<string,HostInfo> HostsRefs,
+}
+
+/**
+ * DataEntry is a stored key/value pair, TTL being the number
+ * of seconds before it expires.
+ */
+struct DataEntry {
+  1: binary Key,
+  2: binary Value,
+  3: i32 TTL,
+}
+
+/**
+ * DataDigest identifies the value stored for a key, Hash
+ * being the checksum of the value.
+ */
+struct DataDigest {
+  1: binary Key,
+  2: binary Hash,
+}
+
+/**
+ * MerkleNode is a node of the Merkle tree of a store. Prefix is
+ * the path to the node, one byte per level, each byte being the
+ * index of a child. Inner nodes give the hashes of their children,
+ * leaves give the digests of the entries they contain.
+ */
+struct MerkleNode {
+  1: binary Prefix,
+  2: binary Hash,
+  3: list<binary> Children,
+  4: list<DataDigest> Digests,
+}
+
+/**
+ * Used to store Merkle requests. The target node stores Entries,
+ * as replicas, then builds the Merkle tree of the keys it holds
+ * within (RangeStart,RangeEnd], and returns the nodes at Prefixes,
+ * along with the entries for WantedKeys. Nodes use this to repair
+ * their replicas, transferring only the entries which differ.
+ */
+struct MerkleRequest {
+    1:ContextInfo Context,
+    2:binary RangeStart,
+    3:binary RangeEnd,
+    4:list<binary> Prefixes,
+    5:list<DataEntry> Entries,
+    6:list<binary> WantedKeys,
+    7:binary Sig,
+}
+
+/**
+ * Used to store results when doing Merkle requests.
+ */
+struct MerkleResponse {
+  1: list<MerkleNode> Nodes,
+  2: list<DataEntry> Entries,
+}
+
+/**
+ * Used to store Subscribe requests. The topic is hashed into a key,
+ * and the node holding that key keeps the list of subscribers. If
+ * Replica is false, the request is routed to that node, which then
+ * replicates the subscription on its successors. If Replica is true,
+ * the subscription is stored on the target node, as is. Subscriptions
+ * expire after DataLifetime, just like data, subscribers renew them
+ * by subscribing again.
+ */
+struct SubscribeRequest {
+    1:ContextInfo Context,
+    2:string Topic,
+    3:NodeInfo Subscriber,
+    4:bool Replica,
+    5:binary Sig,
+}
+
+/**
+ * Used to store results when doing Subscribe requests.
+ */
+struct SubscribeResponse {
+  1: i32 NbCopy,
+  2: list<NodeInfo> NodesPath,
+  3: map<string,HostInfo> HostsRefs,
+}
+
+/**
+ * Used to store Unsubscribe requests. If Replica is true, the
+ * subscription is only removed from the target node.
+ */
+struct UnsubscribeRequest {
+    1:ContextInfo Context,
+    2:string Topic,
+    3:NodeInfo Subscriber,
+    4:bool Replica,
+    5:binary Sig,
+}
+
+/**
+ * Used to store results when doing Unsubscribe requests.
+ */
+struct UnsubscribeResponse {
+  1: i32 NbCopy,
+  2: list<NodeInfo> NodesPath,
+  3: map<string,HostInfo> HostsRefs,
+}
+
+/**
+ * Used to store Publish requests. If Deliver is false, the request
+ * is routed to the node holding the topic key, which sends the
+ * message to all subscribers, with Deliver set to true. If Deliver
+ * is true, the message is handed to the target node, if it is
+ * subscribed to the topic.
+ */
+struct PublishRequest {
+    1:ContextInfo Context,
+    2:string Topic,
+    3:binary Message,
+    4:bool Deliver,
+    5:binary Sig,
+}
+
+/**
+ * Used to store results when doing Publish requests.
+ */
+struct PublishResponse {
+  1: i32 NbDelivered,
+  2: list<NodeInfo> NodesPath,
+  3: map<string,HostInfo> HostsRefs,
+}
+
+/**
+ * Used to store Leave requests. The source node tells its
+ * neighbours it is leaving the ring, giving its successors and
+ * predecessor so that they can splice the ring.
+ */
+struct LeaveRequest {
+    1:ContextInfo Context,
+    2:list<NodeInfo> SuccessorNodes,
+    3:optional NodeInfo PredecessorNode,
+    4:binary Sig,
+}
+
+/**
+ * Used to store results when doing Leave requests.
+ */
+struct LeaveResponse {
+  1: bool Done,
+}
+
+/**
+ * Used to store AnnounceRing requests. The announced ring is stored
+ * in the ring directory, on the node holding the directory key,
+ * and on its successors. If Replica is true, the ring is stored
+ * on the target node, as is.
+ */
+struct AnnounceRingRequest {
+    1:ContextInfo Context,
+    2:RingInfo Ring,
+    3:bool Replica,
+    4:binary Sig,
+}
+
+/**
+ * Used to store results when doing AnnounceRing requests.
+ */
+struct AnnounceRingResponse {
+  1: i32 NbCopy,
+  2: list<NodeInfo> NodesPath,
+  3: map<string,HostInfo> HostsRefs,
+}
+
+/**
+ * Used to store ListRings requests. Rings are filtered by AppID,
+ * if not empty, and by title prefix. If Replica is true, only the
+ * target node local directory is searched.
+ */
+struct ListRingsRequest {
+    1:ContextInfo Context,
+    2:binary AppID,
+    3:string TitlePrefix,
+    4:bool Replica,
+    5:binary Sig,
+}
+
+/**
+ * Used to store results when doing ListRings requests.
+ */
+struct ListRingsResponse {
+  1: list<RingInfo> Rings,
+  2: list<NodeInfo> NodesPath,
+  3: map<string,HostInfo> HostsRefs,
+}
+
+/**
+ * Used to store SecureMessage requests. The message is sent to the
+ * target node, Payload being encrypted with the symmetric session key
+ * identified by SessionID, which only the source and target hosts know.
+ * The first message of a session carries the session key in SessionKey,
+ * encrypted with the public key of the target host. Later messages
+ * leave SessionKey empty, unless the target host forgot the session.
+ */
+struct SecureMessageRequest {
+    1:ContextInfo Context,
+    2:binary SessionID,
+    3:binary SessionKey,
+    4:binary Payload,
+    5:binary Sig,
+}
+
+/**
+ * Used to store results when doing SecureMessage requests. If
+ * UnknownSession is true, the message has not been read, and must
+ * be sent again, along with the session key.
+ */
+struct SecureMessageResponse {
+  1: bool Delivered,
+  2: bool UnknownSession,
+}
+
+/**
+ * HandshakeInfo describes what a program is able to speak. Peers
+ * with incompatible packages are refused. Peers use the highest
+ * protocol version within both [MinProtocol,MaxProtocol] ranges,
+ * and only the capabilities they both have. Version is informative.
+ */
+struct HandshakeInfo {
+  1: vpcommonapi.Package Package,
+  2: vpcommonapi.Version Version,
+  3: i32 MinProtocol,
+  4: i32 MaxProtocol,
+  5: list<string> Capabilities,
+}
+
+/**
+ * Used to store Handshake requests.
+ */
+struct HandshakeRequest {
+    1:HandshakeInfo Info,
+}
+
+/**
+ * Used to store results when doing Handshake requests. A target
+ * host which refuses to talk with the caller fails the call instead.
+ */
+struct HandshakeResponse {
+  1: HandshakeInfo Info,
+  2: i32 Protocol,
+  3: list<string> Capabilities,
+}
+
 /**
  * VpP2pApi is used to communicate between 2 Vapor nodes
  * in peer-to-peer mode.
@@ -204,9 +585,15 @@ service VpP2pApi extends vpcommonapi.VpCommonApi
 {
   HostStatus Status(
   ),
+  ChallengeResponse Challenge(
+    1:ChallengeRequest request,
+  ),
   LookupResponse Lookup(
     1:LookupRequest request,
   ),
+  LookupManyResponse LookupMany(
+    1:LookupManyRequest request,
+  ),
   GetSuccessorsResponse GetSuccessors(
     1:GetSuccessorsRequest request,
   ),
@@ -216,4 +603,40 @@ service VpP2pApi extends vpcommonapi.VpCommonApi
   SyncResponse Sync(
     1:SyncRequest request,
   ),
+  PutResponse Put(
+    1:PutRequest request,
+  ),
+  GetResponse Get(
+    1:GetRequest request,
+  ),
+  DeleteResponse Delete(
+    1:DeleteRequest request,
+  ),
+  MerkleResponse Merkle(
+    1:MerkleRequest request,
+  ),
+  LeaveResponse Leave(
+    1:LeaveRequest request,
+  ),
+  AnnounceRingResponse AnnounceRing(
+    1:AnnounceRingRequest request,
+  ),
+  ListRingsResponse ListRings(
+    1:ListRingsRequest request,
+  ),
+  SubscribeResponse Subscribe(
+    1:SubscribeRequest request,
+  ),
+  UnsubscribeResponse Unsubscribe(
+    1:UnsubscribeRequest request,
+  ),
+  PublishResponse Publish(
+    1:PublishRequest request,
+  ),
+  HandshakeResponse Handshake(
+    1:HandshakeRequest request,
+  ),
+  SecureMessageResponse SecureMessage(
+    1:SecureMessageRequest request,
+  ),
 }